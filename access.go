@@ -0,0 +1,200 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"gopkg.in/mgo.v2"
+)
+
+// CanAccessProject 메소드는 사용자가 프로젝트에 접근할 수 있는지 체크한다.
+// AccessProjects가 비어있다면 모든 프로젝트에 접근할 수 있다. Admin은 항상 모든 프로젝트에 접근할 수 있다.
+// 개인 토큰으로 요청했다면 토큰에 허용된 프로젝트(TokenProjects)와 겹치는 프로젝트만 접근할 수 있다.
+func (u User) CanAccessProject(project string) bool {
+	if len(u.TokenProjects) != 0 && !hasString(u.TokenProjects, project) {
+		return false
+	}
+	if u.AccessLevel >= AdminAccessLevel || len(u.AccessProjects) == 0 {
+		return true
+	}
+	return hasString(u.AccessProjects, project)
+}
+
+// AccessibleProjects 메소드는 프로젝트 리스트에서 사용자가 접근할 수 있는 프로젝트만 순서대로 반환한다.
+func (u User) AccessibleProjects(projects []string) []string {
+	var results []string
+	for _, p := range projects {
+		if u.CanAccessProject(p) {
+			results = append(results, p)
+		}
+	}
+	return results
+}
+
+// checkProjectAccess 함수는 사용자가 모든 프로젝트에 접근할 수 있는지 체크한다.
+func checkProjectAccess(u User, projects []string) error {
+	for _, p := range projects {
+		if len(u.TokenProjects) != 0 && !hasString(u.TokenProjects, p) {
+			return fmt.Errorf("%s 사용자의 토큰은 %s 프로젝트에 접근할 수 없습니다", u.ID, p)
+		}
+		if !u.CanAccessProject(p) {
+			return fmt.Errorf("%s 사용자는 %s 프로젝트에 접근할 수 없습니다", u.ID, p)
+		}
+	}
+	return nil
+}
+
+// requestProjects 함수는 요청이 다루는 프로젝트 이름을 모두 가지고 온다.
+// project, Project 폼값과 /api/v3/projects/{project} 경로를 사용한다.
+func requestProjects(r *http.Request) []string {
+	r.FormValue("project") // 폼을 파싱한다.
+	var projects []string
+	for _, key := range []string{"project", "Project"} {
+		for _, p := range r.Form[key] {
+			if p != "" && !hasString(projects, p) {
+				projects = append(projects, p)
+			}
+		}
+	}
+	if strings.HasPrefix(r.URL.Path, "/api/v3/projects/") {
+		p := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v3/projects/"), "/")[0]
+		if p != "" && !hasString(projects, p) {
+			projects = append(projects, p)
+		}
+	}
+	return projects
+}
+
+// requestUser 함수는 요청의 토큰 또는 로그인 세션으로 사용자를 가지고 온다.
+// 개인 토큰으로 요청했다면 토큰에 허용된 프로젝트를 사용자의 TokenProjects로 설정한다.
+// 토큰과 세션이 모두 없다면 에러를 반환한다.
+func requestUser(r *http.Request, session *mgo.Session) (User, error) {
+	if key, err := GetTokenFromHeader(r); err == nil {
+		id, err := tokenUserID(session, key)
+		if err != nil {
+			return User{}, err
+		}
+		u, err := getUser(session, id)
+		if err != nil {
+			return u, err
+		}
+		if t, ok := requestAPIToken(r, session); ok {
+			u.TokenProjects = t.Projects
+		}
+		return u, nil
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		return User{}, err
+	}
+	return getUser(session, ssid.ID)
+}
+
+// requestProjectAccess 함수는 요청한 사용자가 요청이 다루는 프로젝트에 접근할 수 있는지 체크한다.
+// 프로젝트를 다루지 않거나 토큰, 세션이 없거나 유효하지 않은 요청은 체크하지 않고 인증은 각 핸들러에 맡긴다.
+func requestProjectAccess(r *http.Request) error {
+	projects := requestProjects(r)
+	if len(projects) == 0 {
+		return nil
+	}
+	key, tokenErr := GetTokenFromHeader(r)
+	ssid, sessionErr := GetSessionID(r)
+	if tokenErr != nil && sessionErr != nil {
+		return nil
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+	id := ssid.ID
+	if tokenErr == nil {
+		id, err = tokenUserID(session, key)
+		if err != nil {
+			return nil
+		}
+	}
+	u, err := getUser(session, id)
+	if err != nil {
+		return err
+	}
+	return checkProjectAccess(u, projects)
+}
+
+// projectAccessHandler 함수는 웹페이지 요청의 project 값을 로그인 사용자의 AccessProjects로 체크한다.
+// /api 요청은 apiHandler가 토큰으로 체크한다.
+func projectAccessHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/api/") || strings.HasPrefix(r.URL.Path, "/api2/") || strings.HasPrefix(r.URL.Path, "/assets/") {
+			h.ServeHTTP(w, r)
+			return
+		}
+		err := requestProjectAccess(r)
+		if err != nil {
+			http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// grantProjects 함수는 프로젝트 리스트에 projects를 추가한다. 이미 있는 프로젝트는 추가하지 않는다.
+func grantProjects(list, projects []string) []string {
+	results := append([]string{}, list...)
+	for _, p := range projects {
+		if p != "" && !hasString(results, p) {
+			results = append(results, p)
+		}
+	}
+	return results
+}
+
+// revokeProjects 함수는 프로젝트 리스트에서 projects를 제거한다.
+// AccessProjects가 비어있으면 모든 프로젝트에 접근할 수 있으므로 모든 프로젝트가 제거되는 경우 에러를 반환한다.
+func revokeProjects(list, projects []string) ([]string, error) {
+	var results []string
+	for _, p := range list {
+		if !hasString(projects, p) {
+			results = append(results, p)
+		}
+	}
+	if len(list) != 0 && len(results) == 0 {
+		return list, errors.New("모든 프로젝트를 회수하면 전체 프로젝트에 접근할 수 있게 됩니다. 퇴사처리 또는 엑세스 레벨을 조정해주세요")
+	}
+	return results, nil
+}
+
+// InTeam 메소드는 사용자의 조직정보에 팀 ID가 있는지 체크한다.
+func (u User) InTeam(team string) bool {
+	for _, o := range u.Organizations {
+		if o.Team.ID == team {
+			return true
+		}
+	}
+	return false
+}
+
+// accessiblePlaylistEntries 함수는 플레이리스트 항목 중 사용자가 접근할 수 있는 프로젝트의 항목만 반환한다.
+// 플레이리스트는 여러 프로젝트의 mov를 담을 수 있으므로 프로젝트 단위로 거른다.
+func accessiblePlaylistEntries(u User, entries []PlaylistEntry) []PlaylistEntry {
+	results := []PlaylistEntry{}
+	for _, e := range entries {
+		if u.CanAccessProject(e.Project) {
+			results = append(results, e)
+		}
+	}
+	return results
+}
+
+// playlistProjects 함수는 플레이리스트 항목의 프로젝트를 중복없이 순서대로 반환한다.
+func playlistProjects(entries []PlaylistEntry) []string {
+	var projects []string
+	for _, e := range entries {
+		if !hasString(projects, e.Project) {
+			projects = append(projects, e.Project)
+		}
+	}
+	return projects
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCanAccessProject(t *testing.T) {
+	artist := User{ID: "artist", AccessLevel: ArtistAccessLevel, AccessProjects: []string{"circle", "TEMP"}}
+	all := User{ID: "lead", AccessLevel: LeadAccessLevel}
+	admin := User{ID: "admin", AccessLevel: AdminAccessLevel, AccessProjects: []string{"TEMP"}}
+	cases := []struct {
+		user    User
+		project string
+		want    bool
+	}{
+		{artist, "circle", true},
+		{artist, "TEMP", true},
+		{artist, "secret", false},
+		{artist, "", false},
+		{all, "secret", true},   // AccessProjects가 비어있으면 모든 프로젝트에 접근할 수 있다.
+		{admin, "secret", true}, // Admin은 항상 접근할 수 있다.
+	}
+	for _, c := range cases {
+		if got := c.user.CanAccessProject(c.project); got != c.want {
+			t.Fatalf("%s %s: 얻은 값 %v 원하는 값 %v", c.user.ID, c.project, got, c.want)
+		}
+	}
+	if got := artist.AccessibleProjects([]string{"secret", "TEMP", "circle"}); !reflect.DeepEqual(got, []string{"TEMP", "circle"}) {
+		t.Fatalf("AccessibleProjects: %v", got)
+	}
+	if err := checkProjectAccess(artist, []string{"circle", "secret"}); err == nil {
+		t.Fatal("secret 프로젝트는 접근할 수 없어야 합니다")
+	}
+	if err := checkProjectAccess(artist, []string{"circle", "TEMP"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRequestProjects(t *testing.T) {
+	cases := []struct {
+		req  *http.Request
+		want []string
+	}{
+		{httptest.NewRequest(http.MethodGet, "/api/items?project=circle&searchword=SS", nil), []string{"circle"}},
+		{httptest.NewRequest(http.MethodGet, "/inputmode?project=circle&project=secret", nil), []string{"circle", "secret"}},
+		{httptest.NewRequest(http.MethodGet, "/api/v3/projects/secret/items/SS_0010_org/tasks", nil), []string{"secret"}},
+		{httptest.NewRequest(http.MethodGet, "/api/projects", nil), nil},
+	}
+	post := httptest.NewRequest(http.MethodPost, "/edititem-submit?project=circle", strings.NewReader("Project=secret&name=SS_0010"))
+	post.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	cases = append(cases, struct {
+		req  *http.Request
+		want []string
+	}{post, []string{"circle", "secret"}})
+	for _, c := range cases {
+		if got := requestProjects(c.req); !reflect.DeepEqual(got, c.want) {
+			t.Fatalf("%s: 얻은 값 %v 원하는 값 %v", c.req.URL, got, c.want)
+		}
+	}
+	// JSON 요청은 apiHandler가 폼으로 바꾼 뒤에 체크한다.
+	req := httptest.NewRequest(http.MethodPost, "/api/settaskstatus", strings.NewReader(`{"project":"secret","name":"SS_0010"}`))
+	req.Header.Set("Content-Type", "application/json")
+	if err := jsonBodyToForm(req); err != nil {
+		t.Fatal(err)
+	}
+	if got := requestProjects(req); !reflect.DeepEqual(got, []string{"secret"}) {
+		t.Fatalf("json: %v", got)
+	}
+}
+
+func TestRequestProjectAccessWithoutCredential(t *testing.T) {
+	// 토큰과 세션이 없는 요청은 DB에 접속하지 않고 각 핸들러의 인증에 맡긴다.
+	req := httptest.NewRequest(http.MethodGet, "/api/items?project=secret", nil)
+	if err := requestProjectAccess(req); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGrantRevokeProjects(t *testing.T) {
+	if got := grantProjects([]string{"TEMP"}, []string{"circle", "TEMP", ""}); !reflect.DeepEqual(got, []string{"TEMP", "circle"}) {
+		t.Fatalf("grant: %v", got)
+	}
+	got, err := revokeProjects([]string{"TEMP", "circle"}, []string{"TEMP"})
+	if err != nil || !reflect.DeepEqual(got, []string{"circle"}) {
+		t.Fatalf("revoke: %v %v", got, err)
+	}
+	// 모든 프로젝트를 회수하면 전체 프로젝트에 접근할 수 있게 되므로 에러가 발생해야 한다.
+	if _, err := revokeProjects([]string{"circle"}, []string{"circle"}); err == nil {
+		t.Fatal("모든 프로젝트 회수는 에러가 발생해야 합니다")
+	}
+	u := User{Organizations: []Organization{{Team: Team{ID: "comp", Name: "Comp"}}}}
+	if !u.InTeam("comp") || u.InTeam("fx") {
+		t.Fatal("InTeam")
+	}
+}
+
+func TestAccessiblePlaylistEntries(t *testing.T) {
+	u := User{ID: "artist", AccessLevel: ArtistAccessLevel, AccessProjects: []string{"circle"}}
+	entries := []PlaylistEntry{
+		{Project: "circle", Item: "SS_0010_org"},
+		{Project: "secret", Item: "SS_0020_org"},
+	}
+	got := accessiblePlaylistEntries(u, entries)
+	if len(got) != 1 || got[0].Project != "circle" {
+		t.Fatalf("entries: %+v", got)
+	}
+}
+
+func TestTokenProjects(t *testing.T) {
+	// 개인 토큰으로 요청하면 requestUser가 토큰에 허용된 프로젝트를 TokenProjects로 설정한다.
+	admin := User{ID: "admin", AccessLevel: AdminAccessLevel, TokenProjects: []string{"circle"}}
+	artist := User{ID: "artist", AccessLevel: ArtistAccessLevel, AccessProjects: []string{"circle", "TEMP"}, TokenProjects: []string{"TEMP", "secret"}}
+	cases := []struct {
+		user    User
+		project string
+		want    bool
+	}{
+		{admin, "circle", true},
+		{admin, "secret", false}, // Admin도 토큰에 허용된 프로젝트만 접근할 수 있다.
+		{artist, "TEMP", true},
+		{artist, "circle", false}, // 토큰에 허용되지 않은 프로젝트
+		{artist, "secret", false}, // 사용자에게 허용되지 않은 프로젝트
+	}
+	for _, c := range cases {
+		if got := c.user.CanAccessProject(c.project); got != c.want {
+			t.Fatalf("%s %s: 얻은 값 %v 원하는 값 %v", c.user.ID, c.project, got, c.want)
+		}
+	}
+	// /api/project?id=secret
+	if err := checkProjectAccess(admin, []string{"secret"}); err == nil {
+		t.Fatal("토큰에 허용되지 않은 프로젝트는 조회할 수 없어야 합니다")
+	}
+	if err := checkProjectAccess(admin, []string{"circle"}); err != nil {
+		t.Fatal(err)
+	}
+	// /api/projects
+	projects := []Project{
+		{ID: "circle", Status: PostProjectStatus},
+		{ID: "secret", Status: PostProjectStatus},
+		{ID: "TEMP", Status: PreProjectStatus},
+	}
+	if got := accessibleProjectIDs(admin, projects, ""); !reflect.DeepEqual(got, []string{"circle"}) {
+		t.Fatalf("/api/projects: %v", got)
+	}
+	if got := accessibleProjectIDs(User{ID: "admin", AccessLevel: AdminAccessLevel}, projects, "post"); !reflect.DeepEqual(got, []string{"circle", "secret"}) {
+		t.Fatalf("/api/projects?status=post: %v", got)
+	}
+	// 플레이리스트
+	entries := []PlaylistEntry{{Project: "circle"}, {Project: "secret"}}
+	if got := accessiblePlaylistEntries(admin, entries); len(got) != 1 || got[0].Project != "circle" {
+		t.Fatalf("entries: %+v", got)
+	}
+}
+
+func TestPlaylistProjects(t *testing.T) {
+	entries := []PlaylistEntry{{Project: "circle"}, {Project: "secret"}, {Project: "circle"}}
+	if got := playlistProjects(entries); !reflect.DeepEqual(got, []string{"circle", "secret"}) {
+		t.Fatalf("얻은 값 %v", got)
+	}
+	u := User{ID: "pipeline", AccessLevel: AdminAccessLevel, TokenProjects: []string{"circle"}}
+	if err := checkProjectAccess(u, playlistProjects(entries)); err == nil {
+		t.Fatal("토큰에 허용되지 않은 프로젝트의 항목은 추가할 수 없어야 합니다")
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// APIToken 자료구조는 사용자가 직접 발급하는 개인 restAPI 토큰이다.
+// 사용자마다 여러개를 발급할 수 있고, 권한범위(Scope)와 만료일을 가진다.
+// 토큰 키는 발급할 때 한번만 보여주고 DB에는 해시값만 저장한다.
+type APIToken struct {
+	ID         string   `json:"id"`         // 토큰 ID
+	UserID     string   `json:"userid"`     // 토큰을 발급한 사용자 ID
+	Name       string   `json:"name"`       // 토큰 이름. 예) nuke-publish
+	Hash       string   `json:"hash"`       // 토큰 키의 sha256 해시값
+	Prefix     string   `json:"prefix"`     // 토큰을 구분하기 위해 보여주는 토큰 키의 앞부분
+	ReadOnly   bool     `json:"readonly"`   // 조회 요청만 허용
+	Projects   []string `json:"projects"`   // 허용된 프로젝트. 비어있으면 사용자가 접근할 수 있는 모든 프로젝트
+	Endpoints  []string `json:"endpoints"`  // 허용된 /api 경로. 비어있으면 모든 경로. /api/v3/* 처럼 *로 끝나면 앞부분이 같은 경로를 허용한다.
+	Expires    string   `json:"expires"`    // 만료시간 RFC3339. 빈 문자열이면 만료되지 않는다.
+	Createtime string   `json:"createtime"` // 발급시간 RFC3339
+	Lastused   string   `json:"lastused"`   // 마지막 사용시간 RFC3339
+}
+
+// apiTokenPrefix 는 개인 토큰 키의 접두어이다. 기존 토큰과 구분하기 위해 사용한다.
+const apiTokenPrefix = "csi_"
+
+// readOnlyPostPaths 는 POST 메소드를 사용하지만 조회만 하는 /api 경로이다. 읽기전용 토큰에서 허용한다.
+var readOnlyPostPaths = []string{
+	"/api/categorytasksettings",
+	"/api/deadline2d",
+	"/api/deadline3d",
+	"/api/mailinfo",
+	"/api/search",
+	"/api/shottype",
+	"/api/task",
+	"/api/tasksetting",
+	"/api/timeinfo",
+	"/api/validuser",
+}
+
+// newAPITokenKey 함수는 새 토큰 키를 만든다.
+func newAPITokenKey() (string, error) {
+	b := make([]byte, 24)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return apiTokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashAPITokenKey 함수는 토큰 키의 sha256 해시값을 반환한다.
+func hashAPITokenKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// apiTokenExpires 함수는 만료일(2006-01-02)을 그날이 끝나는 시간의 RFC3339 문자열로 바꾼다. 빈 문자열은 만료되지 않는 토큰이다.
+func apiTokenExpires(date string, now time.Time) (string, error) {
+	if date == "" {
+		return "", nil
+	}
+	d, err := time.ParseInLocation("2006-01-02", date, now.Location())
+	if err != nil {
+		return "", fmt.Errorf("만료일 %s 은 2006-01-02 형태로 입력해주세요", date)
+	}
+	end := d.Add(24*time.Hour - time.Second)
+	if end.Before(now) {
+		return "", errors.New("만료일은 오늘 이후여야 합니다")
+	}
+	return end.Format(time.RFC3339), nil
+}
+
+// checkAPITokenEndpoints 함수는 허용할 경로가 /api 경로인지 체크한다.
+func checkAPITokenEndpoints(endpoints []string) error {
+	for _, e := range endpoints {
+		if !strings.HasPrefix(e, "/api/") {
+			return fmt.Errorf("%s 는 /api/ 로 시작하는 경로가 아닙니다", e)
+		}
+		if strings.Contains(strings.TrimSuffix(e, "*"), "*") {
+			return fmt.Errorf("%s: *는 경로의 마지막에만 사용할 수 있습니다", e)
+		}
+	}
+	return nil
+}
+
+// expired 메소드는 토큰이 만료되었는지 체크한다.
+func (t APIToken) expired(now time.Time) bool {
+	if t.Expires == "" {
+		return false
+	}
+	exp, err := time.Parse(time.RFC3339, t.Expires)
+	if err != nil {
+		return true
+	}
+	return now.After(exp)
+}
+
+// allowEndpoint 메소드는 토큰으로 경로를 요청할 수 있는지 체크한다.
+func (t APIToken) allowEndpoint(path string) bool {
+	if len(t.Endpoints) == 0 {
+		return true
+	}
+	for _, e := range t.Endpoints {
+		if strings.HasSuffix(e, "*") && strings.HasPrefix(path, strings.TrimSuffix(e, "*")) {
+			return true
+		}
+		if e == path {
+			return true
+		}
+	}
+	return false
+}
+
+// allowProject 메소드는 토큰으로 프로젝트에 접근할 수 있는지 체크한다.
+func (t APIToken) allowProject(project string) bool {
+	return len(t.Projects) == 0 || hasString(t.Projects, project)
+}
+
+// readOnlyRequest 함수는 조회만 하는 요청인지 체크한다.
+func readOnlyRequest(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		return hasString(readOnlyPostPaths, r.URL.Path)
+	}
+	return false
+}
+
+// allow 메소드는 토큰의 만료일과 권한범위로 요청을 허용할지 체크한다.
+func (t APIToken) allow(r *http.Request, now time.Time) error {
+	if t.expired(now) {
+		return fmt.Errorf("%s 토큰이 만료되었습니다", t.Name)
+	}
+	if t.ReadOnly && !readOnlyRequest(r) {
+		return fmt.Errorf("%s 토큰은 읽기전용입니다", t.Name)
+	}
+	if !t.allowEndpoint(r.URL.Path) {
+		return fmt.Errorf("%s 토큰은 %s 경로를 사용할 수 없습니다", t.Name, r.URL.Path)
+	}
+	for _, p := range requestProjects(r) {
+		if !t.allowProject(p) {
+			return fmt.Errorf("%s 토큰은 %s 프로젝트에 접근할 수 없습니다", t.Name, p)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAPITokenKey(t *testing.T) {
+	a, err := newAPITokenKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := newAPITokenKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(a, apiTokenPrefix) || a == b {
+		t.Fatalf("key: %s %s", a, b)
+	}
+	if hashAPITokenKey(a) != hashAPITokenKey(a) || hashAPITokenKey(a) == hashAPITokenKey(b) || len(hashAPITokenKey(a)) != 64 {
+		t.Fatal("hash")
+	}
+}
+
+func TestAPITokenExpires(t *testing.T) {
+	now := time.Date(2020, 3, 10, 15, 0, 0, 0, time.UTC)
+	got, err := apiTokenExpires("2020-03-10", now)
+	if err != nil || got != "2020-03-10T23:59:59Z" {
+		t.Fatalf("얻은 값 %s %v", got, err)
+	}
+	if got, err := apiTokenExpires("", now); err != nil || got != "" {
+		t.Fatalf("빈 만료일: %s %v", got, err)
+	}
+	for _, date := range []string{"2020-03-09", "0310", "2020/03/11"} {
+		if _, err := apiTokenExpires(date, now); err == nil {
+			t.Fatalf("%s: 에러가 발생해야 합니다", date)
+		}
+	}
+	token := APIToken{Expires: "2020-03-10T23:59:59Z"}
+	if token.expired(now) || !token.expired(now.Add(9*time.Hour)) {
+		t.Fatal("expired")
+	}
+	if (APIToken{}).expired(now) {
+		t.Fatal("만료일이 없는 토큰은 만료되지 않습니다")
+	}
+}
+
+func TestCheckAPITokenEndpoints(t *testing.T) {
+	if err := checkAPITokenEndpoints([]string{"/api/items", "/api/v3/*"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range []string{"/edititem", "/api/*/items", "api/items"} {
+		if err := checkAPITokenEndpoints([]string{e}); err == nil {
+			t.Fatalf("%s: 에러가 발생해야 합니다", e)
+		}
+	}
+}
+
+func TestAPITokenAllow(t *testing.T) {
+	now := time.Date(2020, 3, 10, 15, 0, 0, 0, time.UTC)
+	pipeline := APIToken{
+		Name:      "pipeline",
+		ReadOnly:  true,
+		Projects:  []string{"circle"},
+		Endpoints: []string{"/api/items", "/api/search", "/api/v3/*"},
+		Expires:   "2020-03-31T23:59:59Z",
+	}
+	form := func(path, body string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return r
+	}
+	cases := []struct {
+		token APIToken
+		req   *http.Request
+		ok    bool
+	}{
+		{pipeline, httptest.NewRequest(http.MethodGet, "/api/items?project=circle", nil), true},
+		{pipeline, form("/api/search", "project=circle&searchword=SS"), true},
+		{pipeline, httptest.NewRequest(http.MethodGet, "/api/v3/projects/circle/items/SS_0010_org", nil), true},
+		{pipeline, httptest.NewRequest(http.MethodGet, "/api/items?project=secret", nil), false},                   // 프로젝트
+		{pipeline, httptest.NewRequest(http.MethodGet, "/api/v3/projects/secret/items/SS_0010_org", nil), false},   // 경로의 프로젝트
+		{pipeline, httptest.NewRequest(http.MethodGet, "/api/users", nil), false},                                  // 경로
+		{pipeline, httptest.NewRequest(http.MethodPatch, "/api/v3/projects/circle/items/SS_0010_org", nil), false}, // 읽기전용
+		{pipeline, form("/api/settaskstatus", "project=circle"), false},
+		{APIToken{Name: "all"}, form("/api/settaskstatus", "project=secret"), true},
+		{APIToken{Name: "expired", Expires: "2020-03-01T00:00:00Z"}, httptest.NewRequest(http.MethodGet, "/api/projects", nil), false},
+	}
+	for _, c := range cases {
+		err := c.token.allow(c.req, now)
+		if (err == nil) != c.ok {
+			t.Fatalf("%s %s %s: %v", c.token.Name, c.req.Method, c.req.URL, err)
+		}
+	}
+}
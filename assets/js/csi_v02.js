@@ -40,26 +40,23 @@ function selectmode() {
 		}
 	}
 
-	if (onnum == 9) {
-		for(var i=0; i<document.getElementsByClassName('StatusCheckBox').length;i++) {
-			document.getElementsByClassName('StatusCheckBox')[i].checked=false
+	var boxes = document.getElementsByClassName('StatusCheckBox')
+	if (onnum == boxes.length) {
+		for(var i=0; i<boxes.length;i++) {
+			boxes[i].checked=false
 		}
 	} else if (onnum == 0) {
 		// 이 모드는 자주 사용하는 사용자 선택패턴이다.
-		document.getElementsByClassName('StatusCheckBox')[0].checked=true // assign
-		document.getElementsByClassName('StatusCheckBox')[1].checked=true // ready
-		document.getElementsByClassName('StatusCheckBox')[2].checked=true // wip
-		document.getElementsByClassName('StatusCheckBox')[3].checked=true // confirm
-		document.getElementsByClassName('StatusCheckBox')[4].checked=false // done
-		document.getElementsByClassName('StatusCheckBox')[5].checked=false // out
-		document.getElementsByClassName('StatusCheckBox')[6].checked=false // omit
-		document.getElementsByClassName('StatusCheckBox')[7].checked=false // hold
-		document.getElementsByClassName('StatusCheckBox')[8].checked=false // none
+		// 프로젝트 상태설정에서 기본으로 체크되는 상태만 켠다.
+		for(var i=0; i<boxes.length;i++) {
+			boxes[i].checked = (boxes[i].dataset.defaulton == "true")
+		}
 	} else {
-		for(var i=0; i<document.getElementsByClassName('StatusCheckBox').length;i++) {
-			document.getElementsByClassName('StatusCheckBox')[i].checked=true
+		for(var i=0; i<boxes.length;i++) {
+			boxes[i].checked=true
 		}
 	}
+	changeStatusURI()
 }
 
 
@@ -1,3 +1,20 @@
+// /api 응답은 {"data":...,"error":{"code":"...","message":"..."}} 형태이다.
+// 기존 코드에서 바로 사용할 수 있도록 성공한 응답은 data 값만 전달한다.
+$.ajaxSetup({
+    dataFilter: function(data, type) {
+        if (type !== "json") {
+            return data
+        }
+        try {
+            let res = JSON.parse(data)
+            if (res !== null && typeof res === "object" && "data" in res && "error" in res && res.error === null) {
+                return JSON.stringify(res.data)
+            }
+        } catch (e) {}
+        return data
+    }
+})
+
 // modal이 뜨면 오토포커스가 되어야 한다.
 $('#modal-addcomment').on('shown.bs.modal', function () {
     $('#modal-addcomment-text').trigger('focus')
@@ -1658,7 +1675,7 @@ function setTaskStatus(project, id, task, status) {
                 },
                 dataType: "json",
                 success: function(data) {
-                    document.getElementById(`${data.name}-task-${data.task}-status`).innerHTML = `<a class="mt-1 badge badge-${data.status} statusbox" title="${data.status}">${data.task}</a>`;
+                    document.getElementById(`${data.name}-task-${data.task}-status`).innerHTML = `<a class="mt-1 badge statusbox" style="background-color: ${data.color};" title="${data.status}">${data.task}</a>`;
                 },
                 error: function(request,status,error){
                     alert("code:"+request.status+"\n"+"status:"+status+"\n"+"msg:"+request.responseText+"\n"+"error:"+error);
@@ -1681,7 +1698,7 @@ function setTaskStatus(project, id, task, status) {
             },
             dataType: "json",
             success: function(data) {
-                document.getElementById(`${data.name}-task-${data.task}-status`).innerHTML = `<a class="mt-1 badge badge-${data.status} statusbox" title="${data.status}">${data.task}</a>`;
+                document.getElementById(`${data.name}-task-${data.task}-status`).innerHTML = `<a class="mt-1 badge statusbox" style="background-color: ${data.color};" title="${data.status}">${data.task}</a>`;
             },
             error: function(request,status,error){
                 alert("code:"+request.status+"\n"+"status:"+status+"\n"+"msg:"+request.responseText+"\n"+"error:"+error);
@@ -2672,32 +2689,6 @@ function setObjectID(project, id, innum, outnum) {
     });
 }
 
-function setCrowdAsset(project, id) {
-    let token = document.getElementById("token").value;
-    $.ajax({
-        url: "/api/setcrowdasset",
-        type: "post",
-        data: {
-            project: project,
-            name: id2name(id),
-        },
-        headers: {
-            "Authorization": "Basic "+ token
-        },
-        dataType: "json",
-        success: function(data) {
-            if (data.crowdasset) {
-                document.getElementById("crowdasset-"+data.id).innerHTML = `<span class="badge badge-warning finger" onclick="setCrowdAsset('${data.project}', '${data.id}')">Crowd</span>`;
-            } else {
-                document.getElementById("crowdasset-"+data.id).innerHTML = `<span class="badge badge-light fade finger" onclick="setCrowdAsset('${data.project}', '${data.id}')">Crowd</span>`;
-            }
-        },
-        error: function(request,status,error){
-            alert("code:"+request.status+"\n"+"status:"+status+"\n"+"msg:"+request.responseText+"\n"+"error:"+error);
-        }
-    });
-}
-
 function setPlatesizeModal(project, id) {
     let token = document.getElementById("token").value;
     document.getElementById("modal-platesize-project").value = project
@@ -3205,15 +3196,17 @@ function setRmTaskModal(project, id, type) {
     }
 }
 
-function changeStatusURI(status) {
+function changeStatusURI() {
+    let boxes = document.getElementsByClassName("StatusCheckBox");
+    let ids = [];
+    for (let i = 0; i < boxes.length; i++) {
+        if (boxes[i].checked) {
+            ids.push(boxes[i].value);
+        }
+    }
     let tags = document.getElementsByClassName("statusuri");
     for ( var i = 0; i < tags.length; i++) {
-        let c = document.getElementById("searchbox-checkbox-" + status);
-        if (tags[i].href.includes(status + "=true")) {
-            tags[i].href = tags[i].href.replace(status + "=true", status + "=" + c.checked)
-        } else {
-            tags[i].href = tags[i].href.replace(status + "=false", status + "=" + c.checked)
-        }
+        tags[i].href = tags[i].href.replace(/truestatus=[^&]*/, "truestatus=" + ids.join(","));
     }
 }
 
@@ -3246,4 +3239,128 @@ function mailInfo(project, id) {
             alert("code:"+request.status+"\n"+"status:"+status+"\n"+"msg:"+request.responseText+"\n"+"error:"+error);
         }
     });   
-}
\ No newline at end of file
+}
+// subscribeItemEvents 함수는 /api/events 를 구독하여 다른 사용자가 수정한 아이템을 새로고침 없이 화면에 반영한다.
+// id를 입력하면 해당 아이템의 이벤트만 받는다.
+function subscribeItemEvents(project, id) {
+    if (!window.EventSource || !project) {
+        return
+    }
+    let url = "/api/events?project=" + encodeURIComponent(project);
+    if (id) {
+        url += "&id=" + encodeURIComponent(id);
+    }
+    let source = new EventSource(url);
+    source.addEventListener("item", function(e) {
+        let event = JSON.parse(e.data);
+        if (id) {
+            showItemChanged(event);
+        } else {
+            updateItemRow(event);
+        }
+    });
+}
+
+// showItemChanged 함수는 상세페이지에서 아이템이 수정되었음을 알린다.
+function showItemChanged(event) {
+    let alert = document.getElementById("item-changed");
+    if (!alert) {
+        alert = document.createElement("div");
+        alert.id = "item-changed";
+        alert.className = "alert alert-warning text-center m-2";
+        document.body.insertBefore(alert, document.body.firstChild);
+    }
+    if (event.type === "remove") {
+        alert.innerHTML = `${event.author} 님이 아이템을 삭제했습니다.`;
+        return
+    }
+    alert.innerHTML = `${event.author} 님이 ${event.fields.join(", ")} 값을 수정했습니다. <a href="javascript:location.reload();">새로고침</a>`;
+}
+
+// statusColors 는 이벤트로 Task 상태를 갱신할 때 사용하는 프로젝트 상태색상이다.
+let statusColors = {};
+
+// updateItemRow 함수는 검색페이지에서 이벤트가 발생한 아이템의 값을 갱신한다.
+function updateItemRow(event) {
+    let row = document.getElementById("item-" + event.id);
+    if (!row) {
+        return // 현재 페이지에 없는 아이템이다.
+    }
+    if (event.type === "remove") {
+        row.style.opacity = 0.3;
+        return
+    }
+    let token = document.getElementById("token").value;
+    $.ajax({
+        url: `/api/v3/projects/${encodeURIComponent(event.project)}/items/${encodeURIComponent(event.id)}`,
+        type: "get",
+        headers: {
+            "Authorization": "Basic "+ token
+        },
+        dataType: "json",
+        success: function(item) {
+            let name = item.name;
+            for (let field of event.fields) {
+                let e;
+                switch (field) {
+                    case "platein":
+                    case "plateout":
+                        e = document.getElementById(`${field}-${name}`);
+                        if (e) e.innerHTML = `<span class="text-white black-opbg" title="${field}">${item[field]}</span>`;
+                        break;
+                    case "justin":
+                    case "justout":
+                        e = document.getElementById(`${field}-${name}`);
+                        if (e) e.innerHTML = `<span class="text-warning black-opbg" title="${field}">${item[field]}</span>`;
+                        break;
+                    case "handlein":
+                    case "handleout":
+                        e = document.getElementById(`${field}-${name}`);
+                        if (e) e.innerHTML = item[field];
+                        break;
+                    default:
+                        let m = field.match(/^tasks\.(\w+)\.status$/);
+                        if (m && item.tasks[m[1]]) {
+                            setStatusBadge(event.project, name, m[1], item.tasks[m[1]].status);
+                        }
+                }
+            }
+            let update = document.getElementById("update-" + name);
+            if (update) {
+                update.innerHTML = `<span class="badge badge-warning ml-1 mr-1" title="${event.fields.join(", ")}">UPDATE ${event.author}</span>`;
+            }
+        }
+    });
+}
+
+// setStatusBadge 함수는 Task 상태 뱃지를 프로젝트 상태색상으로 갱신한다.
+function setStatusBadge(project, name, task, status) {
+    let e = document.getElementById(`${name}-task-${task}-status`);
+    if (!e) {
+        return
+    }
+    let render = function() {
+        let color = statusColors[project][status] || "";
+        e.innerHTML = `<a class="mt-1 badge statusbox" style="background-color: ${color};" title="${status}">${task}</a>`;
+    };
+    if (statusColors[project]) {
+        render();
+        return
+    }
+    let token = document.getElementById("token").value;
+    $.ajax({
+        url: "/api/statuses?project=" + encodeURIComponent(project),
+        type: "get",
+        headers: {
+            "Authorization": "Basic "+ token
+        },
+        dataType: "json",
+        success: function(statuses) {
+            statusColors[project] = {};
+            for (let s of statuses) {
+                statusColors[project][s.id] = s.color;
+            }
+            render();
+        }
+    });
+}
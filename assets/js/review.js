@@ -0,0 +1,36 @@
+// 리뷰모드에서 플레이리스트 항목을 하나씩 넘겨본다.
+// 노트를 입력하는 중에는 방향키가 동작하지 않도록 textarea, select에 포커스가 없을 때만 방향키로 이동한다.
+let reviewIndex = 0
+
+function showReviewEntry(n) {
+    let entries = $(".review-entry")
+    if (entries.length === 0) {
+        return
+    }
+    reviewIndex = Math.min(Math.max(n, 0), entries.length - 1)
+    entries.hide()
+    $(entries[reviewIndex]).show()
+    $(entries[reviewIndex]).find("textarea").trigger("focus")
+}
+
+$("#review-prev").click(function() {
+    showReviewEntry(reviewIndex - 1)
+})
+$("#review-next").click(function() {
+    showReviewEntry(reviewIndex + 1)
+})
+$("#review-all").click(function() {
+    $(".review-entry").show()
+})
+$(document).keydown(function(e) {
+    if ($(e.target).is("textarea, input, select")) {
+        return
+    }
+    if (e.key === "ArrowLeft") {
+        showReviewEntry(reviewIndex - 1)
+    } else if (e.key === "ArrowRight") {
+        showReviewEntry(reviewIndex + 1)
+    }
+})
+
+showReviewEntry(0)
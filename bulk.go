@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/digital-idea/ditime"
+)
+
+// BulkOperation 자료구조는 /api/bulk 로 전달되는 아이템 수정 하나이다.
+type BulkOperation struct {
+	Project string `json:"project"` // 프로젝트
+	Name    string `json:"name"`    // 아이템 이름
+	Field   string `json:"field"`   // 수정할 필드
+	Task    string `json:"task"`    // Task 필드를 수정할 때 사용하는 Task 이름
+	Value   string `json:"value"`   // 설정값
+}
+
+// BulkResult 자료구조는 BulkOperation 하나의 처리결과이다.
+type BulkResult struct {
+	BulkOperation
+	Index int    `json:"index"` // 요청 리스트에서의 순서
+	ID    string `json:"id"`    // 아이템 ID
+	Error string `json:"error"` // 에러가 없다면 빈 문자열이다.
+}
+
+// BulkTaskFields 는 task 값이 필요한 Bulk 필드 리스트이다.
+var BulkTaskFields = []string{"taskstatus", "taskuser", "taskstartdate", "taskpredate", "taskdate", "taskusernote", "tasklevel"}
+
+// BulkFields 는 task 값이 필요없는 Bulk 필드 리스트이다.
+var BulkFields = []string{
+	"deadline2d", "deadline3d", "addtag", "rmtag", "shottype", "rnum", "finver", "findate", "outputname",
+	"scanin", "scanout", "scanframe", "platein", "plateout", "justin", "justout", "handlein", "handleout",
+	"scantimecodein", "scantimecodeout", "justtimecodein", "justtimecodeout", "comment",
+}
+
+// isBulkTaskField 함수는 task 값이 필요한 필드인지 체크한다.
+func isBulkTaskField(field string) bool {
+	for _, f := range BulkTaskFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// checkBulkOperation 함수는 DB를 조회하지 않고 확인할 수 있는 BulkOperation 값을 체크한다.
+func checkBulkOperation(op BulkOperation) error {
+	if op.Project == "" {
+		return errors.New("project 값이 빈 문자열입니다")
+	}
+	if op.Name == "" {
+		return errors.New("name 값이 빈 문자열입니다")
+	}
+	if isBulkTaskField(op.Field) {
+		if op.Task == "" {
+			return fmt.Errorf("%s 필드는 task 값이 필요합니다", op.Field)
+		}
+	} else {
+		found := false
+		for _, f := range BulkFields {
+			if f == op.Field {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s 필드는 사용할 수 없습니다. (%s 필드만 사용가능합니다)", op.Field, strings.Join(append(BulkTaskFields, BulkFields...), ", "))
+		}
+	}
+	switch op.Field {
+	case "taskstartdate", "taskpredate", "taskdate", "deadline2d", "deadline3d", "findate":
+		_, err := ditime.ToFullTime(19, op.Value)
+		if err != nil {
+			return err
+		}
+	case "scanin", "scanout", "scanframe", "platein", "plateout", "justin", "justout", "handlein", "handleout", "tasklevel":
+		_, err := strconv.Atoi(op.Value)
+		if err != nil {
+			return fmt.Errorf("%s 필드는 숫자를 입력해야 합니다", op.Field)
+		}
+	case "scantimecodein", "scantimecodeout", "justtimecodein", "justtimecodeout":
+		if !(regexpTimecode.MatchString(op.Value) || op.Value == "") {
+			return fmt.Errorf("%s 문자열은 00:00:00:00 형식의 문자열이 아닙니다", op.Value)
+		}
+	case "shottype":
+		return validShottype(op.Value)
+	case "addtag", "rmtag", "outputname", "comment":
+		if op.Value == "" {
+			return fmt.Errorf("%s 필드의 value 값이 빈 문자열입니다", op.Field)
+		}
+	}
+	return nil
+}
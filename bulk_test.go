@@ -0,0 +1,58 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestCheckBulkOperation(t *testing.T) {
+	cases := []struct {
+		op   BulkOperation
+		want bool
+	}{
+		{op: BulkOperation{Project: "TEMP", Name: "SS_0010", Field: "taskstatus", Task: "comp", Value: "wip"}, want: true},
+		{op: BulkOperation{Project: "TEMP", Name: "SS_0010", Field: "taskstatus", Value: "wip"}, want: false}, // task가 없다.
+		{op: BulkOperation{Project: "TEMP", Name: "SS_0010", Field: "deadline2d", Value: "2020-03-01"}, want: true},
+		{op: BulkOperation{Project: "TEMP", Name: "SS_0010", Field: "deadline2d", Value: "tomorrow"}, want: false},
+		{op: BulkOperation{Project: "TEMP", Name: "SS_0010", Field: "platein", Value: "1001"}, want: true},
+		{op: BulkOperation{Project: "TEMP", Name: "SS_0010", Field: "platein", Value: "a"}, want: false},
+		{op: BulkOperation{Project: "TEMP", Name: "SS_0010", Field: "scantimecodein", Value: "01:00:00:00"}, want: true},
+		{op: BulkOperation{Project: "TEMP", Name: "SS_0010", Field: "addtag", Value: ""}, want: false},
+		{op: BulkOperation{Project: "TEMP", Name: "SS_0010", Field: "name", Value: "SS_0020"}, want: false},
+		{op: BulkOperation{Project: "", Name: "SS_0010", Field: "addtag", Value: "fx"}, want: false},
+	}
+	for _, c := range cases {
+		err := checkBulkOperation(c.op)
+		if (err == nil) != c.want {
+			t.Fatalf("checkBulkOperation(%v): 얻은 값 %v, 원하는 값 %v", c.op, err, c.want)
+		}
+	}
+}
+
+func TestCheckBulkTransition(t *testing.T) {
+	statuses := DefaultStatuses()
+	transitions := []Transition{
+		{From: WIP, To: CONFIRM, AccessLevel: ArtistAccessLevel},
+		{From: CONFIRM, To: DONE, AccessLevel: SupervisorAccessLevel},
+	}
+	projected := make(map[string]string)
+	key := "TEMP/SS_0010_org/comp"
+	// wip -> confirm -> done 순서의 작업은 앞선 작업으로 바뀔 상태에서 체크한다.
+	err := checkBulkTransition(statuses, transitions, projected, key, WIP, CONFIRM, SupervisorAccessLevel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = checkBulkTransition(statuses, transitions, projected, key, WIP, DONE, SupervisorAccessLevel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 앞선 작업으로 done이 되므로 done -> confirm 규칙이 없어 실패해야 한다.
+	err = checkBulkTransition(statuses, transitions, projected, key, WIP, CONFIRM, SupervisorAccessLevel)
+	if _, ok := err.(TransitionError); !ok {
+		t.Fatalf("checkBulkTransition: TransitionError가 발생해야 합니다: %v", err)
+	}
+	// 다른 Task는 현재 상태에서 체크한다.
+	err = checkBulkTransition(statuses, transitions, projected, "TEMP/SS_0010_org/fx", WIP, DONE, AdminAccessLevel)
+	if _, ok := err.(TransitionError); !ok {
+		t.Fatalf("checkBulkTransition: TransitionError가 발생해야 합니다: %v", err)
+	}
+}
@@ -274,14 +274,14 @@ func addOtherItemCmd(project, name, typ, platesize, scanname, scantimecodein, sc
 		log.Fatal(err)
 	}
 	// src 라면 기존 plate에 소스 등록을 진행한다.
-	_, err = AddSource(session, project, name, "scantool", name+"_"+typ, platePath)
+	_, err = AddSource(session, project, name, "scantool", name+"_"+typ, platePath, cliEditor())
 	if err != nil {
 		log.Println(err)
 	}
 	// org1, left1 형태의 아이템이 처리되면 org, left 아이템의 .UseType을 추가해준다.
 	// 이 값은 썸네일을 업데이트하고, 아티스트가 재스캔 되었을 때 사용할 타입의 알람으로 사용된다.
 	if strings.Contains(typ, "org") || strings.Contains(typ, "left") {
-		err = SetUseType(session, project, name, typ)
+		err = SetUseType(session, project, name, typ, cliEditor())
 		if err != nil {
 			log.Println(err)
 		}
@@ -302,7 +302,7 @@ func rmItemCmd(project, name, typ string) {
 		log.Fatal(err)
 	}
 	defer session.Close()
-	err = rmItem(session, project, name, typ)
+	err = rmItem(session, project, name, typ, cliEditor())
 	if err != nil {
 		log.Fatal(err)
 	}
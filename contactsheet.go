@@ -0,0 +1,228 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SheetColumns 는 컨택시트에 출력할 수 있는 항목이다. 출력순서이기도 하다.
+var SheetColumns = []string{"thumbnail", "name", "status", "tasks", "note", "deadline", "frame"}
+
+// sheetFormats 는 컨택시트 파일 포멧별 Content-Type 이다.
+var sheetFormats = map[string]string{
+	"pdf": "application/pdf",
+	"png": "image/png",
+	"jpg": "image/jpeg",
+}
+
+// regexpSheetLayout 은 컨택시트 레이아웃 문자열이다. 예) 4x3
+var regexpSheetLayout = regexp.MustCompile(`^([1-9]\d?)x([1-9]\d?)$`)
+
+// SheetOption 자료구조는 검색결과를 컨택시트로 내보낼 때 사용하는 옵션이다.
+type SheetOption struct {
+	Format  string   // pdf, png, jpg
+	Columns []string // 출력할 항목. SheetColumns 값을 사용한다.
+	Layout  string   // 한 페이지의 가로x세로 아이템 갯수. 예) 4x3
+	Page    int      // 이미지로 내보낼 페이지. 1부터 시작한다. PDF는 모든 페이지를 내보낸다.
+	Title   string   // 페이지 상단에 출력할 제목
+}
+
+// sheetCell 자료구조는 컨택시트에서 아이템 하나가 차지하는 칸이다.
+type sheetCell struct {
+	Thumbnail string   // 썸네일 파일경로. 썸네일을 출력하지 않는다면 빈 문자열이다.
+	Lines     []string // 썸네일 아래에 출력할 문자열
+}
+
+// sheetOption 함수는 폼 값으로 컨택시트 옵션을 만든다.
+// columns는 여러번 또는 ,로 구분해서 입력할 수 있으며, 입력하지 않으면 모든 항목을 출력한다.
+func sheetOption(form url.Values) (SheetOption, error) {
+	o := SheetOption{
+		Format: strings.ToLower(form.Get("format")),
+		Layout: strings.ToLower(form.Get("layout")),
+		Page:   1,
+		Title:  form.Get("title"),
+	}
+	if o.Format == "" {
+		o.Format = "pdf"
+	}
+	if o.Layout == "" {
+		o.Layout = "4x3"
+	}
+	for _, v := range form["columns"] {
+		for _, c := range strings.Split(v, ",") {
+			c = strings.ToLower(strings.TrimSpace(c))
+			if c == "" || hasString(o.Columns, c) {
+				continue
+			}
+			o.Columns = append(o.Columns, c)
+		}
+	}
+	if len(o.Columns) == 0 {
+		o.Columns = SheetColumns
+	}
+	if form.Get("page") != "" {
+		n, err := strconv.Atoi(form.Get("page"))
+		if err != nil {
+			return o, fmt.Errorf("page: %v", err)
+		}
+		o.Page = n
+	}
+	return o, checkSheetOption(o)
+}
+
+// checkSheetOption 함수는 컨택시트 옵션이 올바른지 체크한다.
+func checkSheetOption(o SheetOption) error {
+	if _, found := sheetFormats[o.Format]; !found {
+		return fmt.Errorf("%s 포멧은 지원하지 않습니다. pdf, png, jpg 중 하나를 사용해주세요", o.Format)
+	}
+	if !regexpSheetLayout.MatchString(o.Layout) {
+		return fmt.Errorf("layout %s 은 4x3 처럼 가로x세로 형태로 입력해주세요", o.Layout)
+	}
+	if len(o.Columns) == 0 {
+		return errors.New("출력할 항목이 없습니다")
+	}
+	for _, c := range o.Columns {
+		if !hasString(SheetColumns, c) {
+			return fmt.Errorf("%s 항목은 지원하지 않습니다. 사용가능한 항목: %s", c, strings.Join(SheetColumns, ", "))
+		}
+	}
+	if o.Page < 1 {
+		return errors.New("page는 1부터 시작합니다")
+	}
+	return nil
+}
+
+// grid 메소드는 한 페이지의 가로, 세로 아이템 갯수를 반환한다.
+func (o SheetOption) grid() (int, int) {
+	m := regexpSheetLayout.FindStringSubmatch(o.Layout)
+	if m == nil {
+		return 4, 3
+	}
+	cols, _ := strconv.Atoi(m[1])
+	rows, _ := strconv.Atoi(m[2])
+	return cols, rows
+}
+
+// filename 메소드는 내려받을 컨택시트 파일이름을 반환한다.
+func (o SheetOption) filename(project string) string {
+	name := project
+	if name == "" {
+		name = "csi"
+	}
+	if o.Format != "pdf" {
+		return fmt.Sprintf("%s_contactsheet_%d.%s", name, o.Page, o.Format)
+	}
+	return fmt.Sprintf("%s_contactsheet.%s", name, o.Format)
+}
+
+// sheetFrameText 함수는 프레임 범위와 길이를 문자로 반환한다. 범위가 없다면 빈 문자열을 반환한다.
+func sheetFrameText(label string, in, out int) string {
+	num := Framecal(in, out)
+	if num == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s %d-%d (%sf)", label, in, out, num)
+}
+
+// sheetCells 함수는 아이템 리스트를 컨택시트 칸 리스트로 만든다.
+// Task 상태는 tasks 순서대로 아이템에 존재하는 Task만 출력한다.
+func sheetCells(items []Item, statuses []Status, tasks []string, columns []string) []sheetCell {
+	cells := []sheetCell{}
+	for _, i := range items {
+		cell := sheetCell{}
+		for _, c := range SheetColumns {
+			if !hasString(columns, c) {
+				continue
+			}
+			switch c {
+			case "thumbnail":
+				cell.Thumbnail = thumbnailFilePath(i.Project, i.ID)
+			case "name":
+				cell.Lines = append(cell.Lines, i.Name)
+			case "status":
+				cell.Lines = append(cell.Lines, "Status: "+StatusName(statuses, i.Status))
+			case "tasks":
+				var ts []string
+				for _, t := range tasks {
+					task, found := i.Tasks[strings.ToLower(t)]
+					if !found {
+						continue
+					}
+					ts = append(ts, fmt.Sprintf("%s: %s", strings.ToLower(t), StatusName(statuses, task.Status)))
+				}
+				if len(ts) != 0 {
+					cell.Lines = append(cell.Lines, strings.Join(ts, ", "))
+				}
+			case "note":
+				for _, line := range strings.Split(strings.TrimSpace(i.Note.Text), "\n") {
+					if strings.TrimSpace(line) == "" {
+						continue
+					}
+					cell.Lines = append(cell.Lines, strings.TrimSpace(line))
+				}
+			case "deadline":
+				var ds []string
+				if i.Ddline2d != "" {
+					ds = append(ds, "2D "+ToNormalTime(i.Ddline2d))
+				}
+				if i.Ddline3d != "" {
+					ds = append(ds, "3D "+ToNormalTime(i.Ddline3d))
+				}
+				if len(ds) != 0 {
+					cell.Lines = append(cell.Lines, strings.Join(ds, ", "))
+				}
+			case "frame":
+				for _, f := range []string{
+					sheetFrameText("Plate", i.PlateIn, i.PlateOut),
+					sheetFrameText("Just", i.JustIn, i.JustOut),
+				} {
+					if f != "" {
+						cell.Lines = append(cell.Lines, f)
+					}
+				}
+			}
+		}
+		cells = append(cells, cell)
+	}
+	return cells
+}
+
+// sheetPages 함수는 칸 리스트를 페이지당 size 갯수로 나눈다. 칸이 없어도 빈 페이지 하나를 반환한다.
+func sheetPages(cells []sheetCell, size int) [][]sheetCell {
+	pages := [][]sheetCell{}
+	for len(cells) > size {
+		pages = append(pages, cells[:size])
+		cells = cells[size:]
+	}
+	return append(pages, cells)
+}
+
+// exportSheet 함수는 칸 리스트를 옵션의 포멧으로 만든다.
+func exportSheet(cells []sheetCell, o SheetOption) ([]byte, error) {
+	err := checkSheetOption(o)
+	if err != nil {
+		return nil, err
+	}
+	cols, rows := o.grid()
+	pages := sheetPages(cells, cols*rows)
+	if o.Format == "pdf" {
+		return sheetPDF(pages, o)
+	}
+	if o.Page > len(pages) {
+		return nil, fmt.Errorf("page %d 가 없습니다. 전체 %d 페이지입니다", o.Page, len(pages))
+	}
+	return sheetImage(pages[o.Page-1], o, len(pages))
+}
+
+// sheetPageTitle 함수는 페이지 상단에 출력할 제목을 반환한다.
+func sheetPageTitle(o SheetOption, page, total int) string {
+	title := o.Title
+	if title == "" {
+		title = "Contact Sheet"
+	}
+	return fmt.Sprintf("%s  %d / %d", title, page, total)
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// 컨택시트 이미지의 여백과 글자 크기. 단위는 픽셀이다.
+const (
+	sheetImageMargin = 16
+	sheetImageGap    = 16
+	sheetImageLineH  = 15
+)
+
+// sheetASCII 함수는 이미지 폰트로 출력할 수 없는 문자를 ?로 바꾼다. 이미지는 영문, 숫자만 출력할 수 있다.
+func sheetASCII(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7E {
+			return '?'
+		}
+		return r
+	}, s)
+}
+
+// sheetImage 함수는 한 페이지의 칸 리스트로 컨택시트 이미지를 만든다.
+func sheetImage(cells []sheetCell, o SheetOption, total int) ([]byte, error) {
+	cols, rows := o.grid()
+	face := basicfont.Face7x13
+	maxLines := 0
+	thumbH := 0
+	for _, c := range cells {
+		if len(c.Lines) > maxLines {
+			maxLines = len(c.Lines)
+		}
+		if c.Thumbnail != "" {
+			thumbH = ThumbnailHeight
+		}
+	}
+	cellW := ThumbnailWidth
+	cellH := thumbH + 4 + maxLines*sheetImageLineH
+	width := 2*sheetImageMargin + cols*cellW + (cols-1)*sheetImageGap
+	top := sheetImageMargin + sheetImageLineH + sheetImageGap
+	height := top + rows*cellH + (rows-1)*sheetImageGap + sheetImageMargin
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	d := &font.Drawer{Dst: img, Src: image.Black, Face: face}
+	drawLine := func(x, y int, s string) {
+		d.Dot = fixed.P(x, y)
+		d.DrawString(fitText(sheetASCII(s), 14, float64(cellW)))
+	}
+	drawLine(sheetImageMargin, sheetImageMargin+13, sheetPageTitle(o, o.Page, total))
+	gray := image.NewUniform(color.Gray{Y: 217})
+	for i, c := range cells {
+		x := sheetImageMargin + (i%cols)*(cellW+sheetImageGap)
+		y := top + (i/cols)*(cellH+sheetImageGap)
+		if c.Thumbnail != "" {
+			rect := image.Rect(x, y, x+cellW, y+thumbH)
+			src, err := imaging.Open(c.Thumbnail)
+			if err != nil {
+				draw.Draw(img, rect, gray, image.Point{}, draw.Src)
+			} else {
+				thumb := imaging.Fit(src, cellW, thumbH, imaging.Lanczos)
+				// 썸네일 비율을 유지하면서 칸 가운데에 놓는다.
+				offset := image.Pt((cellW-thumb.Bounds().Dx())/2, (thumbH-thumb.Bounds().Dy())/2)
+				draw.Draw(img, thumb.Bounds().Add(rect.Min).Add(offset), thumb, image.Point{}, draw.Src)
+			}
+		}
+		for l, line := range c.Lines {
+			drawLine(x, y+thumbH+4+(l+1)*sheetImageLineH-3, line)
+		}
+	}
+	var buf bytes.Buffer
+	var err error
+	if o.Format == "jpg" {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image/jpeg"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/disintegration/imaging"
+)
+
+// PDF 페이지 크기(A4 가로)와 여백. 단위는 pt 이다.
+const (
+	pdfPageWidth  = 842.0
+	pdfPageHeight = 595.0
+	pdfMargin     = 28.0
+	pdfGap        = 10.0
+	pdfTitleSize  = 12.0
+	pdfFontSize   = 7.0
+)
+
+// pdfFont 는 한글을 출력하기 위해 사용하는 PDF 기본 CJK 폰트이다. 폰트를 포함하지 않고 PDF 뷰어의 폰트를 사용한다.
+// 문자열은 UCS-2 로 인코딩되며 영문, 숫자는 반각 폭으로 계산한다.
+const pdfFont = "HYGoThic-Medium"
+
+// pdfWriter 자료구조는 PDF 오브젝트를 순서대로 모아서 하나의 PDF 파일로 만든다.
+type pdfWriter struct {
+	objs [][]byte
+}
+
+// reserve 메소드는 오브젝트 번호를 미리 할당한다.
+func (p *pdfWriter) reserve() int {
+	p.objs = append(p.objs, nil)
+	return len(p.objs)
+}
+
+// set 메소드는 할당된 오브젝트 번호에 오브젝트 내용을 설정한다.
+func (p *pdfWriter) set(n int, obj []byte) {
+	p.objs[n-1] = obj
+}
+
+// add 메소드는 오브젝트를 추가하고 오브젝트 번호를 반환한다.
+func (p *pdfWriter) add(obj []byte) int {
+	n := p.reserve()
+	p.set(n, obj)
+	return n
+}
+
+// addStream 메소드는 dict 사전을 가진 스트림 오브젝트를 추가한다.
+func (p *pdfWriter) addStream(dict string, data []byte) int {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<< %s /Length %d >>\nstream\n", dict, len(data))
+	buf.Write(data)
+	buf.WriteString("\nendstream")
+	return p.add(buf.Bytes())
+}
+
+// bytes 메소드는 root 오브젝트를 Catalog로 사용하는 PDF 파일을 만든다.
+func (p *pdfWriter) bytes(root int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(p.objs))
+	for i, obj := range p.objs {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n", i+1)
+		buf.Write(obj)
+		buf.WriteString("\nendobj\n")
+	}
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(p.objs)+1)
+	for _, o := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", o)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(p.objs)+1, root, xref)
+	return buf.Bytes()
+}
+
+// pdfText 함수는 문자열을 PDF UCS-2 16진수 문자열로 바꾼다. UCS-2로 표현할 수 없는 문자는 ?로 바꾼다.
+func pdfText(s string) string {
+	var b strings.Builder
+	b.WriteString("<")
+	for _, r := range s {
+		if r > 0xFFFF || r == utf8.RuneError {
+			r = '?'
+		}
+		fmt.Fprintf(&b, "%04X", r)
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+// sheetTextWidth 함수는 문자열의 대략적인 폭을 반환한다. 영문, 숫자는 글자크기의 절반, 그 밖의 문자는 글자크기를 사용한다.
+func sheetTextWidth(s string, size float64) float64 {
+	w := 0.0
+	for _, r := range s {
+		if r < 0x80 {
+			w += size / 2
+		} else {
+			w += size
+		}
+	}
+	return w
+}
+
+// fitText 함수는 문자열이 width 폭을 넘으면 뒤를 잘라내고 ...을 붙인다.
+func fitText(s string, size, width float64) string {
+	if sheetTextWidth(s, size) <= width {
+		return s
+	}
+	rs := []rune(s)
+	for len(rs) > 0 {
+		rs = rs[:len(rs)-1]
+		if sheetTextWidth(string(rs)+"...", size) <= width {
+			break
+		}
+	}
+	return string(rs) + "..."
+}
+
+// pdfImage 함수는 썸네일 파일을 PDF에 넣을 수 있는 JPEG로 바꾼다.
+func pdfImage(path string) ([]byte, int, int, error) {
+	img, err := imaging.Open(path)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	dst := imaging.Fit(img, ThumbnailWidth, ThumbnailHeight, imaging.Lanczos)
+	var buf bytes.Buffer
+	err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 85})
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	return buf.Bytes(), dst.Bounds().Dx(), dst.Bounds().Dy(), nil
+}
+
+// sheetPDF 함수는 페이지별 칸 리스트로 PDF 파일을 만든다.
+// 썸네일 파일이 없다면 회색 상자를 그린다.
+func sheetPDF(pages [][]sheetCell, o SheetOption) ([]byte, error) {
+	cols, rows := o.grid()
+	p := &pdfWriter{}
+	catalog := p.reserve()
+	pagesObj := p.reserve()
+	cidFont := p.add([]byte(fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType0 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Korea1) /Supplement 1 >> /DW 1000 /W [1 95 500] >>", pdfFont)))
+	font := p.add([]byte(fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /UniKS-UCS2-H /DescendantFonts [%d 0 R] >>", pdfFont, cidFont)))
+	p.set(catalog, []byte(fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesObj)))
+
+	cellW := (pdfPageWidth - 2*pdfMargin - float64(cols-1)*pdfGap) / float64(cols)
+	top := pdfPageHeight - pdfMargin - pdfTitleSize - pdfGap
+	cellH := (top - pdfMargin - float64(rows-1)*pdfGap) / float64(rows)
+	lineH := pdfFontSize + 2
+	var kids []string
+	for n, cells := range pages {
+		var content bytes.Buffer
+		images := map[string]int{}
+		fmt.Fprintf(&content, "BT /F1 %.0f Tf %.2f %.2f Td %s Tj ET\n", pdfTitleSize, pdfMargin, pdfPageHeight-pdfMargin-pdfTitleSize, pdfText(sheetPageTitle(o, n+1, len(pages))))
+		for i, cell := range cells {
+			x := pdfMargin + float64(i%cols)*(cellW+pdfGap)
+			y := top - float64(i/cols)*(cellH+pdfGap) // 칸의 윗변
+			textTop := y
+			if cell.Thumbnail != "" {
+				thumbH := cellW * ThumbnailHeight / ThumbnailWidth
+				if limit := cellH - float64(len(cell.Lines))*lineH; thumbH > limit && limit > 0 {
+					thumbH = limit
+				}
+				data, w, h, err := pdfImage(cell.Thumbnail)
+				if err != nil {
+					fmt.Fprintf(&content, "0.85 g %.2f %.2f %.2f %.2f re f 0 g\n", x, y-thumbH, cellW, thumbH)
+				} else {
+					name := fmt.Sprintf("Im%d", i+1)
+					images[name] = p.addStream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /DCTDecode", w, h), data)
+					// 썸네일 비율을 유지하면서 칸 가운데에 놓는다.
+					dw, dh := cellW, cellW*float64(h)/float64(w)
+					if dh > thumbH {
+						dw, dh = thumbH*float64(w)/float64(h), thumbH
+					}
+					fmt.Fprintf(&content, "q %.2f 0 0 %.2f %.2f %.2f cm /%s Do Q\n", dw, dh, x+(cellW-dw)/2, y-thumbH+(thumbH-dh)/2, name)
+				}
+				textTop = y - thumbH - 2
+			}
+			for l, line := range cell.Lines {
+				ly := textTop - float64(l+1)*lineH
+				if ly < y-cellH {
+					break
+				}
+				fmt.Fprintf(&content, "BT /F1 %.0f Tf %.2f %.2f Td %s Tj ET\n", pdfFontSize, x, ly, pdfText(fitText(line, pdfFontSize, cellW)))
+			}
+		}
+		contentObj := p.addStream("", content.Bytes())
+		var xobjects []string
+		for i := range cells {
+			name := fmt.Sprintf("Im%d", i+1)
+			if obj, found := images[name]; found {
+				xobjects = append(xobjects, fmt.Sprintf("/%s %d 0 R", name, obj))
+			}
+		}
+		page := p.add([]byte(fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 %d 0 R >> /XObject << %s >> >> /Contents %d 0 R >>",
+			pagesObj, pdfPageWidth, pdfPageHeight, font, strings.Join(xobjects, " "), contentObj)))
+		kids = append(kids, fmt.Sprintf("%d 0 R", page))
+	}
+	p.set(pagesObj, []byte(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids))))
+	return p.bytes(catalog), nil
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSheetOption(t *testing.T) {
+	o, err := sheetOption(url.Values{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Format != "pdf" || o.Layout != "4x3" || o.Page != 1 || !reflect.DeepEqual(o.Columns, SheetColumns) {
+		t.Fatalf("default: %+v", o)
+	}
+	o, err = sheetOption(url.Values{
+		"format":  {"PNG"},
+		"layout":  {"2x5"},
+		"page":    {"3"},
+		"columns": {"name, status", "note", "name"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(o.Columns, []string{"name", "status", "note"}) {
+		t.Fatalf("columns: %v", o.Columns)
+	}
+	if cols, rows := o.grid(); cols != 2 || rows != 5 {
+		t.Fatalf("grid: %d %d", cols, rows)
+	}
+	if o.filename("TEMP") != "TEMP_contactsheet_3.png" {
+		t.Fatalf("filename: %s", o.filename("TEMP"))
+	}
+	bad := []url.Values{
+		{"format": {"gif"}},
+		{"layout": {"4*3"}},
+		{"layout": {"0x3"}},
+		{"columns": {"name,comment"}},
+		{"page": {"0"}},
+		{"page": {"one"}},
+	}
+	for _, form := range bad {
+		if _, err := sheetOption(form); err == nil {
+			t.Fatalf("%v: 에러가 발생해야 합니다", form)
+		}
+	}
+}
+
+func TestSheetCells(t *testing.T) {
+	statuses := []Status{{ID: "wip", Name: "wip"}, {ID: "done", Name: "done"}}
+	items := []Item{{
+		Project:  "TEMP",
+		ID:       "SS_0010_org",
+		Name:     "SS_0010",
+		Status:   "wip",
+		Note:     Comment{Text: "첫번째 줄\n\n두번째 줄\n"},
+		Ddline2d: "2020-03-01T19:00:00+09:00",
+		PlateIn:  1001,
+		PlateOut: 1100,
+		JustIn:   1010,
+		Tasks: map[string]Task{
+			"comp": {Status: "wip"},
+			"fx":   {Status: "done"},
+		},
+	}}
+	cells := sheetCells(items, statuses, []string{"FX", "Light", "Comp"}, SheetColumns)
+	want := []string{
+		"SS_0010",
+		"Status: wip",
+		"fx: done, comp: wip",
+		"첫번째 줄",
+		"두번째 줄",
+		"2D 2020-03-01",
+		"Plate 1001-1100 (100f)",
+	}
+	if !reflect.DeepEqual(cells[0].Lines, want) {
+		t.Fatalf("lines:\n%q\nwant:\n%q", cells[0].Lines, want)
+	}
+	if !strings.HasSuffix(cells[0].Thumbnail, "/TEMP/SS_0010_org.jpg") {
+		t.Fatalf("thumbnail: %s", cells[0].Thumbnail)
+	}
+	cells = sheetCells(items, statuses, nil, []string{"frame", "name"})
+	if cells[0].Thumbnail != "" || !reflect.DeepEqual(cells[0].Lines, []string{"SS_0010", "Plate 1001-1100 (100f)"}) {
+		t.Fatalf("columns: %+v", cells[0])
+	}
+}
+
+func TestSheetPages(t *testing.T) {
+	cells := make([]sheetCell, 7)
+	var sizes []int
+	for _, p := range sheetPages(cells, 3) {
+		sizes = append(sizes, len(p))
+	}
+	if !reflect.DeepEqual(sizes, []int{3, 3, 1}) {
+		t.Fatalf("pages: %v", sizes)
+	}
+	if pages := sheetPages(nil, 3); len(pages) != 1 || len(pages[0]) != 0 {
+		t.Fatalf("empty: %v", pages)
+	}
+}
+
+func TestPDFText(t *testing.T) {
+	if got := pdfText("A가"); got != "<0041AC00>" {
+		t.Fatalf("pdfText: %s", got)
+	}
+	if got := fitText("SS_0010", 10, 100); got != "SS_0010" {
+		t.Fatalf("fitText: %s", got)
+	}
+	// 글자크기 10의 영문은 5pt, 한글은 10pt 폭으로 계산한다.
+	if got := fitText("abcdefghij", 10, 30); got != "abc..." {
+		t.Fatalf("fitText: %s", got)
+	}
+	if got := fitText("가나다라", 10, 30); got != "가..." {
+		t.Fatalf("fitText: %s", got)
+	}
+}
+
+func TestExportSheet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "contactsheet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	thumb := filepath.Join(dir, "thumb.jpg")
+	writeTestImage(t, thumb, color.RGBA{255, 0, 0, 255})
+	cells := []sheetCell{
+		{Thumbnail: thumb, Lines: []string{"SS_0010", "Status: 진행"}},
+		{Thumbnail: filepath.Join(dir, "none.jpg"), Lines: []string{"SS_0020"}},
+		{Lines: []string{"SS_0030"}},
+	}
+	o := SheetOption{Format: "pdf", Columns: SheetColumns, Layout: "2x1", Page: 1}
+	data, err := exportSheet(cells, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Fatal("PDF 파일이 아닙니다")
+	}
+	for _, s := range []string{"/Count 2", "/DCTDecode", pdfText("Status: 진행"), "0.85 g"} {
+		if !bytes.Contains(data, []byte(s)) {
+			t.Fatalf("PDF에 %s 가 없습니다", s)
+		}
+	}
+
+	o.Format = "png"
+	o.Page = 2
+	data, err = exportSheet(cells, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 두번째 페이지는 썸네일이 없는 한줄짜리 칸 하나이다.
+	wantW := 2*sheetImageMargin + 2*ThumbnailWidth + sheetImageGap
+	wantH := sheetImageMargin + sheetImageLineH + sheetImageGap + 4 + sheetImageLineH + sheetImageMargin
+	if format != "png" || img.Bounds().Dx() != wantW || img.Bounds().Dy() != wantH {
+		t.Fatalf("image: %s %v", format, img.Bounds())
+	}
+	o.Page = 3
+	if _, err = exportSheet(cells, o); err == nil {
+		t.Fatal("없는 페이지는 에러가 발생해야 합니다")
+	}
+}
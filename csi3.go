@@ -56,8 +56,9 @@ var (
 	flagCertPrivkey    = flag.String("certprivkey", fmt.Sprintf("/etc/letsencrypt/live/%s/privkey.pem", DNS), "certification privkey path")
 
 	// RV
-	flagRV   = flag.String("rvpath", "/opt/rv-Linux-x86-64-7.0.0/bin/rv", "rvplayer path")
-	flagPlay = flag.Bool("play", false, "Play RV")
+	flagRV       = flag.String("rvpath", "/opt/rv-Linux-x86-64-7.0.0/bin/rv", "rvplayer path")
+	flagPlay     = flag.Bool("play", false, "Play RV")
+	flagPlaylist = flag.String("playlist", "", "저장된 리뷰 플레이리스트 ID. -play 와 함께 사용하면 RV로 플레이한다.")
 	// Etc Service
 	flagDILOG = flag.String("dilog", DILOG, "dilog webserver url and port. ex) "+DILOG)
 	flagWFS   = flag.String("wfs", WFS, "wfs webserver url and port. ex) "+WFS)
@@ -78,6 +79,8 @@ var (
 	// Commandline Args: User
 	flagID                = flag.String("id", "", "user id")
 	flagInitPass          = flag.String("initpass", "", "initialize user password")
+	flagResetTOTP         = flag.Bool("resettotp", false, "reset user TOTP 2-step verification")
+	flagAuthSource        = flag.String("authsource", "", "link user to auth service: ldap, oidc, local")
 	flagAccessLevel       = flag.Int("accesslevel", -1, "edit user Access Level")
 	flagSignUpAccessLevel = flag.Int("signupaccesslevel", 3, "signup access level")
 	// scan정보 추가. plate scan tool에서 데이터를 등록할 때 활용되는 옵션
@@ -177,6 +180,34 @@ func main() {
 			log.Fatal(err)
 		}
 		return
+	} else if *flagResetTOTP && *flagID != "" {
+		if user.Username != "root" {
+			log.Fatal(errors.New("사용자의 2단계 인증을 초기화하기 위해서는 root 권한이 필요합니다"))
+		}
+		session, err := mgo.Dial(*flagDBIP)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer session.Close()
+		err = resetTOTP(session, *flagID)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	} else if *flagAuthSource != "" && *flagID != "" {
+		if user.Username != "root" {
+			log.Fatal(errors.New("사용자의 인증서비스를 연결하기 위해서는 root 권한이 필요합니다"))
+		}
+		session, err := mgo.Dial(*flagDBIP)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer session.Close()
+		err = setUserAuthSource(session, *flagID, *flagAuthSource)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
 	} else if *flagRm == "division" && *flagID != "" { // division 삭제
 		if user.Username != "root" {
 			log.Fatal(errors.New("사용자를 삭제하기 위해서는 root 권한이 필요합니다"))
@@ -264,6 +295,10 @@ func main() {
 		if err != nil {
 			log.Fatal(err)
 		}
+		err = rmUserAPITokens(session, u.ID)
+		if err != nil {
+			log.Fatal(err)
+		}
 		err = rmUser(session, u)
 		if err != nil {
 			log.Fatal(err)
@@ -300,76 +335,76 @@ func main() {
 						log.Fatal(err)
 					}
 					defer session.Close()
-					_, err = SetImageSize(session, *flagProject, *flagName, "platesize", *flagPlatesize)
+					_, err = SetImageSize(session, *flagProject, *flagName, "platesize", *flagPlatesize, cliEditor())
 					if err != nil {
 						log.Fatal(err)
 					}
-					err = SetTimecode(session, *flagProject, *flagName, "scantimecodein", *flagScantimecodein)
+					err = SetTimecode(session, *flagProject, *flagName, "scantimecodein", *flagScantimecodein, cliEditor())
 					if err != nil {
 						log.Fatal(err)
 					}
-					err = SetTimecode(session, *flagProject, *flagName, "scantimecodeout", *flagScantimecodeout)
+					err = SetTimecode(session, *flagProject, *flagName, "scantimecodeout", *flagScantimecodeout, cliEditor())
 					if err != nil {
 						log.Fatal(err)
 					}
 					if *flagJusttimecodein != "" {
-						err = SetTimecode(session, *flagProject, *flagName, "justtimecodein", *flagJusttimecodein)
+						err = SetTimecode(session, *flagProject, *flagName, "justtimecodein", *flagJusttimecodein, cliEditor())
 						if err != nil {
 							log.Fatal(err)
 						}
 					}
 					if *flagJusttimecodeout != "" {
-						err = SetTimecode(session, *flagProject, *flagName, "justtimecodeout", *flagJusttimecodeout)
+						err = SetTimecode(session, *flagProject, *flagName, "justtimecodeout", *flagJusttimecodeout, cliEditor())
 						if err != nil {
 							log.Fatal(err)
 						}
 					}
-					err = SetFrame(session, *flagProject, *flagName, "scanin", *flagScanin)
+					err = SetFrame(session, *flagProject, *flagName, "scanin", *flagScanin, cliEditor())
 					if err != nil {
 						log.Fatal(err)
 					}
-					err = SetFrame(session, *flagProject, *flagName, "scanout", *flagScanout)
+					err = SetFrame(session, *flagProject, *flagName, "scanout", *flagScanout, cliEditor())
 					if err != nil {
 						log.Fatal(err)
 					}
-					err = SetFrame(session, *flagProject, *flagName, "scanframe", *flagScanframe)
+					err = SetFrame(session, *flagProject, *flagName, "scanframe", *flagScanframe, cliEditor())
 					if err != nil {
 						log.Fatal(err)
 					}
-					err = SetFrame(session, *flagProject, *flagName, "platein", *flagPlatein)
+					err = SetFrame(session, *flagProject, *flagName, "platein", *flagPlatein, cliEditor())
 					if err != nil {
 						log.Fatal(err)
 					}
-					err = SetFrame(session, *flagProject, *flagName, "plateout", *flagPlateout)
+					err = SetFrame(session, *flagProject, *flagName, "plateout", *flagPlateout, cliEditor())
 					if err != nil {
 						log.Fatal(err)
 					}
 					// Just In/Out 등록
 					if *flagJustin > 0 {
-						err = SetFrame(session, *flagProject, *flagName, "justin", *flagJustin)
+						err = SetFrame(session, *flagProject, *flagName, "justin", *flagJustin, cliEditor())
 						if err != nil {
 							log.Fatal(err)
 						}
 					} else {
 						// JustIn 값이 없다면, org,left 값을 초기화 한다.
-						err = SetFrame(session, *flagProject, *flagName, "justin", 0)
+						err = SetFrame(session, *flagProject, *flagName, "justin", 0, cliEditor())
 						if err != nil {
 							log.Fatal(err)
 						}
 					}
 					if *flagJustout > 0 {
-						err = SetFrame(session, *flagProject, *flagName, "justout", *flagJustout)
+						err = SetFrame(session, *flagProject, *flagName, "justout", *flagJustout, cliEditor())
 						if err != nil {
 							log.Fatal(err)
 						}
 					} else {
 						// JustOut 값이 없다면, org,left 값을 초기화 한다.
-						err = SetFrame(session, *flagProject, *flagName, "justout", 0)
+						err = SetFrame(session, *flagProject, *flagName, "justout", 0, cliEditor())
 						if err != nil {
 							log.Fatal(err)
 						}
 					}
-					err = SetUseType(session, *flagProject, *flagName, *flagType)
+					err = SetUseType(session, *flagProject, *flagName, *flagType, cliEditor())
 					if err != nil {
 						log.Fatal(err)
 					}
@@ -403,6 +438,16 @@ func main() {
 		if err != nil {
 			log.Fatal("DB가 실행되고 있지 않습니다.")
 		}
+		// 예전 버전의 LDAP 사용자 정보를 authsource 필드로 옮긴다.
+		err = migrateUserAuthSource(session)
+		if err != nil {
+			log.Fatal(err)
+		}
+		// 상태가 없는 프로젝트에 기본 상태를 저장한다. 조회할 때는 DB에 쓰지 않는다.
+		err = migrateStatuses(session)
+		if err != nil {
+			log.Fatal(err)
+		}
 		plist, err := Projectlist(session)
 		if err != nil {
 			log.Fatal(err)
@@ -428,48 +473,24 @@ func main() {
 		}
 		TEMPLATES = vfsTempates
 		webserver(*flagHTTPPort)
-	} else if MatchNormalTime.MatchString(*flagDate) {
+	} else if MatchNormalTime.MatchString(*flagDate) || *flagPlaylist != "" {
 		// date 값이 데일리 형식이면 해당 날짜에 업로드된 mov를 RV를 통해 플레이한다.
+		// playlist 값이 있다면 저장된 리뷰 플레이리스트의 mov를 플레이한다.
 		// 예: $ csi3 -date 2016-12-05 -play
+		// 예: $ csi3 -playlist 5e8f0a... -play
 		session, err := mgo.Dial(*flagDBIP)
 		if err != nil {
 			log.Fatal(err)
 		}
 		defer session.Close()
-		dbProjectlist, err := Projectlist(session)
-		if err != nil {
-			log.Println(err)
-			os.Exit(1)
-		}
-		// 해당프로젝트만 데일리를 위한 옵션
-		// 만약 담당 프로젝트 감독님이 오면 해당 프로젝트 영상만 띄운다.
-		reviewProjectlist := dbProjectlist
-		if *flagProject != "" {
-			err := HasProject(session, *flagProject)
+		var entries []PlaylistEntry
+		if *flagPlaylist != "" {
+			p, err := GetPlaylist(session, *flagPlaylist)
 			if err != nil {
-				log.Fatalln(err)
-			}
-		}
-
-		var playlist []string
-		for _, project := range reviewProjectlist {
-			op := SearchOption{
-				Project:    project,
-				Searchword: *flagDate,
-				Assign:     true,
-				Ready:      true,
-				Wip:        true,
-				Confirm:    true,
-				Done:       true,
-				Out:        true,
-				Sortkey:    "slug",
-			}
-			items, err := Searchv2(session, op)
-			if err != nil {
-				log.Println(err)
-				os.Exit(1)
+				log.Fatalf("%s 플레이리스트: %v\n", *flagPlaylist, err)
 			}
-
+			entries = p.Entries
+		} else {
 			// 만약 태스크명을 입력받았다면, 태스크명이 유효한지 체크하는 부분.
 			if *flagTask != "" {
 				hastask := false
@@ -478,7 +499,7 @@ func main() {
 					log.Fatal(err)
 				}
 				for _, t := range tasks {
-					if *flagTask == strings.ToLower(t) {
+					if strings.ToLower(*flagTask) == strings.ToLower(t) {
 						hastask = true
 					}
 				}
@@ -486,20 +507,21 @@ func main() {
 					log.Fatalf("%s Task 이름은 사용할 수 없습니다.\n", *flagTask)
 				}
 			}
-			// 검색 옵션을 이용해서 daily 리스트를 만든다.
-			for _, item := range items {
-				tasks, err := TasksettingNames(session)
-				if err != nil {
-					log.Println(err)
-				}
-				for _, t := range tasks {
-					if _, found := item.Tasks[t]; !found {
-						continue
-					}
-					if *flagDate == ToNormalTime(item.Tasks[t].Mdate) && isMov(item.Tasks[t].Mov) {
-						playlist = append(playlist, item.Tasks[t].Mov)
-					}
-				}
+			// 해당프로젝트만 데일리를 위한 옵션
+			// 만약 담당 프로젝트 감독님이 오면 해당 프로젝트 영상만 띄운다.
+			entries, err = SearchPlaylistEntries(session, PlaylistSearch{
+				Project: *flagProject,
+				Task:    *flagTask,
+				Date:    *flagDate,
+			})
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+		var playlist []string
+		for _, e := range entries {
+			if isMov(e.Mov) {
+				playlist = append(playlist, e.Mov)
 			}
 		}
 		// -play 인수가 붙어있다면, RV를 이용해서 플레이한다.
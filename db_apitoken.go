@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// AddAPIToken 함수는 개인 토큰을 발급하고 토큰 정보와 토큰 키를 반환한다. 토큰 키는 다시 확인할 수 없다.
+func AddAPIToken(session *mgo.Session, t APIToken) (APIToken, string, error) {
+	session.SetMode(mgo.Monotonic, true)
+	if t.UserID == "" {
+		return t, "", errors.New("사용자 ID가 빈 문자열입니다")
+	}
+	if t.Name == "" {
+		return t, "", errors.New("토큰 이름이 빈 문자열입니다")
+	}
+	err := checkAPITokenEndpoints(t.Endpoints)
+	if err != nil {
+		return t, "", err
+	}
+	key, err := newAPITokenKey()
+	if err != nil {
+		return t, "", err
+	}
+	t.ID = bson.NewObjectId().Hex()
+	t.Hash = hashAPITokenKey(key)
+	t.Prefix = key[:len(apiTokenPrefix)+4]
+	t.Createtime = time.Now().Format(time.RFC3339)
+	t.Lastused = ""
+	err = session.DB("user").C("apitoken").Insert(t)
+	if err != nil {
+		return t, "", err
+	}
+	return t, key, nil
+}
+
+// getAPITokenByKey 함수는 토큰 키로 개인 토큰을 가지고 온다.
+func getAPITokenByKey(session *mgo.Session, key string) (APIToken, error) {
+	session.SetMode(mgo.Monotonic, true)
+	t := APIToken{}
+	err := session.DB("user").C("apitoken").Find(bson.M{"hash": hashAPITokenKey(key)}).One(&t)
+	if err != nil {
+		return t, errors.New("authorization failed")
+	}
+	return t, nil
+}
+
+// GetAPIToken 함수는 토큰 ID로 개인 토큰을 가지고 온다.
+func GetAPIToken(session *mgo.Session, id string) (APIToken, error) {
+	session.SetMode(mgo.Monotonic, true)
+	t := APIToken{}
+	err := session.DB("user").C("apitoken").Find(bson.M{"id": id}).One(&t)
+	if err != nil {
+		return t, err
+	}
+	return t, nil
+}
+
+// UserAPITokens 함수는 사용자가 발급한 개인 토큰을 최신순으로 가지고 온다.
+func UserAPITokens(session *mgo.Session, userID string) ([]APIToken, error) {
+	session.SetMode(mgo.Monotonic, true)
+	results := []APIToken{}
+	err := session.DB("user").C("apitoken").Find(bson.M{"userid": userID}).Sort("-createtime").All(&results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// RmAPIToken 함수는 개인 토큰을 폐기한다.
+func RmAPIToken(session *mgo.Session, id string) error {
+	session.SetMode(mgo.Monotonic, true)
+	return session.DB("user").C("apitoken").Remove(bson.M{"id": id})
+}
+
+// rmUserAPITokens 함수는 사용자가 발급한 모든 개인 토큰을 폐기한다.
+func rmUserAPITokens(session *mgo.Session, userID string) error {
+	session.SetMode(mgo.Monotonic, true)
+	_, err := session.DB("user").C("apitoken").RemoveAll(bson.M{"userid": userID})
+	return err
+}
+
+// touchAPIToken 함수는 개인 토큰의 마지막 사용시간을 기록한다.
+func touchAPIToken(session *mgo.Session, id string, now time.Time) error {
+	session.SetMode(mgo.Monotonic, true)
+	return session.DB("user").C("apitoken").Update(bson.M{"id": id}, bson.M{"$set": bson.M{"lastused": now.Format(time.RFC3339)}})
+}
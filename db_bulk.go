@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/mgo.v2"
+)
+
+// validBulkOperations 함수는 모든 BulkOperation을 적용하기 전에 체크한다.
+// 하나라도 에러가 있다면 false를 반환한다. 결과에는 작업별 에러가 담긴다.
+// 같은 Task의 상태를 여러번 바꾼다면 앞선 작업으로 바뀔 상태에서 상태변경 규칙을 체크한다.
+func validBulkOperations(session *mgo.Session, ops []BulkOperation, level AccessLevel) ([]BulkResult, bool) {
+	session.SetMode(mgo.Monotonic, true)
+	results := make([]BulkResult, len(ops))
+	statuses := make(map[string][]Status)
+	transitions := make(map[string][]Transition)
+	projected := make(map[string]string)
+	ok := true
+	for n, op := range ops {
+		op.Field = strings.ToLower(op.Field)
+		op.Task = strings.ToLower(op.Task)
+		results[n] = BulkResult{BulkOperation: op, Index: n}
+		err := validBulkOperation(session, op, level, statuses, transitions, projected, &results[n])
+		if err != nil {
+			results[n].Error = err.Error()
+			ok = false
+		}
+	}
+	return results, ok
+}
+
+// validBulkOperation 함수는 BulkOperation 하나를 DB 정보와 비교하여 체크한다.
+// 프로젝트별 상태리스트와 상태변경 규칙은 statuses, transitions 에 담아 재사용한다.
+// projected 에는 앞선 작업으로 바뀔 Task 상태가 담긴다.
+func validBulkOperation(session *mgo.Session, op BulkOperation, level AccessLevel, statuses map[string][]Status, transitions map[string][]Transition, projected map[string]string, result *BulkResult) error {
+	err := checkBulkOperation(op)
+	if err != nil {
+		return err
+	}
+	if _, found := statuses[op.Project]; !found {
+		err = HasProject(session, op.Project)
+		if err != nil {
+			return err
+		}
+		statuses[op.Project], err = AllStatuses(session, op.Project)
+		if err != nil {
+			return err
+		}
+		transitions[op.Project], err = AllTransitions(session, op.Project)
+		if err != nil {
+			return err
+		}
+	}
+	typ, err := Type(session, op.Project, op.Name)
+	if err != nil {
+		return err
+	}
+	result.ID = op.Name + "_" + typ
+	if !isBulkTaskField(op.Field) {
+		return nil
+	}
+	item, err := getItem(session, op.Project, result.ID)
+	if err != nil {
+		return err
+	}
+	t, found := item.Tasks[op.Task]
+	if !found {
+		return fmt.Errorf("%s 프로젝트 %s에 %s Task가 존재하지 않습니다", op.Project, op.Name, op.Task)
+	}
+	if op.Field != "taskstatus" {
+		return nil
+	}
+	s, err := findStatus(statuses[op.Project], op.Value)
+	if err != nil {
+		return err
+	}
+	key := op.Project + "/" + result.ID + "/" + op.Task
+	return checkBulkTransition(statuses[op.Project], transitions[op.Project], projected, key, t.Status, s.ID, level)
+}
+
+// checkBulkTransition 함수는 Task가 앞선 작업으로 바뀔 상태에서 to 상태로 바뀔 수 있는지 체크한다.
+// 앞선 작업이 없다면 현재 상태 current 를 사용한다. 체크를 통과하면 projected 에 바뀔 상태를 기록한다.
+func checkBulkTransition(statuses []Status, transitions []Transition, projected map[string]string, key, current, to string, level AccessLevel) error {
+	from := current
+	if s, found := projected[key]; found {
+		from = s
+	}
+	err := checkTransition(statuses, transitions, from, to, level)
+	if err != nil {
+		return err
+	}
+	projected[key] = to
+	return nil
+}
+
+// applyBulkOperations 함수는 체크된 BulkOperation을 순서대로 적용한다.
+func applyBulkOperations(session *mgo.Session, results []BulkResult, level AccessLevel, editor Editor) []BulkResult {
+	for n, r := range results {
+		err := applyBulkOperation(session, r, level, editor)
+		if err != nil {
+			results[n].Error = err.Error()
+		}
+	}
+	return results
+}
+
+// applyBulkOperation 함수는 BulkOperation 하나를 아이템에 적용한다.
+func applyBulkOperation(session *mgo.Session, r BulkResult, level AccessLevel, editor Editor) error {
+	op := r.BulkOperation
+	var err error
+	switch op.Field {
+	case "taskstatus":
+		err = SetTaskStatus(session, op.Project, op.Name, op.Task, op.Value, level, editor)
+	case "taskuser":
+		err = SetTaskUser(session, op.Project, op.Name, op.Task, op.Value, editor)
+	case "taskstartdate":
+		err = SetTaskStartdate(session, op.Project, op.Name, op.Task, op.Value, editor)
+	case "taskpredate":
+		_, err = SetTaskPredate(session, op.Project, op.Name, op.Task, op.Value, editor)
+	case "taskdate":
+		err = SetTaskDate(session, op.Project, op.Name, op.Task, op.Value, editor)
+	case "taskusernote":
+		err = SetTaskUserNote(session, op.Project, op.Name, op.Task, op.Value, editor)
+	case "tasklevel":
+		err = setTaskLevel(session, op.Project, op.Name, op.Task, op.Value, editor)
+	case "deadline2d":
+		_, err = SetDeadline2D(session, op.Project, op.Name, op.Value, editor)
+	case "deadline3d":
+		_, err = SetDeadline3D(session, op.Project, op.Name, op.Value, editor)
+	case "addtag":
+		_, err = AddTag(session, op.Project, op.Name, op.Value, editor)
+	case "rmtag":
+		err = RmTag(session, op.Project, op.Name, op.Value, editor)
+	case "shottype":
+		_, err = SetShotType(session, op.Project, op.Name, op.Value, editor)
+	case "rnum":
+		_, err = SetRnum(session, op.Project, op.Name, op.Value, editor)
+	case "finver":
+		err = SetFinver(session, op.Project, op.Name, op.Value, editor)
+	case "findate":
+		err = SetFindate(session, op.Project, op.Name, op.Value, editor)
+	case "outputname":
+		err = SetOutputName(session, op.Project, op.Name, op.Value, editor)
+	case "scanin", "scanout", "scanframe", "platein", "plateout", "justin", "justout", "handlein", "handleout":
+		frame, _ := strconv.Atoi(op.Value) // checkBulkOperation 에서 체크된 값이다.
+		err = SetFrame(session, op.Project, op.Name, op.Field, frame, editor)
+	case "scantimecodein", "scantimecodeout", "justtimecodein", "justtimecodeout":
+		err = SetTimecode(session, op.Project, op.Name, op.Field, op.Value, editor)
+	case "comment":
+		_, err = AddComment(session, op.Project, op.Name, editor.ID, time.Now().Format(time.RFC3339), op.Value, "", editor)
+	default:
+		err = fmt.Errorf("%s 필드는 사용할 수 없습니다", op.Field)
+	}
+	return err
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// dependencyTarget 함수는 아이템 ID 또는 이름으로 의존성 대상 아이템을 가지고 온다.
+// 이름은 org, left, asset 타입에서 고유해야 한다.
+func dependencyTarget(session *mgo.Session, project, key string) (Item, error) {
+	c := session.DB("project").C(project)
+	var target Item
+	err := c.Find(bson.M{"id": key}).One(&target)
+	if err == nil {
+		return target, nil
+	}
+	if err != mgo.ErrNotFound {
+		return target, err
+	}
+	typ, err := Type(session, project, key)
+	if err != nil {
+		return target, err
+	}
+	return getItem(session, project, key+"_"+typ)
+}
+
+// dependencyGraph 함수는 프로젝트 아이템의 의존성을 아이템 ID별 사용하는 아이템 ID 리스트로 가지고 온다.
+func dependencyGraph(session *mgo.Session, project string) (map[string][]string, error) {
+	c := session.DB("project").C(project)
+	var items []Item
+	err := c.Find(bson.M{"uses.0": bson.M{"$exists": true}}).Select(bson.M{"id": 1, "uses": 1}).All(&items)
+	if err != nil {
+		return nil, err
+	}
+	graph := make(map[string][]string)
+	for _, i := range items {
+		for _, u := range i.Uses {
+			graph[i.ID] = append(graph[i.ID], u.ID)
+		}
+	}
+	return graph, nil
+}
+
+// AddDependency 함수는 id 아이템이 target 아이템을 kind 종류로 사용하도록 연결한다.
+// target은 아이템 ID 또는 이름을 사용할 수 있다. 이미 연결된 아이템이라면 종류를 바꾼다.
+func AddDependency(session *mgo.Session, project, id, target, kind string, editor Editor) (Item, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return Item{}, err
+	}
+	item, err := getItem(session, project, id)
+	if err != nil {
+		return Item{}, err
+	}
+	t, err := dependencyTarget(session, project, target)
+	if err != nil {
+		return Item{}, err
+	}
+	err = checkDependency(item, t, kind)
+	if err != nil {
+		return Item{}, err
+	}
+	graph, err := dependencyGraph(session, project)
+	if err != nil {
+		return Item{}, err
+	}
+	if hasDependencyCycle(graph, item.ID, t.ID) {
+		return Item{}, fmt.Errorf("%s 는 이미 %s 를 사용하고 있어 순환 의존성이 됩니다", t.ID, item.ID)
+	}
+	var before Dependency
+	for _, u := range item.Uses {
+		if u.ID == t.ID {
+			before = u
+		}
+	}
+	uses := setDependency(item.Uses, Dependency{Kind: kind, ID: t.ID, Name: t.Name})
+	err = updateItem(session, project, id, bson.M{"$set": bson.M{"uses": uses, "updatetime": time.Now().Format(time.RFC3339)}}, editor)
+	if err != nil {
+		return Item{}, err
+	}
+	if kind == DependencyCrowd || before.Kind == DependencyCrowd {
+		err = syncCrowdAsset(session, project, t.ID, editor)
+		if err != nil {
+			return Item{}, err
+		}
+	}
+	return getItem(session, project, id)
+}
+
+// RmDependency 함수는 id 아이템에서 target 아이템 의존성을 제거한다.
+func RmDependency(session *mgo.Session, project, id, target string, editor Editor) (Item, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return Item{}, err
+	}
+	item, err := getItem(session, project, id)
+	if err != nil {
+		return Item{}, err
+	}
+	uses, removed, found := removeDependency(item.Uses, target)
+	if !found {
+		return Item{}, fmt.Errorf("%s 는 %s 를 사용하고 있지 않습니다", id, target)
+	}
+	err = updateItem(session, project, id, bson.M{"$set": bson.M{"uses": uses, "updatetime": time.Now().Format(time.RFC3339)}}, editor)
+	if err != nil {
+		return Item{}, err
+	}
+	if removed.Kind == DependencyCrowd {
+		err = syncCrowdAsset(session, project, removed.ID, editor)
+		if err != nil {
+			return Item{}, err
+		}
+	}
+	return getItem(session, project, id)
+}
+
+// UsedIn 함수는 id 아이템을 사용하는 아이템 리스트를 가지고 온다.
+// 반환되는 Dependency의 ID, Name은 사용하는 아이템이고 Kind는 사용하는 종류이다.
+func UsedIn(session *mgo.Session, project, id string) ([]Dependency, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("project").C(project)
+	var items []Item
+	err := c.Find(bson.M{"uses.id": id}).Select(bson.M{"id": 1, "name": 1, "uses": 1}).Sort("id").All(&items)
+	if err != nil {
+		return nil, err
+	}
+	results := []Dependency{}
+	for _, i := range items {
+		for _, u := range i.Uses {
+			if u.ID == id {
+				results = append(results, Dependency{Kind: u.Kind, ID: i.ID, Name: i.Name})
+			}
+		}
+	}
+	return results, nil
+}
+
+// syncCrowdAsset 함수는 에셋을 crowd 종류로 사용하는 샷이 있는지에 따라 에셋의 CrowdAsset 값을 설정한다.
+func syncCrowdAsset(session *mgo.Session, project, id string, editor Editor) error {
+	c := session.DB("project").C(project)
+	num, err := c.Find(bson.M{"uses": bson.M{"$elemMatch": bson.M{"id": id, "kind": DependencyCrowd}}}).Count()
+	if err != nil {
+		return err
+	}
+	return updateItem(session, project, id, bson.M{"$set": bson.M{"crowdasset": num != 0}}, editor)
+}
+
+// rmDependencyLinks 함수는 삭제된 아이템을 사용하던 아이템에서 의존성을 제거한다.
+// 다른 수정과 같이 변경이력, 스냅샷, 이벤트, 웹훅이 기록되도록 아이템마다 updateItem으로 수정한다.
+func rmDependencyLinks(session *mgo.Session, project, id string, editor Editor) error {
+	used, err := UsedIn(session, project, id)
+	if err != nil {
+		return err
+	}
+	done := make(map[string]bool)
+	for _, d := range used {
+		if done[d.ID] {
+			continue
+		}
+		done[d.ID] = true
+		err = updateItem(session, project, d.ID, bson.M{"$pull": bson.M{"uses": bson.M{"id": id}}}, editor)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// itemRemoved 함수는 아이템이 삭제된 뒤 이벤트를 전달하고, 삭제된 아이템을 사용하던 의존성을 정리한다.
+func itemRemoved(session *mgo.Session, project, id string, editor Editor) {
+	publishItemEvent(ItemRemoved, project, id)
+	sendWebhookEvent(session, WebhookItemRemove, project, id)
+	err := rmDependencyLinks(session, project, id, editor)
+	if err != nil {
+		log.Println(err)
+	}
+}
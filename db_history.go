@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// updateItem 함수는 아이템을 업데이트하고 변경된 필드를 이력으로 남긴 뒤 이벤트를 전달한다.
+// update는 c.Update에 사용하는 값으로 Item 자료구조 또는 $set 쿼리를 사용할 수 있다.
+func updateItem(session *mgo.Session, project, id string, update interface{}, editor Editor) error {
+	session.SetMode(mgo.Monotonic, true)
+	before, err := getItem(session, project, id)
+	if err != nil {
+		return err
+	}
+	c := session.DB("project").C(project)
+	err = c.Update(bson.M{"id": id}, update)
+	if err != nil {
+		return err
+	}
+	after, err := getItem(session, project, id)
+	if err != nil {
+		return err
+	}
+	now := time.Now().Format(time.RFC3339)
+	histories, err := diffItem(before, after, editor, now)
+	if err != nil {
+		return err
+	}
+	err = addHistories(session, project, histories)
+	if err != nil {
+		return err
+	}
+	if len(histories) == 0 {
+		return nil
+	}
+	err = addSnapshot(session, project, before, after, editor, now)
+	if err != nil {
+		return err
+	}
+	itemEvents.publish(newItemEvent(project, id, histories, editor, now))
+	// 웹훅 에러로 아이템 수정이 실패하지 않도록 에러는 로그로만 남긴다.
+	err = enqueueWebhooks(session, project, webhookEvents(project, id, histories, editor, now))
+	if err != nil {
+		log.Println(err)
+	}
+	return nil
+}
+
+// addSnapshot 함수는 수정된 아이템의 스냅샷을 저장한다.
+// 아이템의 첫 스냅샷이라면 수정전 아이템도 마지막 수정시간으로 저장하여 되돌릴 수 있도록 한다.
+func addSnapshot(session *mgo.Session, project string, before, after Item, editor Editor, now string) error {
+	c := session.DB("snapshot").C(project)
+	num, err := c.Find(bson.M{"itemid": after.ID}).Count()
+	if err != nil {
+		return err
+	}
+	if num == 0 && before.Updatetime < now {
+		err = c.Insert(Snapshot{ItemID: before.ID, Time: before.Updatetime, Item: before})
+		if err != nil {
+			return err
+		}
+	}
+	return c.Insert(Snapshot{
+		ItemID: after.ID,
+		Author: editor.ID,
+		Source: editor.Source,
+		Time:   now,
+		Item:   after,
+	})
+}
+
+// addHistories 함수는 프로젝트에 아이템 변경이력을 추가한다.
+func addHistories(session *mgo.Session, project string, histories []History) error {
+	if len(histories) == 0 {
+		return nil
+	}
+	c := session.DB("history").C(project)
+	docs := make([]interface{}, len(histories))
+	for n, h := range histories {
+		docs[n] = h
+	}
+	return c.Insert(docs...)
+}
+
+// Histories 함수는 아이템의 변경이력을 최신순으로 가지고 온다.
+// field가 빈 문자열이 아니라면 해당 필드로 시작하는 이력만 가지고 온다.
+func Histories(session *mgo.Session, project, id, field string) ([]History, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("history").C(project)
+	query := bson.M{"itemid": id}
+	if field != "" {
+		query["field"] = &bson.RegEx{Pattern: "^" + regexp.QuoteMeta(field)}
+	}
+	results := []History{}
+	err := c.Find(query).Sort("-time").All(&results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Snapshots 함수는 아이템의 스냅샷을 최신순으로 가지고 온다.
+func Snapshots(session *mgo.Session, project, id string) ([]Snapshot, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("snapshot").C(project)
+	results := []Snapshot{}
+	err := c.Find(bson.M{"itemid": id}).Sort("-time").All(&results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// getSnapshot 함수는 입력된 시간 또는 그 이전의 가장 최근 스냅샷을 가지고 온다.
+func getSnapshot(session *mgo.Session, project, id, t string) (Snapshot, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("snapshot").C(project)
+	var result Snapshot
+	err := c.Find(bson.M{"itemid": id, "time": bson.M{"$lte": t}}).Sort("-time").One(&result)
+	if err == mgo.ErrNotFound {
+		return result, fmt.Errorf("%s 아이템에 %s 이전의 스냅샷이 없습니다", id, t)
+	}
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// RevertItem 함수는 아이템의 필드를 입력된 시간의 스냅샷으로 되돌린다.
+// 되돌린 내용은 변경이력으로 기록되고, 되돌린 스냅샷 시간이 revert 필드로 함께 기록된다.
+// Task 상태가 바뀐다면 SetTaskStatus와 같이 사용자의 AccessLevel로 상태변경 규칙을 체크한다.
+func RevertItem(session *mgo.Session, project, id, t string, fields []string, level AccessLevel, editor Editor) (Item, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return Item{}, err
+	}
+	snapshot, err := getSnapshot(session, project, id, t)
+	if err != nil {
+		return Item{}, err
+	}
+	current, err := getItem(session, project, id)
+	if err != nil {
+		return Item{}, err
+	}
+	i, err := revertItem(current, snapshot.Item, fields)
+	if err != nil {
+		return Item{}, err
+	}
+	statuses, err := AllStatuses(session, project)
+	if err != nil {
+		return Item{}, err
+	}
+	transitions, err := AllTransitions(session, project)
+	if err != nil {
+		return Item{}, err
+	}
+	err = checkRevertTransitions(statuses, transitions, current, i, level)
+	if err != nil {
+		return Item{}, err
+	}
+	err = setItem(session, project, i, editor)
+	if err != nil {
+		return Item{}, err
+	}
+	h := History{
+		ItemID: id,
+		Author: editor.ID,
+		Source: editor.Source,
+		Field:  "revert",
+		New:    fmt.Sprintf("%s (%s)", snapshot.Time, strings.Join(fields, ",")),
+		Time:   time.Now().Format(time.RFC3339),
+	}
+	err = addHistories(session, project, []History{h})
+	if err != nil {
+		return Item{}, err
+	}
+	return getItem(session, project, id)
+}
@@ -10,7 +10,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/digital-idea/ditime"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 )
@@ -41,16 +40,27 @@ func addItem(session *mgo.Session, project string, i Item) error {
 	if err != nil {
 		return err
 	}
+	publishItemEvent(ItemAdded, project, i.ID)
+	sendWebhookEvent(session, WebhookItemAdd, project, i.ID)
+	autoThumbnail(session, project, i.ID)
+	// 아이템은 이미 추가되었으므로 폴더 생성 에러는 로그로만 남긴다.
+	err = autoMkItemFolders(session, i)
+	if err != nil {
+		log.Printf("%s 아이템은 추가되었지만 폴더를 생성하지 못했습니다: %v", i.ID, err)
+	}
 	return nil
 }
 
-func setItem(session *mgo.Session, project string, i Item) error {
+func setItem(session *mgo.Session, project string, i Item, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
+	statuses, err := AllStatuses(session, project)
+	if err != nil {
+		return err
+	}
 	i.Updatetime = time.Now().Format(time.RFC3339)
-	i.updateStatus() // Task상태 업데이트
-	i.setRnumTag()   // 롤넘버에 따른 테그 셋팅
-	c := session.DB("project").C(project)
-	err := c.Update(bson.M{"id": i.ID}, i)
+	i.updateStatus(statuses) // Task상태 업데이트
+	i.setRnumTag()           // 롤넘버에 따른 테그 셋팅
+	err = updateItem(session, project, i.ID, i, editor)
 	if err != nil {
 		log.Println(err)
 		return err
@@ -159,7 +169,7 @@ func Shots(session *mgo.Session, project string, seq string) ([]string, error) {
 	return shots, nil
 }
 
-func rmItem(session *mgo.Session, project, name, usertyp string) error {
+func rmItem(session *mgo.Session, project, name, usertyp string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	var typ string
 	if usertyp == "" {
@@ -183,20 +193,22 @@ func rmItem(session *mgo.Session, project, name, usertyp string) error {
 	if err != nil {
 		return err
 	}
+	itemRemoved(session, project, name+"_"+typ, editor)
 	return nil
 }
 
-func rmItemID(session *mgo.Session, project, id string) error {
+func rmItemID(session *mgo.Session, project, id string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	c := session.DB("project").C(project)
 	err := c.Remove(bson.M{"id": id})
 	if err != nil {
 		return err
 	}
+	itemRemoved(session, project, id, editor)
 	return nil
 }
 
-func rmItemAndType(session *mgo.Session, project, name, typ string) error {
+func rmItemAndType(session *mgo.Session, project, name, typ string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	c := session.DB("project").C(project)
 	num, err := c.Find(bson.M{"name": name, "type": typ}).Count()
@@ -210,6 +222,7 @@ func rmItemAndType(session *mgo.Session, project, name, typ string) error {
 	if err != nil {
 		return err
 	}
+	itemRemoved(session, project, name+"_"+typ, editor)
 	return nil
 }
 
@@ -385,77 +398,40 @@ func SearchAssetTree(session *mgo.Session, op SearchOption) ([]Item, error) {
 // SearchKey 함수는 Item.{key} 필드의 값과 검색어가 정확하게 일치하는 항목들만 검색한다.
 func SearchKey(session *mgo.Session, op SearchOption, key string) ([]Item, error) {
 	session.SetMode(mgo.Monotonic, true)
+	statuses, err := AllStatuses(session, op.Project)
+	if err != nil {
+		return nil, err
+	}
 	c := session.DB("project").C(op.Project)
 	query := []bson.M{}
 	// 아래 단어는 CSI에 버튼으로 되어있는 태그단어를 클릭시 작동되는 예약어이다.
-	switch op.Searchword {
-	case "2d", "2D":
-		query = append(query, bson.M{"shottype": &bson.RegEx{Pattern: "2d", Options: "i"}})
-	case "3d", "3D":
-		query = append(query, bson.M{"shottype": &bson.RegEx{Pattern: "3d", Options: "i"}})
-	case "asset":
-		query = append(query, bson.M{"type": &bson.RegEx{Pattern: "asset", Options: "i"}})
-	case "assign":
-		query = append(query, bson.M{"status": ASSIGN})
-	case "ready":
-		query = append(query, bson.M{"status": READY})
-	case "wip":
-		query = append(query, bson.M{"status": WIP})
-	case "confirm":
-		query = append(query, bson.M{"status": CONFIRM})
-	case "done":
-		query = append(query, bson.M{"status": DONE})
-	case "omit":
-		query = append(query, bson.M{"status": OMIT})
-	case "hold":
-		query = append(query, bson.M{"status": HOLD})
-	case "out":
-		query = append(query, bson.M{"status": OUT})
-	case "none":
-		query = append(query, bson.M{"status": NONE})
-	default:
-		query = append(query, bson.M{key: op.Searchword})
+	// 프로젝트에 설정된 상태이름도 예약어로 사용한다.
+	if s, err := findStatus(statuses, op.Searchword); err == nil {
+		query = append(query, bson.M{"status": s.ID})
+	} else {
+		switch op.Searchword {
+		case "2d", "2D":
+			query = append(query, bson.M{"shottype": &bson.RegEx{Pattern: "2d", Options: "i"}})
+		case "3d", "3D":
+			query = append(query, bson.M{"shottype": &bson.RegEx{Pattern: "3d", Options: "i"}})
+		case "asset":
+			query = append(query, bson.M{"type": &bson.RegEx{Pattern: "asset", Options: "i"}})
+		default:
+			query = append(query, bson.M{key: op.Searchword})
+		}
 	}
 
 	var results []Item
-	if !op.Assign && !op.Ready && !op.Wip && !op.Confirm && !op.Done && !op.Omit && !op.Hold && !op.Out && !op.None {
+	trueStatus := op.checkedStatus()
+	if len(trueStatus) == 0 {
 		// 체크박스가 아무것도 켜있지 않다면 바로 빈 값을 리턴한다.
 		return results, nil
 	}
-	status := []bson.M{}
-	if op.Assign {
-		status = append(status, bson.M{"status": ASSIGN})
-	}
-	if op.Ready {
-		status = append(status, bson.M{"status": READY})
-	}
-	if op.Wip {
-		status = append(status, bson.M{"status": WIP})
-	}
-	if op.Confirm {
-		status = append(status, bson.M{"status": CONFIRM})
-	}
-	if op.Done {
-		status = append(status, bson.M{"status": DONE})
-	}
-	if op.Omit {
-		status = append(status, bson.M{"status": OMIT})
-	}
-	if op.Hold {
-		status = append(status, bson.M{"status": HOLD})
-	}
-	if op.Out {
-		status = append(status, bson.M{"status": OUT})
-	}
-	if op.None {
-		status = append(status, bson.M{"status": NONE})
-	}
-
 	q := bson.M{"$and": []bson.M{
 		bson.M{"$or": query},
-		bson.M{"$or": status},
+		bson.M{"status": bson.M{"$in": trueStatus}},
 	}}
-	err := c.Find(q).Sort(op.Sortkey).All(&results)
+	err = c.Find(q).Sort(op.Sortkey).All(&results)
 	if err != nil {
 		log.Println("DB Find Err : ", err)
 		return nil, err
@@ -477,38 +453,9 @@ func SearchDdline(session *mgo.Session, op SearchOption, part string) ([]Item, e
 		query = append(query, bson.M{})
 	}
 
-	status := []bson.M{}
-	if op.Assign {
-		status = append(status, bson.M{"status": ASSIGN})
-	}
-	if op.Ready {
-		status = append(status, bson.M{"status": READY})
-	}
-	if op.Wip {
-		status = append(status, bson.M{"status": WIP})
-	}
-	if op.Confirm {
-		status = append(status, bson.M{"status": CONFIRM})
-	}
-	if op.Done {
-		status = append(status, bson.M{"status": DONE})
-	}
-	if op.Omit {
-		status = append(status, bson.M{"status": OMIT})
-	}
-	if op.Hold {
-		status = append(status, bson.M{"status": HOLD})
-	}
-	if op.Out {
-		status = append(status, bson.M{"status": OUT})
-	}
-	if op.None {
-		status = append(status, bson.M{"status": NONE})
-	}
-
 	q := bson.M{"$and": []bson.M{
 		bson.M{"$or": query},
-		bson.M{"$or": status},
+		bson.M{"status": bson.M{"$in": op.checkedStatus()}},
 	}}
 	var results []Item
 	err := c.Find(q).Sort(op.Sortkey).All(&results)
@@ -536,26 +483,7 @@ func Searchnum(project string, items []Item) (Infobarnum, error) {
 		if item.Type == "org" || item.Type == "left" {
 			results.Shot++
 		}
-		switch item.Status {
-		case ASSIGN:
-			results.Assign++
-		case READY:
-			results.Ready++
-		case WIP:
-			results.Wip++
-		case CONFIRM:
-			results.Confirm++
-		case DONE:
-			results.Done++
-		case OMIT:
-			results.Omit++
-		case HOLD:
-			results.Hold++
-		case OUT:
-			results.Out++
-		case NONE:
-			results.None++
-		}
+		results.addStatusNum(item.Status, 1)
 	}
 	return results, nil
 }
@@ -566,167 +494,101 @@ func Totalnum(session *mgo.Session, project string) (Infobarnum, error) {
 		return Infobarnum{}, nil
 	}
 	session.SetMode(mgo.Monotonic, true)
-	c := session.DB("project").C(project)
-
-	var results Infobarnum
-	//진행률 출력.
-	assign := bson.M{}
-	assign = bson.M{"$and": []bson.M{
-		bson.M{"status": ASSIGN},
-		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}}},
-	}}
-	var assignnum int
-	assignnum, err := c.Find(assign).Count()
+	statuses, err := AllStatuses(session, project)
 	if err != nil {
-		log.Println("DB Find Err : ", err)
-		return Infobarnum{}, err
-	}
-
-	ready := bson.M{}
-	ready = bson.M{"$and": []bson.M{
-		bson.M{"status": READY},
-		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}}},
-	}}
-	var readynum int
-	readynum, err = c.Find(ready).Count()
-	if err != nil {
-		log.Println("DB Find Err : ", err)
-		return Infobarnum{}, err
-	}
-
-	wip := bson.M{"$and": []bson.M{
-		bson.M{"status": WIP},
-		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}}},
-	}}
-	var wipnum int
-	wipnum, err = c.Find(wip).Count()
-	if err != nil {
-		log.Println("DB Find Err : ", err)
 		return Infobarnum{}, err
 	}
+	c := session.DB("project").C(project)
 
-	confirm := bson.M{}
-	confirm = bson.M{"$and": []bson.M{
-		bson.M{"status": CONFIRM},
-		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}}},
-	}}
-	var confirmnum int
-	confirmnum, err = c.Find(confirm).Count()
-	if err != nil {
-		log.Println("DB Find Err : ", err)
-		return Infobarnum{}, err
+	var results Infobarnum
+	//진행률 출력.
+	shotQuery := bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}}}
+	for _, s := range statuses {
+		num, err := c.Find(bson.M{"$and": []bson.M{bson.M{"status": s.ID}, shotQuery}}).Count()
+		if err != nil {
+			log.Println("DB Find Err : ", err)
+			return Infobarnum{}, err
+		}
+		results.addStatusNum(s.ID, num)
 	}
-
-	done := bson.M{"$and": []bson.M{
-		bson.M{"status": DONE},
-		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}}},
-	}}
-	var donenum int
-	donenum, err = c.Find(done).Count()
+	results.Total, err = c.Find(shotQuery).Count()
 	if err != nil {
 		log.Println("DB Find Err : ", err)
 		return Infobarnum{}, err
 	}
+	return results, nil
+}
 
-	omit := bson.M{"$and": []bson.M{
-		bson.M{"status": OMIT},
-		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}}},
-	}}
-	var omitnum int
-	omitnum, err = c.Find(omit).Count()
+// setTaskMov함수는 해당 샷에 mov를 설정하는 함수이다.
+// Mov, Mdate는 최신 mov로 바뀌고 mov 등록이력이 Movs에 추가된다.
+func setTaskMov(session *mgo.Session, project, name, task, mov, note string, editor Editor) error {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
 	if err != nil {
-		log.Println("DB Find Err : ", err)
-		return Infobarnum{}, err
+		return err
 	}
-
-	hold := bson.M{"$and": []bson.M{
-		bson.M{"status": HOLD},
-		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}}},
-	}}
-	var holdnum int
-	holdnum, err = c.Find(hold).Count()
+	typ, err := Type(session, project, name)
 	if err != nil {
-		log.Println("DB Find Err : ", err)
-		return Infobarnum{}, err
+		return err
 	}
-
-	out := bson.M{"$and": []bson.M{
-		bson.M{"status": OUT},
-		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}}},
-	}}
-	var outnum int
-	outnum, err = c.Find(out).Count()
+	id := name + "_" + typ
+	item, err := getItem(session, project, id)
 	if err != nil {
-		log.Println("DB Find Err : ", err)
-		return Infobarnum{}, err
+		return err
 	}
-
-	none := bson.M{"$and": []bson.M{
-		bson.M{"status": NONE},
-		bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}}},
-	}}
-	var nonenum int
-	nonenum, err = c.Find(none).Count()
-	if err != nil {
-		log.Println("DB Find Err : ", err)
-		return Infobarnum{}, err
+	// 이력을 기록하기 전에 등록된 mov가 있다면 함께 기록한다.
+	records := []MovRecord{}
+	if t, found := item.Tasks[task]; found {
+		records = taskMovs(t)
 	}
-
-	var totalnum int
-	totalnum, err = c.Find(bson.M{"$or": []bson.M{bson.M{"type": "org"}, bson.M{"type": "left"}}}).Count()
+	record := newMovRecord(mov, note, editor.ID, time.Now())
+	records = append(records, record)
+	err = patchTaskFields(session, project, id, task, map[string]interface{}{"mov": mov, "mdate": record.Date, "movs": records}, editor)
 	if err != nil {
-		log.Println("DB Find Err : ", err)
-		return Infobarnum{}, err
+		return err
 	}
-
-	results.Assign = assignnum
-	results.Ready = readynum
-	results.Wip = wipnum
-	results.Confirm = confirmnum
-	results.Done = donenum
-	results.Omit = omitnum
-	results.Hold = holdnum
-	results.Out = outnum
-	results.None = nonenum
-	results.Total = totalnum
-
-	return results, nil
+	return nil
 }
 
-// setTaskMov함수는 해당 샷에 mov를 설정하는 함수이다.
-func setTaskMov(session *mgo.Session, project, name, task, mov string) error {
+// TaskMovs 함수는 Task의 mov 등록이력을 최신 순서로 반환한다.
+func TaskMovs(session *mgo.Session, project, name, task string) ([]MovRecord, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	c := session.DB("project").C(project)
 	typ, err := Type(session, project, name)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	id := name + "_" + typ
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"tasks." + task + ".mov": mov, task + ".mdate": time.Now().Format(time.RFC3339)}})
+	item, err := getItem(session, project, name+"_"+typ)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	t, found := item.Tasks[task]
+	if !found {
+		return nil, fmt.Errorf("%s 에 %s Task가 존재하지 않습니다", name, task)
+	}
+	movs := taskMovs(t)
+	for i, j := 0, len(movs)-1; i < j; i, j = i+1, j-1 {
+		movs[i], movs[j] = movs[j], movs[i]
+	}
+	return movs, nil
 }
 
 // setTaskDue함수는 해당 샷에 mov를 설정하는 함수이다.
-func setTaskDue(session *mgo.Session, project, name, task string, due int) error {
+func setTaskDue(session *mgo.Session, project, name, task string, due int, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
 	typ, err := Type(session, project, name)
 	if err != nil {
 		return err
 	}
 	id := name + "_" + typ
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"tasks." + task + ".due": due, task + ".mdate": time.Now().Format(time.RFC3339)}})
+	err = patchTaskFields(session, project, id, task, map[string]interface{}{"due": due}, editor)
 	if err != nil {
 		return err
 	}
@@ -734,14 +596,12 @@ func setTaskDue(session *mgo.Session, project, name, task string, due int) error
 }
 
 // setTaskLevel함수는 해당 샷에 level를 설정하는 함수이다.
-func setTaskLevel(session *mgo.Session, project, name, task, level string) error {
+func setTaskLevel(session *mgo.Session, project, name, task, level string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-
 	typ, err := Type(session, project, name)
 	if err != nil {
 		return err
@@ -751,7 +611,7 @@ func setTaskLevel(session *mgo.Session, project, name, task, level string) error
 	if err != nil {
 		return err
 	}
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"tasks." + task + ".tasklevel": TaskLevel(l)}})
+	err = patchTaskFields(session, project, id, task, map[string]interface{}{"tasklevel": TaskLevel(l)}, editor)
 	if err != nil {
 		return err
 	}
@@ -770,7 +630,7 @@ func Type(session *mgo.Session, project, name string) (string, error) {
 		return "", err
 	}
 	if len(items) == 0 {
-		return "", errors.New(name + "에 해당하는 org,left,asset 타입을 DB에서 찾을 수 없습니다.")
+		return "", NotFoundError(name + "에 해당하는 org,left,asset 타입을 DB에서 찾을 수 없습니다.")
 	}
 	if len(items) != 1 {
 		return "", errors.New(name + "값이 DB에서 고유하지 않습니다.")
@@ -780,7 +640,7 @@ func Type(session *mgo.Session, project, name string) (string, error) {
 
 // SetImageSize 함수는 해당 샷의 이미지 사이즈를 설정한다.
 // key 설정값 : platesize, distortionsize, rendersize
-func SetImageSize(session *mgo.Session, project, name, key, size string) (string, error) {
+func SetImageSize(session *mgo.Session, project, name, key, size string, editor Editor) (string, error) {
 	if !(key == "platesize" || key == "dsize" || key == "rendersize") {
 		return "", errors.New("잘못된 key값입니다")
 	}
@@ -794,8 +654,7 @@ func SetImageSize(session *mgo.Session, project, name, key, size string) (string
 		return "", err
 	}
 	id := name + "_" + typ
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{key: size, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, id, map[string]interface{}{key: size}, editor)
 	if err != nil {
 		return id, err
 	}
@@ -804,7 +663,7 @@ func SetImageSize(session *mgo.Session, project, name, key, size string) (string
 
 // SetTimecode 함수는 item에 Timecode를 설정한다.
 // ScanTimecodeIn,ScanTimecodeOut,JustTimecodeIn,JustTimecoeOut 문자를 key로 사용할 수 있다.
-func SetTimecode(session *mgo.Session, project, name, key, timecode string) error {
+func SetTimecode(session *mgo.Session, project, name, key, timecode string, editor Editor) error {
 	key = strings.ToLower(key)
 	if !(key == "scantimecodein" ||
 		key == "scantimecodeout" ||
@@ -821,15 +680,15 @@ func SetTimecode(session *mgo.Session, project, name, key, timecode string) erro
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": name + "_" + typ}, bson.M{"$set": bson.M{key: timecode, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, name+"_"+typ, map[string]interface{}{key: timecode}, editor)
 	if err != nil {
 		return err
 	}
 	// 우리회사는 현재 timecode와 keycode를 혼용해서 사용중이다.
 	// 원래는 Timecode가 맞지만 현재 DB가 keycode로 되어있어 아직은 아래줄이 필요하다.
+	// keycode는 Item 필드가 아니므로 PatchItem을 사용할 수 없다.
 	key = strings.Replace(key, "timecode", "keycode", -1)
-	err = c.Update(bson.M{"id": name + "_" + typ}, bson.M{"$set": bson.M{key: timecode, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = updateItem(session, project, name+"_"+typ, bson.M{"$set": bson.M{key: timecode, "updatetime": time.Now().Format(time.RFC3339)}}, editor)
 	if err != nil {
 		return err
 	}
@@ -837,7 +696,7 @@ func SetTimecode(session *mgo.Session, project, name, key, timecode string) erro
 }
 
 // SetUseType 함수는 item에 UseType string을 설정한다.
-func SetUseType(session *mgo.Session, project, name, usetype string) error {
+func SetUseType(session *mgo.Session, project, name, usetype string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -847,8 +706,7 @@ func SetUseType(session *mgo.Session, project, name, usetype string) error {
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": name + "_" + typ}, bson.M{"$set": bson.M{"usetype": usetype, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, name+"_"+typ, map[string]interface{}{"usetype": usetype}, editor)
 	if err != nil {
 		return err
 	}
@@ -857,7 +715,7 @@ func SetUseType(session *mgo.Session, project, name, usetype string) error {
 
 // SetFrame 함수는 item에 프레임을 설정한다.
 // ScanIn,ScanOut,ScanFrame,PlateIn,PlateOut,JustIn,JustOut,HandleIn,HandleOut 문자를 key로 사용할 수 있다.
-func SetFrame(session *mgo.Session, project, name, key string, frame int) error {
+func SetFrame(session *mgo.Session, project, name, key string, frame int, editor Editor) error {
 	if frame == -1 {
 		return nil
 	}
@@ -881,8 +739,7 @@ func SetFrame(session *mgo.Session, project, name, key string, frame int) error
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": name + "_" + typ}, bson.M{"$set": bson.M{key: frame, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, name+"_"+typ, map[string]interface{}{key: frame}, editor)
 	if err != nil {
 		return err
 	}
@@ -890,7 +747,7 @@ func SetFrame(session *mgo.Session, project, name, key string, frame int) error
 }
 
 // SetCameraPubPath 함수는 해당 카메라 퍼블리쉬 경로를 설정한다.
-func SetCameraPubPath(session *mgo.Session, project, name, path string) error {
+func SetCameraPubPath(session *mgo.Session, project, name, path string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -900,8 +757,7 @@ func SetCameraPubPath(session *mgo.Session, project, name, path string) error {
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": name + "_" + typ}, bson.M{"$set": bson.M{"productioncam.pubpath": path, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, name+"_"+typ, map[string]interface{}{"productioncam": map[string]interface{}{"pubpath": path}}, editor)
 	if err != nil {
 		return err
 	}
@@ -909,7 +765,7 @@ func SetCameraPubPath(session *mgo.Session, project, name, path string) error {
 }
 
 // SetCameraPubTask 함수는 해당 카메라 퍼블리쉬 팀을 설정한다.
-func SetCameraPubTask(session *mgo.Session, project, name, task string) error {
+func SetCameraPubTask(session *mgo.Session, project, name, task string, editor Editor) error {
 	if !(task == "" || task == "mm" || task == "layout" || task == "ani") {
 		return errors.New("none(빈문자열), mm, layout, ani 팀만 카메라 publish가 가능합니다")
 	}
@@ -922,8 +778,7 @@ func SetCameraPubTask(session *mgo.Session, project, name, task string) error {
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": name + "_" + typ}, bson.M{"$set": bson.M{"productioncam.pubtask": task, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, name+"_"+typ, map[string]interface{}{"productioncam": map[string]interface{}{"pubtask": task}}, editor)
 	if err != nil {
 		return err
 	}
@@ -931,7 +786,7 @@ func SetCameraPubTask(session *mgo.Session, project, name, task string) error {
 }
 
 // SetCameraProjection 함수는 샷에 Projection 카메라 사용여부를 체크한다.
-func SetCameraProjection(session *mgo.Session, project, name string, isProjection bool) error {
+func SetCameraProjection(session *mgo.Session, project, name string, isProjection bool, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -941,8 +796,7 @@ func SetCameraProjection(session *mgo.Session, project, name string, isProjectio
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": name + "_" + typ}, bson.M{"$set": bson.M{"productioncam.projection": isProjection, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, name+"_"+typ, map[string]interface{}{"productioncam": map[string]interface{}{"projection": isProjection}}, editor)
 	if err != nil {
 		return err
 	}
@@ -950,7 +804,7 @@ func SetCameraProjection(session *mgo.Session, project, name string, isProjectio
 }
 
 // SetObjectID 함수는 Item에 Object In, Out 값을 설정한다.
-func SetObjectID(session *mgo.Session, project, name string, in, out int) error {
+func SetObjectID(session *mgo.Session, project, name string, in, out int, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -963,8 +817,7 @@ func SetObjectID(session *mgo.Session, project, name string, in, out int) error
 	if typ != "asset" {
 		return errors.New("asset 타입이 아닙니다")
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": name + "_" + typ}, bson.M{"$set": bson.M{"objectidin": in, "objectidout": out, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, name+"_"+typ, map[string]interface{}{"objectidin": in, "objectidout": out}, editor)
 	if err != nil {
 		return err
 	}
@@ -972,7 +825,7 @@ func SetObjectID(session *mgo.Session, project, name string, in, out int) error
 }
 
 // SetThummov 함수는 item에 Thummov값을 셋팅한다.
-func SetThummov(session *mgo.Session, project, name, path string) error {
+func SetThummov(session *mgo.Session, project, name, path string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -982,16 +835,16 @@ func SetThummov(session *mgo.Session, project, name, path string) error {
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": name + "_" + typ}, bson.M{"$set": bson.M{"thummov": path, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, name+"_"+typ, map[string]interface{}{"thummov": path}, editor)
 	if err != nil {
 		return err
 	}
+	autoThumbnail(session, project, name+"_"+typ)
 	return nil
 }
 
 // SetBeforemov 함수는 item에 Before mov값을 셋팅한다.
-func SetBeforemov(session *mgo.Session, project, name, path string) error {
+func SetBeforemov(session *mgo.Session, project, name, path string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1001,8 +854,7 @@ func SetBeforemov(session *mgo.Session, project, name, path string) error {
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": name + "_" + typ}, bson.M{"$set": bson.M{"beforemov": path, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, name+"_"+typ, map[string]interface{}{"beforemov": path}, editor)
 	if err != nil {
 		return err
 	}
@@ -1010,7 +862,7 @@ func SetBeforemov(session *mgo.Session, project, name, path string) error {
 }
 
 // SetAftermov 함수는 item에 After mov값을 셋팅한다.
-func SetAftermov(session *mgo.Session, project, name, path string) error {
+func SetAftermov(session *mgo.Session, project, name, path string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1020,8 +872,7 @@ func SetAftermov(session *mgo.Session, project, name, path string) error {
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": name + "_" + typ}, bson.M{"$set": bson.M{"aftermov": path, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, name+"_"+typ, map[string]interface{}{"aftermov": path}, editor)
 	if err != nil {
 		return err
 	}
@@ -1029,7 +880,8 @@ func SetAftermov(session *mgo.Session, project, name, path string) error {
 }
 
 // SetTaskStatus 함수는 item에 task의 status 값을 셋팅한다.
-func SetTaskStatus(session *mgo.Session, project, name, task, status string) error {
+// 프로젝트에 상태변경 규칙이 있다면 사용자의 AccessLevel로 변경가능 여부를 체크한다.
+func SetTaskStatus(session *mgo.Session, project, name, task, status string, level AccessLevel, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1039,47 +891,7 @@ func SetTaskStatus(session *mgo.Session, project, name, task, status string) err
 	if err != nil {
 		return err
 	}
-	id := name + "_" + typ
-	item, err := getItem(session, project, id)
-	if err != nil {
-		return err
-	}
-	statusNum := ""
-	switch strings.ToLower(status) {
-	case READY, "ready":
-		statusNum = READY
-	case ASSIGN, "assign":
-		statusNum = ASSIGN
-	case WIP, "wip":
-		statusNum = WIP
-	case CONFIRM, "confirm":
-		statusNum = CONFIRM
-	case DONE, "done":
-		statusNum = DONE
-	case OMIT, "omit":
-		statusNum = OMIT
-	case HOLD, "hold":
-		statusNum = HOLD
-	case OUT, "out":
-		statusNum = OUT
-	case NONE, "none":
-		statusNum = NONE
-	}
-	if statusNum == "" {
-		return errors.New("올바른 status가 아닙니다")
-	}
-	if _, found := item.Tasks[strings.ToLower(task)]; !found {
-		return errors.New("task가 존재하지 않습니다")
-	}
-	t := item.Tasks[task]
-	t.BeforeStatus = t.Status
-	t.Status = statusNum
-	item.Tasks[task] = t
-
-	c := session.DB("project").C(project)
-	item.Updatetime = time.Now().Format(time.RFC3339)
-	item.updateStatus()
-	err = c.Update(bson.M{"id": item.ID}, item)
+	_, err = PatchTask(session, project, name+"_"+typ, task, map[string]interface{}{"status": status}, level, editor)
 	if err != nil {
 		return err
 	}
@@ -1103,13 +915,13 @@ func HasTask(session *mgo.Session, project, name, task string) error {
 		return err
 	}
 	if _, found := item.Tasks[task]; !found {
-		return fmt.Errorf("%s 프로젝트 %s에 %s Task가 존재하지 않습니다", project, name, task)
+		return NotFoundError(fmt.Sprintf("%s 프로젝트 %s에 %s Task가 존재하지 않습니다", project, name, task))
 	}
 	return nil
 }
 
 // SetAssignTask 함수는 item에 task의 assign을 셋팅한다.
-func SetAssignTask(session *mgo.Session, project, name, taskname string, remove bool) (string, error) {
+func SetAssignTask(session *mgo.Session, project, name, taskname string, remove bool, editor Editor) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1139,28 +951,42 @@ func SetAssignTask(session *mgo.Session, project, name, taskname string, remove
 	if !remove {
 		delete(item.Tasks, task)
 	}
-	c := session.DB("project").C(project)
+	statuses, err := AllStatuses(session, project)
+	if err != nil {
+		return "", err
+	}
 	item.Updatetime = time.Now().Format(time.RFC3339)
-	item.updateStatus()
-	err = c.Update(bson.M{"id": item.ID}, item)
+	item.updateStatus(statuses)
+	err = updateItem(session, project, item.ID, item, editor)
 	if err != nil {
 		return "", err
 	}
+	// Task가 배정되면 Tasksetting의 폴더 구조를 생성한다.
+	if remove {
+		// Task는 이미 배정되었으므로 폴더 생성 에러는 로그로만 남긴다.
+		_, err = MkTaskFolders(session, item, task)
+		if err != nil {
+			log.Printf("%s Task는 배정되었지만 폴더를 생성하지 못했습니다: %v", task, err)
+		}
+	}
 	return id, nil
 }
 
 // RmTask 함수는 item에 task를 제거한다.
-func RmTask(session *mgo.Session, project, id, taskname string) (string, error) {
+func RmTask(session *mgo.Session, project, id, taskname string, editor Editor) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	item, err := getItem(session, project, id)
 	if err != nil {
 		return "", err
 	}
 	delete(item.Tasks, taskname)
-	c := session.DB("project").C(project)
+	statuses, err := AllStatuses(session, project)
+	if err != nil {
+		return "", err
+	}
 	item.Updatetime = time.Now().Format(time.RFC3339)
-	item.updateStatus()
-	err = c.Update(bson.M{"id": item.ID}, item)
+	item.updateStatus(statuses)
+	err = updateItem(session, project, item.ID, item, editor)
 	if err != nil {
 		return "", err
 	}
@@ -1168,7 +994,7 @@ func RmTask(session *mgo.Session, project, id, taskname string) (string, error)
 }
 
 // SetTaskUser 함수는 item에 task의 user 값을 셋팅한다.
-func SetTaskUser(session *mgo.Session, project, name, task, user string) error {
+func SetTaskUser(session *mgo.Session, project, name, task, user string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1183,8 +1009,7 @@ func SetTaskUser(session *mgo.Session, project, name, task, user string) error {
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": item.ID}, bson.M{"$set": bson.M{"tasks." + task + ".user": user, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = patchTaskFields(session, project, item.ID, task, map[string]interface{}{"user": user}, editor)
 	if err != nil {
 		return err
 	}
@@ -1192,7 +1017,7 @@ func SetTaskUser(session *mgo.Session, project, name, task, user string) error {
 }
 
 // SetTaskDate 함수는 item에 task에 마감일을 셋팅한다.
-func SetTaskDate(session *mgo.Session, project, name, task, date string) error {
+func SetTaskDate(session *mgo.Session, project, name, task, date string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1207,12 +1032,11 @@ func SetTaskDate(session *mgo.Session, project, name, task, date string) error {
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	fullTime, err := ditime.ToFullTime(19, date)
+	fullTime, err := toFullTime(date)
 	if err != nil {
 		return err
 	}
-	err = c.Update(bson.M{"id": item.ID}, bson.M{"$set": bson.M{"tasks." + task + ".date": fullTime, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = patchTaskFields(session, project, item.ID, task, map[string]interface{}{"date": fullTime}, editor)
 	if err != nil {
 		return err
 	}
@@ -1220,7 +1044,7 @@ func SetTaskDate(session *mgo.Session, project, name, task, date string) error {
 }
 
 // SetDeadline2D 함수는 item에 2D마감일을 셋팅한다.
-func SetDeadline2D(session *mgo.Session, project, name, date string) (string, error) {
+func SetDeadline2D(session *mgo.Session, project, name, date string, editor Editor) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1231,12 +1055,11 @@ func SetDeadline2D(session *mgo.Session, project, name, date string) (string, er
 		return "", err
 	}
 	id := name + "_" + typ
-	fullTime, err := ditime.ToFullTime(19, date)
+	fullTime, err := toFullTime(date)
 	if err != nil {
 		return id, err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"ddline2d": fullTime, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, id, map[string]interface{}{"ddline2d": fullTime}, editor)
 	if err != nil {
 		return id, err
 	}
@@ -1244,7 +1067,7 @@ func SetDeadline2D(session *mgo.Session, project, name, date string) (string, er
 }
 
 // SetDeadline3D 함수는 item에 3D마감일을 셋팅한다.
-func SetDeadline3D(session *mgo.Session, project, name, date string) (string, error) {
+func SetDeadline3D(session *mgo.Session, project, name, date string, editor Editor) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1255,12 +1078,11 @@ func SetDeadline3D(session *mgo.Session, project, name, date string) (string, er
 		return "", err
 	}
 	id := name + "_" + typ
-	fullTime, err := ditime.ToFullTime(19, date)
+	fullTime, err := toFullTime(date)
 	if err != nil {
 		return id, err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"ddline3d": fullTime, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, id, map[string]interface{}{"ddline3d": fullTime}, editor)
 	if err != nil {
 		return id, err
 	}
@@ -1268,7 +1090,7 @@ func SetDeadline3D(session *mgo.Session, project, name, date string) (string, er
 }
 
 // SetTaskStartdate 함수는 item에 task의 startdate 값을 셋팅한다.
-func SetTaskStartdate(session *mgo.Session, project, name, task, date string) error {
+func SetTaskStartdate(session *mgo.Session, project, name, task, date string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1279,12 +1101,11 @@ func SetTaskStartdate(session *mgo.Session, project, name, task, date string) er
 		return err
 	}
 	id := name + "_" + typ
-	c := session.DB("project").C(project)
-	fullTime, err := ditime.ToFullTime(19, date)
+	fullTime, err := toFullTime(date)
 	if err != nil {
 		return err
 	}
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"tasks." + task + ".startdate": fullTime, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = patchTaskFields(session, project, id, task, map[string]interface{}{"startdate": fullTime}, editor)
 	if err != nil {
 		return err
 	}
@@ -1292,7 +1113,7 @@ func SetTaskStartdate(session *mgo.Session, project, name, task, date string) er
 }
 
 // SetTaskUserNote 함수는 item에 task의 user note 값을 셋팅한다.
-func SetTaskUserNote(session *mgo.Session, project, name, task, usernote string) error {
+func SetTaskUserNote(session *mgo.Session, project, name, task, usernote string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1303,8 +1124,7 @@ func SetTaskUserNote(session *mgo.Session, project, name, task, usernote string)
 		return err
 	}
 	id := name + "_" + typ
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"tasks." + task + ".usernote": usernote, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = patchTaskFields(session, project, id, task, map[string]interface{}{"usernote": usernote}, editor)
 	if err != nil {
 		return err
 	}
@@ -1312,7 +1132,7 @@ func SetTaskUserNote(session *mgo.Session, project, name, task, usernote string)
 }
 
 // SetTaskPredate 함수는 item에 task의 predate 값을 셋팅한다.
-func SetTaskPredate(session *mgo.Session, project, name, task, date string) (string, error) {
+func SetTaskPredate(session *mgo.Session, project, name, task, date string, editor Editor) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1327,12 +1147,11 @@ func SetTaskPredate(session *mgo.Session, project, name, task, date string) (str
 	if err != nil {
 		return id, err
 	}
-	c := session.DB("project").C(project)
-	fullTime, err := ditime.ToFullTime(19, date)
+	fullTime, err := toFullTime(date)
 	if err != nil {
 		return id, err
 	}
-	err = c.Update(bson.M{"id": item.ID}, bson.M{"$set": bson.M{"tasks." + task + ".predate": fullTime, "updatetime": time.Now().Format(time.RFC3339)}})
+	err = patchTaskFields(session, project, item.ID, task, map[string]interface{}{"predate": fullTime}, editor)
 	if err != nil {
 		return id, err
 	}
@@ -1340,7 +1159,7 @@ func SetTaskPredate(session *mgo.Session, project, name, task, date string) (str
 }
 
 // SetShotType 함수는 item에 shot type을 셋팅한다.
-func SetShotType(session *mgo.Session, project, name, shottype string) (string, error) {
+func SetShotType(session *mgo.Session, project, name, shottype string, editor Editor) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1358,8 +1177,7 @@ func SetShotType(session *mgo.Session, project, name, shottype string) (string,
 	if err != nil {
 		return id, err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"shottype": shottype, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, id, map[string]interface{}{"shottype": shottype}, editor)
 	if err != nil {
 		return id, err
 	}
@@ -1367,7 +1185,7 @@ func SetShotType(session *mgo.Session, project, name, shottype string) (string,
 }
 
 // SetOutputName 함수는 item에 Outputname 을 셋팅한다.
-func SetOutputName(session *mgo.Session, project, name, outputname string) error {
+func SetOutputName(session *mgo.Session, project, name, outputname string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1384,8 +1202,7 @@ func SetOutputName(session *mgo.Session, project, name, outputname string) error
 		return errors.New("outputname 이 빈 문자열 입니다")
 	}
 	id := name + "_" + typ
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"outputname": outputname, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, id, map[string]interface{}{"outputname": outputname}, editor)
 	if err != nil {
 		return err
 	}
@@ -1393,7 +1210,7 @@ func SetOutputName(session *mgo.Session, project, name, outputname string) error
 }
 
 // SetRetimePlate 함수는 item에 RetimePlate를 셋팅한다.
-func SetRetimePlate(session *mgo.Session, project, name, retimeplate string) error {
+func SetRetimePlate(session *mgo.Session, project, name, retimeplate string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1407,8 +1224,7 @@ func SetRetimePlate(session *mgo.Session, project, name, retimeplate string) err
 		return fmt.Errorf("%s 는 %s type 입니다. retime plate를 설정할 수 없습니다", name, typ)
 	}
 	id := name + "_" + typ
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"retimeplate": retimeplate, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, id, map[string]interface{}{"retimeplate": retimeplate}, editor)
 	if err != nil {
 		return err
 	}
@@ -1416,7 +1232,7 @@ func SetRetimePlate(session *mgo.Session, project, name, retimeplate string) err
 }
 
 // SetOCIOcc 함수는 item에 OCIO .cc를 셋팅한다.
-func SetOCIOcc(session *mgo.Session, project, name, path string) error {
+func SetOCIOcc(session *mgo.Session, project, name, path string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1430,8 +1246,7 @@ func SetOCIOcc(session *mgo.Session, project, name, path string) error {
 		return fmt.Errorf("%s 는 %s type 입니다. 설정할 수 없습니다", name, typ)
 	}
 	id := name + "_" + typ
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"ociocc": path, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, id, map[string]interface{}{"ociocc": path}, editor)
 	if err != nil {
 		return err
 	}
@@ -1439,7 +1254,7 @@ func SetOCIOcc(session *mgo.Session, project, name, path string) error {
 }
 
 // SetRollmedia 함수는 item에 Setellite Rollmedia를 셋팅한다.
-func SetRollmedia(session *mgo.Session, project, name, rollmedia string) error {
+func SetRollmedia(session *mgo.Session, project, name, rollmedia string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1450,8 +1265,7 @@ func SetRollmedia(session *mgo.Session, project, name, rollmedia string) error {
 		return err
 	}
 	id := name + "_" + typ
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"rollmedia": rollmedia, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, id, map[string]interface{}{"rollmedia": rollmedia}, editor)
 	if err != nil {
 		return err
 	}
@@ -1459,7 +1273,7 @@ func SetRollmedia(session *mgo.Session, project, name, rollmedia string) error {
 }
 
 // SetRnum 함수는 샷에 롤넘버를 설정한다.
-func SetRnum(session *mgo.Session, project, name, rnum string) (string, error) {
+func SetRnum(session *mgo.Session, project, name, rnum string, editor Editor) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1473,12 +1287,7 @@ func SetRnum(session *mgo.Session, project, name, rnum string) (string, error) {
 		return "", fmt.Errorf("%s 는 %s type 입니다. 변경할 수 없습니다", name, typ)
 	}
 	id := name + "_" + typ
-	item, err := getItem(session, project, id)
-	if err != nil {
-		return id, err
-	}
-	item.Rnum = rnum
-	err = setItem(session, project, item)
+	_, err = PatchItem(session, project, id, map[string]interface{}{"rnum": rnum}, editor)
 	if err != nil {
 		return id, err
 	}
@@ -1486,7 +1295,7 @@ func SetRnum(session *mgo.Session, project, name, rnum string) (string, error) {
 }
 
 // SetAssetType 함수는 item에 assettype을 셋팅한다.
-func SetAssetType(session *mgo.Session, project, name, assettype string) (string, string, string, error) {
+func SetAssetType(session *mgo.Session, project, name, assettype string, editor Editor) (string, string, string, error) {
 	_, err := validAssettype(assettype)
 	if err != nil {
 		return "", "", assettype, err
@@ -1508,11 +1317,8 @@ func SetAssetType(session *mgo.Session, project, name, assettype string) (string
 	if err != nil {
 		return id, "", assettype, err
 	}
-	var beforeType string
-	beforeType = i.Assettype
-	i.Assettype = assettype
-	i.setAssettags()
-	err = setItem(session, project, i)
+	beforeType := i.Assettype
+	_, err = PatchItem(session, project, id, map[string]interface{}{"assettype": assettype}, editor)
 	if err != nil {
 		return id, beforeType, assettype, err
 	}
@@ -1520,7 +1326,7 @@ func SetAssetType(session *mgo.Session, project, name, assettype string) (string
 }
 
 // SetScanTimecodeIn 함수는 item에 Scan Timecode In을 셋팅한다.
-func SetScanTimecodeIn(session *mgo.Session, project, name, timecode string) error {
+func SetScanTimecodeIn(session *mgo.Session, project, name, timecode string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1537,8 +1343,7 @@ func SetScanTimecodeIn(session *mgo.Session, project, name, timecode string) err
 	if !(regexpTimecode.MatchString(timecode) || timecode == "") {
 		return fmt.Errorf("%s 문자열은 00:00:00:00 형식의 문자열이 아닙니다", timecode)
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"scantimecodein": timecode, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, id, map[string]interface{}{"scantimecodein": timecode}, editor)
 	if err != nil {
 		return err
 	}
@@ -1546,7 +1351,7 @@ func SetScanTimecodeIn(session *mgo.Session, project, name, timecode string) err
 }
 
 // SetScanTimecodeOut 함수는 item에 Scan Timecode In을 셋팅한다.
-func SetScanTimecodeOut(session *mgo.Session, project, name, timecode string) error {
+func SetScanTimecodeOut(session *mgo.Session, project, name, timecode string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1563,8 +1368,7 @@ func SetScanTimecodeOut(session *mgo.Session, project, name, timecode string) er
 	if !(regexpTimecode.MatchString(timecode) || timecode == "") {
 		return fmt.Errorf("%s 문자열은 00:00:00:00 형식의 문자열이 아닙니다", timecode)
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"scantimecodeout": timecode, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, id, map[string]interface{}{"scantimecodeout": timecode}, editor)
 	if err != nil {
 		return err
 	}
@@ -1572,7 +1376,7 @@ func SetScanTimecodeOut(session *mgo.Session, project, name, timecode string) er
 }
 
 // SetJustTimecodeIn 함수는 item에 Just Timecode In을 셋팅한다.
-func SetJustTimecodeIn(session *mgo.Session, project, name, timecode string) error {
+func SetJustTimecodeIn(session *mgo.Session, project, name, timecode string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1589,8 +1393,7 @@ func SetJustTimecodeIn(session *mgo.Session, project, name, timecode string) err
 	if !(regexpTimecode.MatchString(timecode) || timecode == "") {
 		return fmt.Errorf("%s 문자열은 00:00:00:00 형식의 문자열이 아닙니다", timecode)
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"justtimecodein": timecode, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, id, map[string]interface{}{"justtimecodein": timecode}, editor)
 	if err != nil {
 		return err
 	}
@@ -1598,7 +1401,7 @@ func SetJustTimecodeIn(session *mgo.Session, project, name, timecode string) err
 }
 
 // SetJustTimecodeOut 함수는 item에 Just Timecode In을 셋팅한다.
-func SetJustTimecodeOut(session *mgo.Session, project, name, timecode string) error {
+func SetJustTimecodeOut(session *mgo.Session, project, name, timecode string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1615,8 +1418,7 @@ func SetJustTimecodeOut(session *mgo.Session, project, name, timecode string) er
 	if !(regexpTimecode.MatchString(timecode) || timecode == "") {
 		return fmt.Errorf("%s 문자열은 00:00:00:00 형식의 문자열이 아닙니다", timecode)
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"justtimecodeout": timecode, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, id, map[string]interface{}{"justtimecodeout": timecode}, editor)
 	if err != nil {
 		return err
 	}
@@ -1624,7 +1426,7 @@ func SetJustTimecodeOut(session *mgo.Session, project, name, timecode string) er
 }
 
 // SetFinver 함수는 item에 파이널 버전을 셋팅한다.
-func SetFinver(session *mgo.Session, project, name, version string) error {
+func SetFinver(session *mgo.Session, project, name, version string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1635,8 +1437,7 @@ func SetFinver(session *mgo.Session, project, name, version string) error {
 		return err
 	}
 	id := name + "_" + typ
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"finver": version, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, id, map[string]interface{}{"finver": version}, editor)
 	if err != nil {
 		return err
 	}
@@ -1644,7 +1445,7 @@ func SetFinver(session *mgo.Session, project, name, version string) error {
 }
 
 // SetFindate 함수는 item에 최종 데이터 아웃풋 날짜를 셋팅한다.
-func SetFindate(session *mgo.Session, project, name, date string) error {
+func SetFindate(session *mgo.Session, project, name, date string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1655,12 +1456,11 @@ func SetFindate(session *mgo.Session, project, name, date string) error {
 		return err
 	}
 	id := name + "_" + typ
-	fullTime, err := ditime.ToFullTime(19, date)
+	fullTime, err := toFullTime(date)
 	if err != nil {
 		return err
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"findate": fullTime, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, id, map[string]interface{}{"findate": fullTime}, editor)
 	if err != nil {
 		return err
 	}
@@ -1668,7 +1468,8 @@ func SetFindate(session *mgo.Session, project, name, date string) error {
 }
 
 // SetCrowdAsset 함수는 item에 crowdtype을 설정한다.
-func SetCrowdAsset(session *mgo.Session, project, name string) (string, bool, error) {
+// Deprecated: 샷에 crowd 의존성을 추가하면 에셋의 CrowdAsset 값이 자동으로 설정된다.
+func SetCrowdAsset(session *mgo.Session, project, name string, editor Editor) (string, bool, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1679,13 +1480,12 @@ func SetCrowdAsset(session *mgo.Session, project, name string) (string, bool, er
 		return "", false, err
 	}
 	id := name + "_" + typ
-	c := session.DB("project").C(project)
 	item, err := getItem(session, project, id)
 	if err != nil {
 		return id, item.CrowdAsset, err
 	}
 	invertBool := !item.CrowdAsset
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"crowdasset": invertBool, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, id, map[string]interface{}{"crowdasset": invertBool}, editor)
 	if err != nil {
 		return id, invertBool, err
 	}
@@ -1693,7 +1493,7 @@ func SetCrowdAsset(session *mgo.Session, project, name string) (string, bool, er
 }
 
 // AddTag 함수는 item에 tag를 셋팅한다.
-func AddTag(session *mgo.Session, project, name, inputTag string) (string, error) {
+func AddTag(session *mgo.Session, project, name, inputTag string, editor Editor) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1715,8 +1515,7 @@ func AddTag(session *mgo.Session, project, name, inputTag string) (string, error
 		}
 	}
 	newTags := append(i.Tag, rmspaceTag)
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"tag": newTags, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, id, map[string]interface{}{"tag": newTags}, editor)
 	if err != nil {
 		return id, err
 	}
@@ -1724,7 +1523,7 @@ func AddTag(session *mgo.Session, project, name, inputTag string) (string, error
 }
 
 // RenameTag 함수는 item의 Tag를 리네임한다.
-func RenameTag(session *mgo.Session, project, before, after string) error {
+func RenameTag(session *mgo.Session, project, before, after string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	c := session.DB("project").C(project)
 	var items []Item
@@ -1743,7 +1542,7 @@ func RenameTag(session *mgo.Session, project, before, after string) error {
 			}
 		}
 		if !reflect.DeepEqual(beforeTags, newTags) {
-			err = c.Update(bson.M{"id": i.ID}, bson.M{"$set": bson.M{"tag": newTags, "updatetime": time.Now().Format(time.RFC3339)}})
+			_, err = PatchItem(session, project, i.ID, map[string]interface{}{"tag": newTags}, editor)
 			if err != nil {
 				return err
 			}
@@ -1753,7 +1552,7 @@ func RenameTag(session *mgo.Session, project, before, after string) error {
 }
 
 // SetTags 함수는 item에 tag를 교체한다.
-func SetTags(session *mgo.Session, project, name string, tags []string) error {
+func SetTags(session *mgo.Session, project, name string, tags []string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1763,14 +1562,8 @@ func SetTags(session *mgo.Session, project, name string, tags []string) error {
 	if err != nil {
 		return err
 	}
-	id := name + "_" + typ
-	i, err := getItem(session, project, id)
-	if err != nil {
-		return err
-	}
-	i.Tag = tags
-	// 만약 태그에 권정보가 없더라도 권관련 태그는 날아가면 안된다. setItem을 이용한다.
-	err = setItem(session, project, i)
+	// 만약 태그에 권정보가 없더라도 권관련 태그는 날아가면 안된다. PatchItem은 setItem을 이용한다.
+	_, err = PatchItem(session, project, name+"_"+typ, map[string]interface{}{"tag": tags}, editor)
 	if err != nil {
 		return err
 	}
@@ -1778,7 +1571,7 @@ func SetTags(session *mgo.Session, project, name string, tags []string) error {
 }
 
 // RmTag 함수는 item에 tag를 삭제한다.
-func RmTag(session *mgo.Session, project, name string, inputTag string) error {
+func RmTag(session *mgo.Session, project, name string, inputTag string, editor Editor) error {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1800,9 +1593,7 @@ func RmTag(session *mgo.Session, project, name string, inputTag string) error {
 		}
 		newTags = append(newTags, tag)
 	}
-	i.Tag = newTags
-	// 만약 태그에 권정보가 없더라도 권관련 태그는 날아가면 안된다. setItem을 이용한다.
-	err = setItem(session, project, i)
+	_, err = PatchItem(session, project, id, map[string]interface{}{"tag": newTags}, editor)
 	if err != nil {
 		return err
 	}
@@ -1810,13 +1601,12 @@ func RmTag(session *mgo.Session, project, name string, inputTag string) error {
 }
 
 // SetNote 함수는 item에 작업내용을 추가한다. Name,노트내용,에러를 반환한다.
-func SetNote(session *mgo.Session, project, id, userID, text string, overwrite bool) (string, string, error) {
+func SetNote(session *mgo.Session, project, id, userID, text string, overwrite bool, editor Editor) (string, string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
 		return "", "", err
 	}
-	c := session.DB("project").C(project)
 	i, err := getItem(session, project, id)
 	if err != nil {
 		return "", "", err
@@ -1831,7 +1621,7 @@ func SetNote(session *mgo.Session, project, id, userID, text string, overwrite b
 			note = text + "\n " + i.Note.Text
 		}
 	}
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"note.text": note, "note.author": userID, "note.date": time.Now().Format(time.RFC3339), "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, id, map[string]interface{}{"note": map[string]interface{}{"text": note, "author": userID, "date": time.Now().Format(time.RFC3339)}}, editor)
 	if err != nil {
 		return i.Name, "", err
 	}
@@ -1839,7 +1629,7 @@ func SetNote(session *mgo.Session, project, id, userID, text string, overwrite b
 }
 
 // AddComment 함수는 item에 수정사항을 추가한다.
-func AddComment(session *mgo.Session, project, name, userID, date, text, media string) (string, error) {
+func AddComment(session *mgo.Session, project, name, userID, date, text, media string, editor Editor) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1860,8 +1650,7 @@ func AddComment(session *mgo.Session, project, name, userID, date, text, media s
 		Text:   text,
 		Media:  media,
 	}
-	i.Comments = append(i.Comments, c)
-	err = setItem(session, project, i)
+	_, err = PatchItem(session, project, id, map[string]interface{}{"comments": append(i.Comments, c)}, editor)
 	if err != nil {
 		return id, err
 	}
@@ -1869,7 +1658,7 @@ func AddComment(session *mgo.Session, project, name, userID, date, text, media s
 }
 
 // EditComment 함수는 item에 수정사항을 수정한다.
-func EditComment(session *mgo.Session, project, id, date, text, media string) (string, error) {
+func EditComment(session *mgo.Session, project, id, date, text, media string, editor Editor) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1889,8 +1678,7 @@ func EditComment(session *mgo.Session, project, id, date, text, media string) (s
 		}
 		comments = append(comments, c)
 	}
-	c := session.DB("project").C(project)
-	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"comments": comments, "updatetime": time.Now().Format(time.RFC3339)}})
+	_, err = PatchItem(session, project, id, map[string]interface{}{"comments": comments}, editor)
 	if err != nil {
 		return i.Name, err
 	}
@@ -1898,7 +1686,7 @@ func EditComment(session *mgo.Session, project, id, date, text, media string) (s
 }
 
 // RmComment 함수는 item에 수정사항을 삭제합니다. 로그처리를 위해서 삭제 내용을 반환합니다.
-func RmComment(session *mgo.Session, project, name, userID, date string) (string, string, error) {
+func RmComment(session *mgo.Session, project, name, userID, date string, editor Editor) (string, string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1922,8 +1710,7 @@ func RmComment(session *mgo.Session, project, name, userID, date string) (string
 		}
 		newComments = append(newComments, comment)
 	}
-	i.Comments = newComments
-	err = setItem(session, project, i)
+	_, err = PatchItem(session, project, id, map[string]interface{}{"comments": newComments}, editor)
 	if err != nil {
 		return id, "", err
 	}
@@ -1931,7 +1718,7 @@ func RmComment(session *mgo.Session, project, name, userID, date string) (string
 }
 
 // AddSource 함수는 item에 소스링크를 추가한다.
-func AddSource(session *mgo.Session, project, name, userID, title, path string) (string, error) {
+func AddSource(session *mgo.Session, project, name, userID, title, path string, editor Editor) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1956,8 +1743,7 @@ func AddSource(session *mgo.Session, project, name, userID, title, path string)
 	s.Author = userID
 	s.Title = title
 	s.Path = path
-	i.Sources = append(i.Sources, s)
-	err = setItem(session, project, i)
+	_, err = PatchItem(session, project, id, map[string]interface{}{"links": append(i.Sources, s)}, editor)
 	if err != nil {
 		return id, err
 	}
@@ -1965,7 +1751,7 @@ func AddSource(session *mgo.Session, project, name, userID, title, path string)
 }
 
 // AddReference 함수는 item에 소스링크를 추가한다.
-func AddReference(session *mgo.Session, project, name, userID, title, path string) (string, error) {
+func AddReference(session *mgo.Session, project, name, userID, title, path string, editor Editor) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -1985,8 +1771,7 @@ func AddReference(session *mgo.Session, project, name, userID, title, path strin
 	r.Author = userID
 	r.Title = title
 	r.Path = path
-	i.References = append(i.References, r)
-	err = setItem(session, project, i)
+	_, err = PatchItem(session, project, id, map[string]interface{}{"references": append(i.References, r)}, editor)
 	if err != nil {
 		return id, err
 	}
@@ -1994,7 +1779,7 @@ func AddReference(session *mgo.Session, project, name, userID, title, path strin
 }
 
 // RmSource 함수는 item에서 소스를 삭제합니다.
-func RmSource(session *mgo.Session, project, name, title string) (string, error) {
+func RmSource(session *mgo.Session, project, name, title string, editor Editor) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2016,8 +1801,7 @@ func RmSource(session *mgo.Session, project, name, title string) (string, error)
 		}
 		newSources = append(newSources, source)
 	}
-	i.Sources = newSources
-	err = setItem(session, project, i)
+	_, err = PatchItem(session, project, id, map[string]interface{}{"links": newSources}, editor)
 	if err != nil {
 		return id, err
 	}
@@ -2025,7 +1809,7 @@ func RmSource(session *mgo.Session, project, name, title string) (string, error)
 }
 
 // RmReference 함수는 item에서 레퍼런스를 삭제합니다.
-func RmReference(session *mgo.Session, project, name, title string) (string, error) {
+func RmReference(session *mgo.Session, project, name, title string, editor Editor) (string, error) {
 	session.SetMode(mgo.Monotonic, true)
 	err := HasProject(session, project)
 	if err != nil {
@@ -2047,8 +1831,7 @@ func RmReference(session *mgo.Session, project, name, title string) (string, err
 		}
 		newReferences = append(newReferences, ref)
 	}
-	i.References = newReferences
-	err = setItem(session, project, i)
+	_, err = PatchItem(session, project, id, map[string]interface{}{"references": newReferences}, editor)
 	if err != nil {
 		return id, err
 	}
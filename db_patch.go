@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/mgo.v2"
+)
+
+// PatchItem 함수는 아이템의 필드를 부분수정한다.
+// patch는 JSON Merge Patch 형태로 수정할 필드만 담는다.
+func PatchItem(session *mgo.Session, project, id string, patch map[string]interface{}, editor Editor) (Item, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return Item{}, err
+	}
+	i, err := getItem(session, project, id)
+	if err != nil {
+		return Item{}, err
+	}
+	i, err = patchItem(i, patch)
+	if err != nil {
+		return Item{}, err
+	}
+	err = setItem(session, project, i, editor)
+	if err != nil {
+		return Item{}, err
+	}
+	return getItem(session, project, id)
+}
+
+// PatchTask 함수는 아이템 Task의 필드를 부분수정한다.
+// status가 변경된다면 프로젝트 상태변경 규칙을 사용자의 AccessLevel로 체크한다.
+func PatchTask(session *mgo.Session, project, id, task string, patch map[string]interface{}, level AccessLevel, editor Editor) (Task, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return Task{}, err
+	}
+	i, err := getItem(session, project, id)
+	if err != nil {
+		return Task{}, err
+	}
+	task = strings.ToLower(task)
+	before, found := i.Tasks[task]
+	if !found {
+		return Task{}, NotFoundError(fmt.Sprintf("%s 프로젝트 %s에 %s Task가 존재하지 않습니다", project, id, task))
+	}
+	t, err := patchTask(before, patch)
+	if err != nil {
+		return Task{}, err
+	}
+	if _, found := patch["status"]; found {
+		statuses, err := AllStatuses(session, project)
+		if err != nil {
+			return Task{}, err
+		}
+		s, err := findStatus(statuses, t.Status)
+		if err != nil {
+			return Task{}, err
+		}
+		transitions, err := AllTransitions(session, project)
+		if err != nil {
+			return Task{}, err
+		}
+		err = checkTransition(statuses, transitions, before.Status, s.ID, level)
+		if err != nil {
+			return Task{}, err
+		}
+		t.Status = s.ID
+		if before.Status != s.ID {
+			t.BeforeStatus = before.Status
+		}
+	}
+	i.Tasks[task] = t
+	err = setItem(session, project, i, editor)
+	if err != nil {
+		return Task{}, err
+	}
+	return t, nil
+}
+
+// patchTaskFields 함수는 status를 제외한 Task 필드를 부분수정한다.
+// 상태변경이 없으므로 상태변경 규칙을 체크하지 않는다. status는 PatchTask로 수정한다.
+func patchTaskFields(session *mgo.Session, project, id, task string, patch map[string]interface{}, editor Editor) error {
+	if _, found := patch["status"]; found {
+		return errors.New("status는 상태변경 규칙을 체크해야 합니다")
+	}
+	_, err := PatchTask(session, project, id, task, patch, UnknownAccessLevel, editor)
+	return err
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/mgo.v2"
+)
+
+// Paths 함수는 프로젝트, 아이템, Task 경로를 반환한다.
+// id가 빈 문자열이면 프로젝트 경로만, task가 빈 문자열이면 아이템의 모든 Task 경로를 반환한다.
+func Paths(session *mgo.Session, project, id, task string) (ItemPaths, error) {
+	session.SetMode(mgo.Monotonic, true)
+	p, err := getProject(session, project)
+	if err != nil {
+		return ItemPaths{}, err
+	}
+	s, err := GetAdminSetting(session)
+	if err != nil {
+		return ItemPaths{}, err
+	}
+	var item Item
+	if id != "" {
+		item, err = getItem(session, project, id)
+		if err != nil {
+			return ItemPaths{}, err
+		}
+	}
+	result, err := itemPaths(s, p, item)
+	if err != nil {
+		return result, err
+	}
+	if id == "" {
+		return result, nil
+	}
+	var tasks []string
+	if task != "" {
+		tasks = append(tasks, strings.ToLower(task))
+	} else {
+		for t := range item.Tasks {
+			tasks = append(tasks, t)
+		}
+		sort.Strings(tasks)
+	}
+	for _, t := range tasks {
+		ts, err := getTaskSetting(session, t+tasksettingType(item.Type))
+		if err == mgo.ErrNotFound && task == "" {
+			continue // Tasksetting이 없는 Task는 건너뛴다.
+		}
+		if err != nil {
+			return result, fmt.Errorf("%s Tasksetting: %v", t, err)
+		}
+		result.Tasks[t], err = taskPaths(ts, item, t)
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// LookupPath 함수는 파일시스템 경로가 속한 프로젝트, 아이템, Task를 찾는다.
+// 관리자 설정과 Tasksetting의 경로 템플릿 중 가장 깊게 일치하는 템플릿을 사용한다.
+func LookupPath(session *mgo.Session, path string) (PathInfo, error) {
+	session.SetMode(mgo.Monotonic, true)
+	info := PathInfo{Path: path}
+	if strings.TrimSpace(path) == "" {
+		return info, errors.New("path가 빈 문자열입니다")
+	}
+	s, err := GetAdminSetting(session)
+	if err != nil {
+		return info, err
+	}
+	tasksettings, err := AllTaskSettings(session)
+	if err != nil {
+		return info, err
+	}
+	p, vars, rest, found := matchPath(pathPatterns(s, tasksettings), path)
+	if !found || vars["Project"] == "" {
+		return info, fmt.Errorf("%s 경로와 일치하는 경로 템플릿이 없습니다", path)
+	}
+	info.Project = vars["Project"]
+	info.Template = p.Template
+	info.Task = p.Task
+	info.Rest = rest
+	err = HasProject(session, info.Project)
+	if err != nil {
+		return info, err
+	}
+	info.Name = pathVarsName(vars)
+	if info.Name == "" {
+		return info, nil
+	}
+	// 에셋 경로라면 에셋을, 아니라면 이름으로 타입을 찾는다.
+	if p.TaskType == "asset" || strings.HasPrefix(p.Template, "Asset") {
+		info.Type = "asset"
+	} else {
+		info.Type, err = Type(session, info.Project, info.Name)
+		if err != nil {
+			return info, err
+		}
+	}
+	item, err := getItem(session, info.Project, info.Name+"_"+info.Type)
+	if err != nil {
+		return info, fmt.Errorf("%s 프로젝트에 %s 아이템이 없습니다: %v", info.Project, info.Name, err)
+	}
+	info.ID = item.ID
+	return info, nil
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// PlaylistSearch 자료구조는 플레이리스트 항목을 만들 검색조건이다.
+type PlaylistSearch struct {
+	Project    string `json:"project"`    // 프로젝트. 빈 문자열이면 모든 프로젝트에서 찾는다.
+	Searchword string `json:"searchword"` // 검색어. 빈 문자열이면 Date를 검색어로 사용한다.
+	Task       string `json:"task"`       // Task 이름. 빈 문자열이면 모든 Task를 사용한다.
+	Date       string `json:"date"`       // 데일리 날짜. 그날 mov가 등록된 Task만 사용한다.
+}
+
+// Playlists 함수는 플레이리스트 리스트를 최신 날짜부터 가지고 온다. date가 빈 문자열이 아니면 해당 날짜의 플레이리스트만 가지고 온다.
+func Playlists(session *mgo.Session, date string) ([]Playlist, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("playlist").C("playlists")
+	q := bson.M{}
+	if date != "" {
+		q["date"] = date
+	}
+	results := []Playlist{}
+	err := c.Find(q).Sort("-date", "-createtime").All(&results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// GetPlaylist 함수는 ID로 플레이리스트를 가지고 온다.
+func GetPlaylist(session *mgo.Session, id string) (Playlist, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("playlist").C("playlists")
+	var p Playlist
+	err := c.Find(bson.M{"id": id}).One(&p)
+	return p, err
+}
+
+// AddPlaylist 함수는 플레이리스트를 추가한다.
+func AddPlaylist(session *mgo.Session, p Playlist) (Playlist, error) {
+	session.SetMode(mgo.Monotonic, true)
+	p.Name = strings.TrimSpace(p.Name)
+	p.Reviewers = cleanReviewers(p.Reviewers)
+	if p.Entries == nil {
+		p.Entries = []PlaylistEntry{}
+	}
+	err := checkPlaylist(p)
+	if err != nil {
+		return p, err
+	}
+	err = fillPlaylistEntries(session, p.Entries)
+	if err != nil {
+		return p, err
+	}
+	p.ID = bson.NewObjectId().Hex()
+	p.Createtime = time.Now().Format(time.RFC3339)
+	p.Updatetime = p.Createtime
+	c := session.DB("playlist").C("playlists")
+	err = c.Insert(p)
+	if err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+// SetPlaylist 함수는 플레이리스트의 이름, 날짜, 항목, 리뷰어를 수정한다.
+func SetPlaylist(session *mgo.Session, p Playlist) (Playlist, error) {
+	session.SetMode(mgo.Monotonic, true)
+	old, err := GetPlaylist(session, p.ID)
+	if err != nil {
+		return p, err
+	}
+	p.Name = strings.TrimSpace(p.Name)
+	p.Reviewers = cleanReviewers(p.Reviewers)
+	if p.Entries == nil {
+		p.Entries = []PlaylistEntry{}
+	}
+	err = checkPlaylist(p)
+	if err != nil {
+		return p, err
+	}
+	err = fillPlaylistEntries(session, p.Entries)
+	if err != nil {
+		return p, err
+	}
+	p.Author = old.Author
+	p.Createtime = old.Createtime
+	p.Updatetime = time.Now().Format(time.RFC3339)
+	c := session.DB("playlist").C("playlists")
+	err = c.Update(bson.M{"id": p.ID}, p)
+	if err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+// fillPlaylistEntries 함수는 이름이 비어있는 플레이리스트 항목에 아이템 이름과 프레임 범위를 채운다.
+// 아이템이 존재하지 않으면 에러를 반환한다.
+func fillPlaylistEntries(session *mgo.Session, entries []PlaylistEntry) error {
+	for n, e := range entries {
+		if e.Name != "" {
+			continue
+		}
+		item, err := getItem(session, e.Project, e.Item)
+		if err != nil {
+			return fmt.Errorf("%d번째 항목 %s %s: %v", n+1, e.Project, e.Item, err)
+		}
+		entries[n].Name = item.Name
+		if e.FrameIn == 0 && e.FrameOut == 0 {
+			entries[n].FrameIn, entries[n].FrameOut = itemFrameRange(item)
+		}
+	}
+	return nil
+}
+
+// RmPlaylist 함수는 플레이리스트를 삭제한다.
+func RmPlaylist(session *mgo.Session, id string) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("playlist").C("playlists")
+	return c.Remove(bson.M{"id": id})
+}
+
+// rmProjectPlaylistEntries 함수는 모든 플레이리스트에서 프로젝트의 항목을 제거한다.
+func rmProjectPlaylistEntries(session *mgo.Session, project string) error {
+	c := session.DB("playlist").C("playlists")
+	_, err := c.UpdateAll(bson.M{"entries.project": project}, bson.M{"$pull": bson.M{"entries": bson.M{"project": project}}})
+	return err
+}
+
+// SearchPlaylistEntries 함수는 검색조건에 맞는 아이템 Task의 mov로 플레이리스트 항목을 만든다.
+// -date 데일리 옵션과 같이 모든 상태의 아이템을 이름순으로 검색한다.
+func SearchPlaylistEntries(session *mgo.Session, s PlaylistSearch) ([]PlaylistEntry, error) {
+	session.SetMode(mgo.Monotonic, true)
+	if s.Date != "" && !MatchNormalTime.MatchString(s.Date) {
+		return nil, errors.New("date 값은 2016-12-06 형태여야 합니다")
+	}
+	if s.Searchword == "" {
+		s.Searchword = s.Date
+	}
+	if s.Searchword == "" {
+		return nil, errors.New("searchword 또는 date 값이 필요합니다")
+	}
+	projects := []string{s.Project}
+	if s.Project == "" {
+		var err error
+		projects, err = Projectlist(session)
+		if err != nil {
+			return nil, err
+		}
+	}
+	tasks, err := TasksettingNames(session)
+	if err != nil {
+		return nil, err
+	}
+	entries := []PlaylistEntry{}
+	for _, project := range projects {
+		err := HasProject(session, project)
+		if err != nil {
+			return nil, err
+		}
+		statuses, err := AllStatuses(session, project)
+		if err != nil {
+			return nil, err
+		}
+		op := SearchOption{
+			Project:    project,
+			Searchword: s.Searchword,
+			Sortkey:    "slug",
+		}
+		// 모든 상태를 검색한다.
+		op.setTrueStatus(splitStatus(StatusIDs(statuses)))
+		items, err := Searchv2(session, op)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, playlistEntries(items, tasks, s.Task, s.Date)...)
+	}
+	return entries, nil
+}
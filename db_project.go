@@ -84,11 +84,20 @@ func addProject(session *mgo.Session, p Project) error {
 	if err != nil {
 		return err
 	}
+	err = ensureStatuses(session, p.ID)
+	if err != nil {
+		return err
+	}
 	//Add(dbip, ip, logstr, project, slug, tool, user string, keep int) error {
 	err = dilog.Add(*flagDBIP, "", p.ID+"프로젝트가 생성되었습니다.", p.ID, "", "csi", "root", 180)
 	if err != nil {
 		log.Println(err)
 	}
+	// 프로젝트는 이미 추가되었으므로 폴더 생성 에러는 로그로만 남긴다.
+	err = autoMkProjectFolders(session, p)
+	if err != nil {
+		log.Printf("%s 프로젝트는 추가되었지만 폴더를 생성하지 못했습니다: %v", p.ID, err)
+	}
 	return nil
 }
 
@@ -197,6 +206,30 @@ func rmProject(session *mgo.Session, project string) error {
 			return err
 		}
 	}
+	// 프로젝트 상태설정, 상태변경 규칙, 변경이력, 스냅샷, 웹훅, 퍼블리시, 썸네일 작업이 존재하면 제거한다.
+	for _, db := range []string{"status", "transition", "history", "snapshot", "webhook", "webhookdelivery", "publish", "thumbnailjob"} {
+		collections, err = session.DB(db).CollectionNames()
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+		for _, c := range collections {
+			if project != c {
+				continue
+			}
+			err = session.DB(db).C(project).DropCollection()
+			if err != nil {
+				log.Println(err)
+				return err
+			}
+		}
+	}
+	// 플레이리스트에서 삭제 프로젝트의 항목을 제거한다.
+	err = rmProjectPlaylistEntries(session, project)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
 	return nil
 }
 
@@ -212,12 +245,27 @@ func setProject(session *mgo.Session, p Project) error {
 	if num != 1 {
 		return errors.New("해당 아이템이 존재하지 않습니다")
 	}
+	var before Project
+	err = c.Find(bson.M{"id": p.ID}).One(&before)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
 	p.Updatetime = time.Now().Format(time.RFC3339)
 	err = c.Update(bson.M{"id": p.ID}, p)
 	if err != nil {
 		log.Println(err)
 		return err
 	}
+	events, err := projectWebhookEvents(before, p, p.Updatetime)
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+	err = enqueueWebhooks(session, p.ID, events)
+	if err != nil {
+		log.Println(err)
+	}
 	return nil
 }
 
@@ -232,5 +280,5 @@ func HasProject(session *mgo.Session, project string) error {
 			return nil
 		}
 	}
-	return errors.New(project + " 프로젝트가 존재하지 않습니다.")
+	return NotFoundError(project + " 프로젝트가 존재하지 않습니다.")
 }
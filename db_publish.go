@@ -0,0 +1,181 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Publishes 함수는 아이템의 퍼블리시 리스트를 최신 버전부터 가지고 온다. task가 빈 문자열이면 모든 Task를 가지고 온다.
+func Publishes(session *mgo.Session, project, id, task string) ([]Publish, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("publish").C(project)
+	q := bson.M{"item": id}
+	if task != "" {
+		q["task"] = strings.ToLower(task)
+	}
+	results := []Publish{}
+	err := c.Find(q).All(&results)
+	if err != nil {
+		return nil, err
+	}
+	sortPublishes(results)
+	return results, nil
+}
+
+// GetPublish 함수는 퍼블리시 ID로 퍼블리시를 가지고 온다.
+func GetPublish(session *mgo.Session, project, id string) (Publish, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("publish").C(project)
+	var p Publish
+	err := c.Find(bson.M{"id": id}).One(&p)
+	return p, err
+}
+
+// LatestPublish 함수는 아이템 Task의 가장 높은 버전의 퍼블리시를 가지고 온다.
+// approved가 true라면 승인된 퍼블리시 중에서 찾는다. 없다면 mgo.ErrNotFound를 반환한다.
+func LatestPublish(session *mgo.Session, project, id, task string, approved bool) (Publish, error) {
+	publishes, err := Publishes(session, project, id, task)
+	if err != nil {
+		return Publish{}, err
+	}
+	p, found := latestPublish(publishes, approved)
+	if !found {
+		return Publish{}, mgo.ErrNotFound
+	}
+	return p, nil
+}
+
+// AddPublish 함수는 퍼블리시를 등록하고 Task의 Version, Pubfile을 최신 퍼블리시로 설정한다.
+// 버전이 없다면 다음 메인 버전을 사용한다.
+func AddPublish(session *mgo.Session, project string, p Publish, editor Editor) (Publish, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return Publish{}, err
+	}
+	p.Task = strings.ToLower(p.Task)
+	err = checkPublish(p)
+	if err != nil {
+		return Publish{}, err
+	}
+	item, err := getItem(session, project, p.Item)
+	if err != nil {
+		return Publish{}, err
+	}
+	if _, found := item.Tasks[p.Task]; !found {
+		return Publish{}, fmt.Errorf("%s 에 %s Task가 존재하지 않습니다", p.Item, p.Task)
+	}
+	for _, u := range p.Uses {
+		_, err := GetPublish(session, project, u)
+		if err != nil {
+			return Publish{}, fmt.Errorf("%s 퍼블리시를 찾을 수 없습니다: %v", u, err)
+		}
+	}
+	publishes, err := Publishes(session, project, p.Item, p.Task)
+	if err != nil {
+		return Publish{}, err
+	}
+	if p.Version == (Version{}) {
+		p.Version = nextPublishVersion(publishes)
+	} else if hasPublishVersion(publishes, p.Version) {
+		return Publish{}, fmt.Errorf("%s %s Task에 %s 버전이 이미 존재합니다", p.Item, p.Task, versionString(p.Version))
+	}
+	p.ID = bson.NewObjectId().Hex()
+	p.Project = project
+	p.Approved = false
+	p.Approver = ""
+	p.Approvetime = ""
+	p.Createtime = time.Now().Format(time.RFC3339)
+	c := session.DB("publish").C(project)
+	err = c.Insert(p)
+	if err != nil {
+		return Publish{}, err
+	}
+	err = syncTaskPublish(session, project, p.Item, p.Task, editor)
+	if err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+// ApprovePublish 함수는 퍼블리시의 승인 상태를 설정한다.
+func ApprovePublish(session *mgo.Session, project, id string, approved bool, approver string) (Publish, error) {
+	session.SetMode(mgo.Monotonic, true)
+	p, err := GetPublish(session, project, id)
+	if err != nil {
+		return Publish{}, err
+	}
+	p.Approved = approved
+	p.Approver = ""
+	p.Approvetime = ""
+	if approved {
+		p.Approver = approver
+		p.Approvetime = time.Now().Format(time.RFC3339)
+	}
+	c := session.DB("publish").C(project)
+	err = c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"approved": p.Approved, "approver": p.Approver, "approvetime": p.Approvetime}})
+	if err != nil {
+		return Publish{}, err
+	}
+	return p, nil
+}
+
+// RmPublish 함수는 퍼블리시를 삭제하고 Task의 Version, Pubfile을 다시 설정한다.
+// 다른 퍼블리시가 사용하고 있는 퍼블리시는 삭제할 수 없다.
+func RmPublish(session *mgo.Session, project, id string, editor Editor) error {
+	session.SetMode(mgo.Monotonic, true)
+	p, err := GetPublish(session, project, id)
+	if err != nil {
+		return err
+	}
+	c := session.DB("publish").C(project)
+	num, err := c.Find(bson.M{"uses": id}).Count()
+	if err != nil {
+		return err
+	}
+	if num != 0 {
+		return errors.New("다른 퍼블리시에서 사용하고 있는 퍼블리시는 삭제할 수 없습니다")
+	}
+	err = c.Remove(bson.M{"id": id})
+	if err != nil {
+		return err
+	}
+	return syncTaskPublish(session, project, p.Item, p.Task, editor)
+}
+
+// syncTaskPublish 함수는 Task의 Version, Pubfile을 가장 높은 버전의 퍼블리시로 설정한다.
+// 퍼블리시가 없다면 초기화하고, 아이템에 Task가 없다면 아무것도 하지 않는다.
+func syncTaskPublish(session *mgo.Session, project, id, task string, editor Editor) error {
+	item, err := getItem(session, project, id)
+	if err != nil {
+		return err
+	}
+	t, found := item.Tasks[task]
+	if !found {
+		return nil
+	}
+	var version Version
+	pubfile := ""
+	latest, err := LatestPublish(session, project, id, task, false)
+	if err != nil && err != mgo.ErrNotFound {
+		return err
+	}
+	if err == nil {
+		version = latest.Version
+		pubfile = latest.Files[0]
+	}
+	if t.Version == version && t.Pubfile == pubfile {
+		return nil
+	}
+	update := bson.M{"$set": bson.M{
+		"tasks." + task + ".version": version,
+		"tasks." + task + ".pubfile": pubfile,
+		"updatetime":                 time.Now().Format(time.RFC3339),
+	}}
+	return updateItem(session, project, id, update, editor)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/mgo.v2"
+)
+
+// SubmitReview 함수는 리뷰 노트를 아이템 코멘트로 등록하고 Task 상태를 한번에 변경한다.
+// 노트는 mov 경로를 Media로 사용하며, 상태는 SetTaskStatus로 변경하므로 상태변경 규칙이 적용된다.
+// 항목 하나가 실패하더라도 나머지 항목은 처리하고 항목별 결과를 반환한다.
+func SubmitReview(session *mgo.Session, notes []ReviewNote, userID string, level AccessLevel, editor Editor) []ReviewResult {
+	session.SetMode(mgo.Monotonic, true)
+	results := []ReviewResult{}
+	dates := reviewCommentDates(notes, time.Now())
+	for n, note := range notes {
+		result := ReviewResult{ReviewNote: note}
+		err := submitReviewNote(session, &result, dates[n], userID, level, editor)
+		if err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// submitReviewNote 함수는 리뷰 노트 하나를 처리하고 결과를 result에 기록한다.
+func submitReviewNote(session *mgo.Session, result *ReviewResult, date, userID string, level AccessLevel, editor Editor) error {
+	item, err := getItem(session, result.Project, result.Item)
+	if err != nil {
+		return fmt.Errorf("%s 아이템을 찾을 수 없습니다: %v", result.Item, err)
+	}
+	result.Name = item.Name
+	t, found := item.Tasks[result.Task]
+	if !found {
+		return fmt.Errorf("%s 에 %s Task가 존재하지 않습니다", item.Name, result.Task)
+	}
+	result.StatusBefore = t.Status
+	if result.Text != "" {
+		_, err = AddComment(session, result.Project, item.Name, userID, date, reviewCommentText(result.ReviewNote), result.Mov, editor)
+		if err != nil {
+			return err
+		}
+		result.Comment = date
+	}
+	if result.Status == "" || result.Status == t.Status {
+		return nil
+	}
+	return SetTaskStatus(session, result.Project, item.Name, result.Task, result.Status, level, editor)
+}
@@ -18,7 +18,8 @@ func Searchv2(session *mgo.Session, op SearchOption) ([]Item, error) {
 		return results, nil
 	}
 	// 체크박스가 아무것도 켜있지 않다면 바로 빈 값을 리턴한다.
-	if !op.Assign && !op.Ready && !op.Wip && !op.Confirm && !op.Done && !op.Omit && !op.Hold && !op.Out && !op.None {
+	trueStatus := op.checkedStatus()
+	if len(trueStatus) == 0 {
 		return results, nil
 	}
 	// 검색어중 연산에 필요한 검색어는 제거한다.
@@ -53,6 +54,10 @@ func Searchv2(session *mgo.Session, op SearchOption) ([]Item, error) {
 	}
 
 	session.SetMode(mgo.Monotonic, true)
+	statuses, err := AllStatuses(session, op.Project)
+	if err != nil {
+		return results, err
+	}
 	c := session.DB("project").C(op.Project)
 
 	// Task 처리
@@ -88,14 +93,17 @@ func Searchv2(session *mgo.Session, op SearchOption) ([]Item, error) {
 			// 데일리 날짜를 검색한다.
 			// 2016-11-21 형태는 데일리로 간주합니다.
 			// jquery 달력의 기본형식이기도 합니다.
+			// 마지막 mov 뿐만 아니라 mov 등록이력의 날짜도 검색한다.
 			regFullTime := fmt.Sprintf(`^%sT\d{2}:\d{2}:\d{2}[-+]\d{2}:\d{2}$`, word)
 			if len(selectTasks) == 0 {
 				for _, task := range allTasks {
 					query = append(query, bson.M{"tasks." + strings.ToLower(task) + ".mdate": &bson.RegEx{Pattern: regFullTime}})
+					query = append(query, bson.M{"tasks." + strings.ToLower(task) + ".movs.date": &bson.RegEx{Pattern: regFullTime}})
 				}
 			} else {
 				for _, task := range selectTasks {
 					query = append(query, bson.M{"tasks." + strings.ToLower(task) + ".mdate": &bson.RegEx{Pattern: regFullTime}})
+					query = append(query, bson.M{"tasks." + strings.ToLower(task) + ".movs.date": &bson.RegEx{Pattern: regFullTime}})
 				}
 			}
 		} else if regexpTimecode.MatchString(word) {
@@ -107,6 +115,9 @@ func Searchv2(session *mgo.Session, op SearchOption) ([]Item, error) {
 			query = append(query, bson.M{"tag": strings.TrimPrefix(word, "tag:")})
 		} else if strings.HasPrefix(word, "assettags:") {
 			query = append(query, bson.M{"assettags": strings.TrimPrefix(word, "assettags:")})
+		} else if strings.HasPrefix(word, "uses:") { // 에셋, 샷을 사용하는 아이템
+			query = append(query, bson.M{"uses.name": strings.TrimPrefix(word, "uses:")})
+			query = append(query, bson.M{"uses.id": strings.TrimPrefix(word, "uses:")})
 		} else if strings.HasPrefix(word, "deadline2d:") {
 			query = append(query, bson.M{"ddline2d": &bson.RegEx{Pattern: strings.TrimPrefix(word, "deadline2d:"), Options: "i"}})
 		} else if strings.HasPrefix(word, "deadline3d:") {
@@ -118,51 +129,16 @@ func Searchv2(session *mgo.Session, op SearchOption) ([]Item, error) {
 		} else if strings.HasPrefix(word, "type:asset") {
 			query = append(query, bson.M{"type": "asset"})
 		} else if strings.HasPrefix(word, "status:") {
-			status := strings.ToLower(strings.TrimPrefix(word, "status:"))
+			status, err := findStatus(statuses, strings.TrimPrefix(word, "status:"))
+			if err != nil {
+				return results, err
+			}
 			if len(selectTasks) != 0 {
 				for _, task := range selectTasks {
-					switch status {
-					case "assign":
-						query = append(query, bson.M{"tasks." + task + ".status": ASSIGN})
-					case "ready":
-						query = append(query, bson.M{"tasks." + task + ".status": READY})
-					case "wip":
-						query = append(query, bson.M{"tasks." + task + ".status": WIP})
-					case "confirm":
-						query = append(query, bson.M{"tasks." + task + ".status": CONFIRM})
-					case "done":
-						query = append(query, bson.M{"tasks." + task + ".status": DONE})
-					case "omit":
-						query = append(query, bson.M{"tasks." + task + ".status": OMIT})
-					case "hold":
-						query = append(query, bson.M{"tasks." + task + ".status": HOLD})
-					case "out":
-						query = append(query, bson.M{"tasks." + task + ".status": OUT})
-					case "none":
-						query = append(query, bson.M{"tasks." + task + ".status": NONE})
-					}
+					query = append(query, bson.M{"tasks." + task + ".status": status.ID})
 				}
 			} else {
-				switch status {
-				case "assign":
-					query = append(query, bson.M{"status": ASSIGN})
-				case "ready":
-					query = append(query, bson.M{"status": READY})
-				case "wip":
-					query = append(query, bson.M{"status": WIP})
-				case "confirm":
-					query = append(query, bson.M{"status": CONFIRM})
-				case "done":
-					query = append(query, bson.M{"status": DONE})
-				case "omit":
-					query = append(query, bson.M{"status": OMIT})
-				case "hold":
-					query = append(query, bson.M{"status": HOLD})
-				case "out":
-					query = append(query, bson.M{"status": OUT})
-				case "none":
-					query = append(query, bson.M{"status": NONE})
-				}
+				query = append(query, bson.M{"status": status.ID})
 			}
 		} else if strings.HasPrefix(word, "user:") {
 			if len(selectTasks) == 0 {
@@ -189,8 +165,11 @@ func Searchv2(session *mgo.Session, op SearchOption) ([]Item, error) {
 		} else if regexTaskStatusQuery.MatchString(word) {
 			// 위 패턴이면 : 문자로 스플릿하고 상태를 숫자로 바꾼다.
 			queryString := strings.Split(word, ":")[0]
-			status := StatusString2string(strings.Split(word, ":")[1])
-			query = append(query, bson.M{queryString: status})
+			status, err := findStatus(statuses, strings.Split(word, ":")[1])
+			if err != nil {
+				return results, err
+			}
+			query = append(query, bson.M{queryString: status.ID})
 		} else {
 			switch word {
 			case "all", "All", "ALL", "올", "미ㅣ", "dhf", "전체":
@@ -238,62 +217,10 @@ func Searchv2(session *mgo.Session, op SearchOption) ([]Item, error) {
 
 	statusQueries := []bson.M{}
 	if len(selectTasks) == 0 {
-		if op.Assign {
-			statusQueries = append(statusQueries, bson.M{"status": ASSIGN})
-		}
-		if op.Ready {
-			statusQueries = append(statusQueries, bson.M{"status": READY})
-		}
-		if op.Wip {
-			statusQueries = append(statusQueries, bson.M{"status": WIP})
-		}
-		if op.Confirm {
-			statusQueries = append(statusQueries, bson.M{"status": CONFIRM})
-		}
-		if op.Done {
-			statusQueries = append(statusQueries, bson.M{"status": DONE})
-		}
-		if op.Omit {
-			statusQueries = append(statusQueries, bson.M{"status": OMIT})
-		}
-		if op.Hold {
-			statusQueries = append(statusQueries, bson.M{"status": HOLD})
-		}
-		if op.Out {
-			statusQueries = append(statusQueries, bson.M{"status": OUT})
-		}
-		if op.None {
-			statusQueries = append(statusQueries, bson.M{"status": NONE})
-		}
+		statusQueries = append(statusQueries, bson.M{"status": bson.M{"$in": trueStatus}})
 	} else {
 		for _, task := range selectTasks {
-			if op.Assign {
-				statusQueries = append(statusQueries, bson.M{"tasks." + task + ".status": ASSIGN})
-			}
-			if op.Ready {
-				statusQueries = append(statusQueries, bson.M{"tasks." + task + ".status": READY})
-			}
-			if op.Wip {
-				statusQueries = append(statusQueries, bson.M{"tasks." + task + ".status": WIP})
-			}
-			if op.Confirm {
-				statusQueries = append(statusQueries, bson.M{"tasks." + task + ".status": CONFIRM})
-			}
-			if op.Done {
-				statusQueries = append(statusQueries, bson.M{"tasks." + task + ".status": DONE})
-			}
-			if op.Omit {
-				statusQueries = append(statusQueries, bson.M{"tasks." + task + ".status": OMIT})
-			}
-			if op.Hold {
-				statusQueries = append(statusQueries, bson.M{"tasks." + task + ".status": HOLD})
-			}
-			if op.Out {
-				statusQueries = append(statusQueries, bson.M{"tasks." + task + ".status": OUT})
-			}
-			if op.None {
-				statusQueries = append(statusQueries, bson.M{"tasks." + task + ".status": NONE})
-			}
+			statusQueries = append(statusQueries, bson.M{"tasks." + task + ".status": bson.M{"$in": trueStatus}})
 		}
 	}
 	// 각 단어에 대한 쿼리를 and 로 검색할지 or 로 검색할지 결정한다.
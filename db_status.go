@@ -0,0 +1,203 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// AllStatuses 함수는 프로젝트에 설정된 상태리스트를 Order 역순으로 가지고 온다.
+// 상태가 설정되지 않은 프로젝트라면 DB에 저장하지 않고 기본 상태리스트를 반환한다.
+func AllStatuses(session *mgo.Session, project string) ([]Status, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("status").C(project)
+	var results []Status
+	err := c.Find(bson.M{}).Sort("-order").All(&results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return DefaultStatuses(), nil
+	}
+	return results, nil
+}
+
+// ensureStatuses 함수는 프로젝트 상태에 id 고유 인덱스를 만들고, 상태가 없다면 기본 상태리스트를 저장한다.
+// 기본 상태는 id로 Upsert 하므로 여러 서버에서 동시에 실행되어도 중복으로 저장되지 않는다.
+func ensureStatuses(session *mgo.Session, project string) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("status").C(project)
+	// 예전 버전에서 중복으로 저장된 상태가 있다면 고유 인덱스를 만들 수 없으므로 먼저 정리한다.
+	var docs []bson.M
+	err := c.Find(bson.M{}).Select(bson.M{"_id": 1, "id": 1}).All(&docs)
+	if err != nil {
+		return err
+	}
+	found := make(map[interface{}]bool)
+	for _, doc := range docs {
+		if found[doc["id"]] {
+			err = c.RemoveId(doc["_id"])
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		found[doc["id"]] = true
+	}
+	err = c.EnsureIndex(mgo.Index{Key: []string{"id"}, Unique: true})
+	if err != nil {
+		return err
+	}
+	if len(docs) != 0 {
+		return nil
+	}
+	for _, s := range DefaultStatuses() {
+		_, err = c.Upsert(bson.M{"id": s.ID}, bson.M{"$setOnInsert": s})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateStatuses 함수는 서버 시작시 모든 프로젝트의 상태를 ensureStatuses로 준비한다.
+func migrateStatuses(session *mgo.Session) error {
+	plist, err := Projectlist(session)
+	if err != nil {
+		return err
+	}
+	for _, p := range plist {
+		err = ensureStatuses(session, p)
+		if err != nil {
+			return fmt.Errorf("%s 프로젝트 상태: %v", p, err)
+		}
+	}
+	return nil
+}
+
+// GetStatus 함수는 프로젝트에서 ID 또는 이름이 일치하는 상태를 가지고 온다.
+func GetStatus(session *mgo.Session, project, key string) (Status, error) {
+	statuses, err := AllStatuses(session, project)
+	if err != nil {
+		return Status{}, err
+	}
+	return findStatus(statuses, key)
+}
+
+// AddStatus 함수는 프로젝트에 상태를 추가한다.
+func AddStatus(session *mgo.Session, project string, s Status) error {
+	if s.ID == "" || s.Name == "" {
+		return errors.New("상태 ID와 이름이 필요합니다")
+	}
+	s.Name = strings.ToLower(s.Name)
+	err := ensureStatuses(session, project)
+	if err != nil {
+		return err
+	}
+	statuses, err := AllStatuses(session, project)
+	if err != nil {
+		return err
+	}
+	for _, i := range statuses {
+		if i.ID == s.ID || i.Name == s.Name {
+			return fmt.Errorf("%s 프로젝트에 이미 %s 상태가 존재합니다", project, s.Name)
+		}
+	}
+	c := session.DB("status").C(project)
+	return c.Insert(s)
+}
+
+// SetStatus 함수는 프로젝트의 상태정보를 수정한다. ID는 변경할 수 없다.
+func SetStatus(session *mgo.Session, project string, s Status) error {
+	session.SetMode(mgo.Monotonic, true)
+	s.Name = strings.ToLower(s.Name)
+	err := ensureStatuses(session, project)
+	if err != nil {
+		return err
+	}
+	c := session.DB("status").C(project)
+	num, err := c.Find(bson.M{"name": s.Name, "id": bson.M{"$ne": s.ID}}).Count()
+	if err != nil {
+		return err
+	}
+	if num != 0 {
+		return fmt.Errorf("%s 프로젝트에 이미 %s 상태가 존재합니다", project, s.Name)
+	}
+	return c.Update(bson.M{"id": s.ID}, s)
+}
+
+// RmStatus 함수는 프로젝트에서 상태를 삭제한다. 사용중인 상태는 삭제할 수 없다.
+func RmStatus(session *mgo.Session, project, id string) error {
+	session.SetMode(mgo.Monotonic, true)
+	err := ensureStatuses(session, project)
+	if err != nil {
+		return err
+	}
+	tasks, err := TasksettingNames(session)
+	if err != nil {
+		return err
+	}
+	query := []bson.M{bson.M{"status": id}}
+	for _, task := range tasks {
+		query = append(query, bson.M{"tasks." + strings.ToLower(task) + ".status": id})
+	}
+	num, err := session.DB("project").C(project).Find(bson.M{"$or": query}).Count()
+	if err != nil {
+		return err
+	}
+	if num != 0 {
+		return fmt.Errorf("%d개의 아이템이 사용중인 상태는 삭제할 수 없습니다", num)
+	}
+	c := session.DB("status").C(project)
+	return c.Remove(bson.M{"id": id})
+}
+
+// AllTransitions 함수는 프로젝트에 설정된 상태변경 규칙을 가지고 온다.
+func AllTransitions(session *mgo.Session, project string) ([]Transition, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("transition").C(project)
+	var results []Transition
+	err := c.Find(bson.M{}).Sort("from", "to").All(&results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// AddTransition 함수는 프로젝트에 상태변경 규칙을 추가한다.
+// 같은 from, to 규칙이 존재한다면 AccessLevel을 덮어쓴다.
+func AddTransition(session *mgo.Session, project string, t Transition) error {
+	session.SetMode(mgo.Monotonic, true)
+	statuses, err := AllStatuses(session, project)
+	if err != nil {
+		return err
+	}
+	// 상태이름으로 입력되어도 상태ID로 저장한다.
+	if t.From != "*" {
+		s, err := findStatus(statuses, t.From)
+		if err != nil {
+			return err
+		}
+		t.From = s.ID
+	}
+	if t.To != "*" {
+		s, err := findStatus(statuses, t.To)
+		if err != nil {
+			return err
+		}
+		t.To = s.ID
+	}
+	c := session.DB("transition").C(project)
+	_, err = c.Upsert(bson.M{"from": t.From, "to": t.To}, t)
+	return err
+}
+
+// RmTransition 함수는 프로젝트에서 상태변경 규칙을 삭제한다.
+func RmTransition(session *mgo.Session, project, from, to string) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("transition").C(project)
+	return c.Remove(bson.M{"from": from, "to": to})
+}
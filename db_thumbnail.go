@@ -0,0 +1,159 @@
+package main
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// thumbnailNotify 는 새 썸네일 작업이 대기열에 추가되었음을 thumbnailWorker에 알린다.
+var thumbnailNotify = make(chan struct{}, 1)
+
+// EnqueueThumbnail 함수는 아이템의 썸네일 생성작업을 대기열에 추가한다.
+// 같은 아이템의 작업이 이미 대기중이라면 추가하지 않는다.
+func EnqueueThumbnail(session *mgo.Session, project, id string) (ThumbnailJob, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("thumbnailjob").C(project)
+	var j ThumbnailJob
+	err := c.Find(bson.M{"itemid": id, "status": ThumbnailPending}).One(&j)
+	if err == nil {
+		return j, nil
+	}
+	if err != mgo.ErrNotFound {
+		return j, err
+	}
+	now := time.Now().Format(time.RFC3339)
+	j = ThumbnailJob{
+		ID:         bson.NewObjectId().Hex(),
+		Project:    project,
+		ItemID:     id,
+		Status:     ThumbnailPending,
+		Createtime: now,
+		Updatetime: now,
+	}
+	err = c.Insert(j)
+	if err != nil {
+		return j, err
+	}
+	select {
+	case thumbnailNotify <- struct{}{}:
+	default:
+	}
+	return j, nil
+}
+
+// autoThumbnail 함수는 관리자 설정에서 AutoThumbnail이 켜져 있을 때만 썸네일 생성작업을 추가한다.
+// 썸네일 에러로 아이템 수정이 실패하지 않도록 에러는 로그로만 남긴다.
+func autoThumbnail(session *mgo.Session, project, id string) {
+	s, err := GetAdminSetting(session)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	if !s.AutoThumbnail {
+		return
+	}
+	_, err = EnqueueThumbnail(session, project, id)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// EnqueueMissingThumbnails 함수는 썸네일 파일이 없는 프로젝트 아이템의 썸네일 생성작업을 추가하고 추가한 작업 갯수를 반환한다.
+func EnqueueMissingThumbnails(session *mgo.Session, project string) (int, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return 0, err
+	}
+	var items []Item
+	err = session.DB("project").C(project).Find(bson.M{}).Select(bson.M{"id": 1}).All(&items)
+	if err != nil {
+		return 0, err
+	}
+	num := 0
+	for _, i := range items {
+		_, err = os.Stat(thumbnailFilePath(project, i.ID))
+		if err == nil {
+			continue
+		}
+		_, err = EnqueueThumbnail(session, project, i.ID)
+		if err != nil {
+			return num, err
+		}
+		num++
+	}
+	return num, nil
+}
+
+// ThumbnailJobs 함수는 프로젝트의 썸네일 작업을 최신순으로 limit 갯수만큼 가지고 온다.
+func ThumbnailJobs(session *mgo.Session, project string, limit int) ([]ThumbnailJob, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("thumbnailjob").C(project)
+	results := []ThumbnailJob{}
+	err := c.Find(bson.M{}).Sort("-createtime").Limit(limit).All(&results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// processThumbnailQueue 함수는 모든 프로젝트의 대기중인 썸네일 작업을 처리한다.
+// 디코더 명령어는 처리시점의 관리자 설정을 사용한다.
+func processThumbnailQueue(session *mgo.Session) error {
+	session.SetMode(mgo.Monotonic, true)
+	s, err := GetAdminSetting(session)
+	if err != nil {
+		return err
+	}
+	projects, err := session.DB("thumbnailjob").CollectionNames()
+	if err != nil {
+		return err
+	}
+	for _, project := range projects {
+		c := session.DB("thumbnailjob").C(project)
+		var queue []ThumbnailJob
+		err = c.Find(bson.M{"status": ThumbnailPending}).Sort("createtime").Limit(100).All(&queue)
+		if err != nil {
+			return err
+		}
+		for _, j := range queue {
+			var source string
+			item, err := getItem(session, project, j.ItemID)
+			if err == nil {
+				source, err = makeItemThumbnail(item, s.ThumbnailDecoder, thumbnailFilePath(project, j.ItemID))
+			}
+			err = c.Update(bson.M{"id": j.ID}, finishThumbnailJob(j, source, err, time.Now()))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// thumbnailWorker 함수는 썸네일 작업대기열을 처리한다.
+// 새 작업이 추가되면 바로, 그렇지 않으면 10초마다 다른 프로세스(CLI 등)에서 추가한 작업을 확인한다.
+func thumbnailWorker() {
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-thumbnailNotify:
+		}
+		session, err := mgo.Dial(*flagDBIP)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		err = processThumbnailQueue(session)
+		if err != nil {
+			log.Println(err)
+		}
+		session.Close()
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// setTOTPPendingSecret 함수는 사용자의 등록중인 TOTP 비밀키를 설정한다.
+func setTOTPPendingSecret(session *mgo.Session, id, secret string) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("user").C("users")
+	return c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{
+		"totppendingsecret": secret,
+		"updatetime":        time.Now().Format(time.RFC3339),
+	}})
+}
+
+// enableTOTP 함수는 등록중인 TOTP 비밀키를 사용자의 TOTP 비밀키로 설정하고 복구코드 해시값을 저장한다.
+// counter는 등록할 때 확인한 코드의 시간단위이다.
+func enableTOTP(session *mgo.Session, id, secret string, counter int64, recoveryHashes []string) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("user").C("users")
+	return c.Update(bson.M{"id": id, "totppendingsecret": secret}, bson.M{"$set": bson.M{
+		"totpenabled":       true,
+		"totpsecret":        secret,
+		"totppendingsecret": "",
+		"totplastcounter":   counter,
+		"totprecoverycodes": recoveryHashes,
+		"updatetime":        time.Now().Format(time.RFC3339),
+	}})
+}
+
+// useTOTPCounter 함수는 사용한 TOTP 시간단위를 저장한다.
+// 동시에 같은 코드로 로그인하는 요청을 막기 위해 저장된 시간단위보다 클 때만 저장하고, 아니라면 에러를 반환한다.
+func useTOTPCounter(session *mgo.Session, id string, counter int64) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("user").C("users")
+	err := c.Update(bson.M{"id": id, "totplastcounter": bson.M{"$lt": counter}}, bson.M{"$set": bson.M{"totplastcounter": counter}})
+	if err == mgo.ErrNotFound {
+		return errors.New("이미 사용한 TOTP 코드입니다")
+	}
+	return err
+}
+
+// setTOTPRecoveryCodes 함수는 사용자의 복구코드 해시값을 설정한다.
+// 복구코드를 사용할 때는 before 값이 DB와 같을 때만 저장하여 같은 복구코드를 두번 사용할 수 없게 한다.
+func setTOTPRecoveryCodes(session *mgo.Session, id string, before, after []string) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("user").C("users")
+	q := bson.M{"id": id}
+	if before != nil {
+		q["totprecoverycodes"] = before
+	}
+	err := c.Update(q, bson.M{"$set": bson.M{
+		"totprecoverycodes": after,
+		"updatetime":        time.Now().Format(time.RFC3339),
+	}})
+	if err == mgo.ErrNotFound {
+		return errors.New("복구코드가 변경되었습니다. 다시 시도해주세요")
+	}
+	return err
+}
+
+// resetTOTP 함수는 사용자의 TOTP 등록정보와 복구코드를 모두 삭제한다.
+func resetTOTP(session *mgo.Session, id string) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("user").C("users")
+	num, err := c.Find(bson.M{"id": id}).Count()
+	if err != nil {
+		return err
+	}
+	if num != 1 {
+		return errors.New("해당 유저가 존재하지 않습니다")
+	}
+	return c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{
+		"totpenabled":       false,
+		"totpsecret":        "",
+		"totppendingsecret": "",
+		"totplastcounter":   0,
+		"totprecoverycodes": []string{},
+		"updatetime":        time.Now().Format(time.RFC3339),
+	}})
+}
@@ -105,6 +105,21 @@ func getUser(session *mgo.Session, id string) (User, error) {
 	return u, nil
 }
 
+// getUserByOIDC 함수는 OIDC 계정의 iss, sub 클레임과 연결된 사용자를 가지고 오는 함수이다.
+func getUserByOIDC(session *mgo.Session, issuer, subject string) (User, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("user").C("users")
+	u := User{}
+	if issuer == "" || subject == "" {
+		return u, mgo.ErrNotFound
+	}
+	err := c.Find(bson.M{"oidcissuer": issuer, "oidcsubject": subject}).One(&u)
+	if err != nil {
+		return u, err
+	}
+	return u, nil
+}
+
 // getToken 함수는 사용자의 토큰을 가지고오는 함수이다.
 func getToken(session *mgo.Session, id string) (Token, error) {
 	session.SetMode(mgo.Monotonic, true)
@@ -158,6 +173,48 @@ func setUser(session *mgo.Session, u User) error {
 	return nil
 }
 
+// migrateUserAuthSource 함수는 예전 ldap 필드로 저장된 디렉토리 사용자를 authsource 필드로 옮긴다.
+func migrateUserAuthSource(session *mgo.Session) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("user").C("users")
+	_, err := c.UpdateAll(bson.M{"ldap": true, "authsource": bson.M{"$in": []interface{}{"", nil}}}, bson.M{"$set": bson.M{"authsource": "ldap"}})
+	if err != nil {
+		return err
+	}
+	_, err = c.UpdateAll(bson.M{"ldap": bson.M{"$exists": true}}, bson.M{"$unset": bson.M{"ldap": ""}})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// setUserAuthSource 함수는 관리자가 사용자를 외부 인증서비스(ldap, oidc)에 연결하거나 local로 연결을 해제하는 함수이다.
+// OIDC 계정은 연결 후 처음 SSO 로그인한 계정의 iss, sub 클레임으로 연결된다.
+func setUserAuthSource(session *mgo.Session, id, source string) error {
+	switch source {
+	case "local":
+		source = ""
+	case "ldap", "oidc":
+	default:
+		return errors.New("인증서비스는 ldap, oidc, local 중 하나를 입력해주세요")
+	}
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("user").C("users")
+	num, err := c.Find(bson.M{"id": id}).Count()
+	if err != nil {
+		return err
+	}
+	if num != 1 {
+		return errors.New("해당 유저가 존재하지 않습니다")
+	}
+	return c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{
+		"authsource":  source,
+		"oidcissuer":  "",
+		"oidcsubject": "",
+		"updatetime":  time.Now().Format(time.RFC3339),
+	}})
+}
+
 // initPassUser 함수는 사용자 정보를 수정하는 함수이다.
 func initPassUser(session *mgo.Session, id string) error {
 	session.SetMode(mgo.Monotonic, true)
@@ -204,6 +261,13 @@ func updatePasswordUser(session *mgo.Session, id, pw, newPw string) error {
 		return errors.New("해당 유저가 존재하지 않습니다")
 	}
 	q := bson.M{"id": id}
+	u, err := getUser(session, id)
+	if err != nil {
+		return err
+	}
+	if u.AuthSource != "" {
+		return errors.New(u.AuthSource + " 계정은 CSI에서 패스워드를 변경할 수 없습니다. 인증서비스에서 변경해주세요")
+	}
 	// 과거의 패스워드로 로그인가능했는지 체크한다.
 	err = vaildUser(session, id, pw)
 	if err != nil {
@@ -314,8 +378,23 @@ func searchUsers(session *mgo.Session, words []string) ([]User, error) {
 }
 
 // vaildUser 함수는 사용자의 id, pw를 받아서 유효한 사용자인지 체크한다.
+// Admin Setting에서 LDAP 로그인을 사용한다면 디렉토리에서 먼저 인증하고, 디렉토리에 없는 사용자는 CSI 패스워드로 인증한다.
 func vaildUser(session *mgo.Session, id, pw string) error {
 	session.SetMode(mgo.Monotonic, true)
+	s, err := GetAdminSetting(session)
+	if err != nil {
+		return err
+	}
+	if s.LDAP {
+		lu, err := ldapLogin(s, id, pw)
+		if err == nil {
+			_, err = setExternalUser(session, lu, s.LDAPGroupAccessLevel, s.LDAPGroupOrganization)
+			return err
+		}
+		if err != errLDAPUserNotFound {
+			return err
+		}
+	}
 	c := session.DB("user").C("users")
 	q := bson.M{"id": id}
 	num, err := c.Find(q).Count()
@@ -330,6 +409,10 @@ func vaildUser(session *mgo.Session, id, pw string) error {
 	if err != nil {
 		return err
 	}
+	err = u.checkLocalSignin()
+	if err != nil {
+		return err
+	}
 	err = bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(pw))
 	if err != nil {
 		return err
@@ -440,3 +523,47 @@ func ReplaceTags(session *mgo.Session, old, new string) error {
 	// 각 유저를 체크하면서 태그이름을 변경한다.
 	return nil
 }
+
+// SetAccessProjects 함수는 사용자가 접근할 수 있는 프로젝트 리스트를 설정한다. 빈 리스트는 모든 프로젝트에 접근할 수 있다.
+func SetAccessProjects(session *mgo.Session, id string, projects []string) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("user").C("users")
+	if projects == nil {
+		projects = []string{}
+	}
+	err := c.Update(bson.M{"id": id}, bson.M{"$set": bson.M{"accessprojects": projects, "updatetime": time.Now().Format(time.RFC3339)}})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetTeamAccessProjects 함수는 팀에 속한 모든 사용자에게 프로젝트 접근권한을 부여(grant)하거나 회수한다.
+// 처리한 사용자 ID와 모든 프로젝트가 회수되어 처리하지 않은 사용자 ID를 반환한다.
+func SetTeamAccessProjects(session *mgo.Session, team string, projects []string, grant bool) ([]string, []string, error) {
+	users, err := allUsers(session)
+	if err != nil {
+		return nil, nil, err
+	}
+	var updated []string
+	var skipped []string
+	for _, u := range users {
+		if !u.InTeam(team) {
+			continue
+		}
+		list := grantProjects(u.AccessProjects, projects)
+		if !grant {
+			list, err = revokeProjects(u.AccessProjects, projects)
+			if err != nil {
+				skipped = append(skipped, u.ID)
+				continue
+			}
+		}
+		err = SetAccessProjects(session, u.ID, list)
+		if err != nil {
+			return updated, skipped, err
+		}
+		updated = append(updated, u.ID)
+	}
+	return updated, skipped, nil
+}
@@ -0,0 +1,257 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// webhookNotify 는 새 전송이 대기열에 추가되었음을 webhookWorker에 알린다.
+var webhookNotify = make(chan struct{}, 1)
+
+// Webhooks 함수는 프로젝트에 등록된 웹훅 리스트를 가지고 온다.
+func Webhooks(session *mgo.Session, project string) ([]Webhook, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("webhook").C(project)
+	results := []Webhook{}
+	err := c.Find(bson.M{}).Sort("createtime").All(&results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// AddWebhook 함수는 프로젝트에 웹훅을 추가한다.
+// 상태 필터는 상태이름으로 입력해도 상태ID로 바꾸어 저장한다.
+func AddWebhook(session *mgo.Session, project string, w Webhook) (Webhook, error) {
+	session.SetMode(mgo.Monotonic, true)
+	err := HasProject(session, project)
+	if err != nil {
+		return w, err
+	}
+	err = checkWebhook(w)
+	if err != nil {
+		return w, err
+	}
+	statuses, err := AllStatuses(session, project)
+	if err != nil {
+		return w, err
+	}
+	for n, key := range w.Statuses {
+		s, err := findStatus(statuses, key)
+		if err != nil {
+			return w, fmt.Errorf("%s: %v", key, err)
+		}
+		w.Statuses[n] = s.ID
+	}
+	w.ID = bson.NewObjectId().Hex()
+	w.Createtime = time.Now().Format(time.RFC3339)
+	c := session.DB("webhook").C(project)
+	err = c.Insert(w)
+	if err != nil {
+		return w, err
+	}
+	return w, nil
+}
+
+// RmWebhook 함수는 프로젝트에서 웹훅을 삭제한다. 전송대기중인 전송은 전송시점에 실패로 처리된다.
+func RmWebhook(session *mgo.Session, project, id string) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("webhook").C(project)
+	return c.Remove(bson.M{"id": id})
+}
+
+// enqueueWebhooks 함수는 이벤트를 조건에 맞는 웹훅의 전송대기열에 추가한다.
+func enqueueWebhooks(session *mgo.Session, project string, events []WebhookEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	hooks, err := Webhooks(session, project)
+	if err != nil {
+		return err
+	}
+	if len(hooks) == 0 {
+		return nil
+	}
+	now := time.Now().Format(time.RFC3339)
+	var docs []interface{}
+	for _, e := range events {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		for _, w := range hooks {
+			if !w.match(e) {
+				continue
+			}
+			docs = append(docs, WebhookDelivery{
+				ID:         bson.NewObjectId().Hex(),
+				WebhookID:  w.ID,
+				URL:        w.URL,
+				Event:      e.Event,
+				Payload:    string(payload),
+				Status:     DeliveryPending,
+				NextTime:   now,
+				Createtime: now,
+			})
+		}
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	err = session.DB("webhookdelivery").C(project).Insert(docs...)
+	if err != nil {
+		return err
+	}
+	select {
+	case webhookNotify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// sendWebhookEvent 함수는 아이템, 프로젝트 이벤트 하나를 웹훅 전송대기열에 추가한다.
+// 웹훅 에러로 아이템 수정이 실패하지 않도록 에러는 로그로만 남긴다.
+func sendWebhookEvent(session *mgo.Session, event, project, id string) {
+	e := WebhookEvent{
+		Event:   event,
+		Project: project,
+		ID:      id,
+		Time:    time.Now().Format(time.RFC3339),
+	}
+	err := enqueueWebhooks(session, project, []WebhookEvent{e})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// Deliveries 함수는 프로젝트의 웹훅 전송기록을 최신순으로 limit 갯수만큼 가지고 온다.
+func Deliveries(session *mgo.Session, project string, limit int) ([]WebhookDelivery, error) {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("webhookdelivery").C(project)
+	results := []WebhookDelivery{}
+	err := c.Find(bson.M{}).Sort("-createtime").Limit(limit).All(&results)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// RetryDelivery 함수는 실패한 전송을 다시 전송대기열에 넣는다.
+func RetryDelivery(session *mgo.Session, project, id string) error {
+	session.SetMode(mgo.Monotonic, true)
+	c := session.DB("webhookdelivery").C(project)
+	var d WebhookDelivery
+	err := c.Find(bson.M{"id": id}).One(&d)
+	if err != nil {
+		return err
+	}
+	if d.Status != DeliveryFailed {
+		return errors.New("실패한 전송만 재전송할 수 있습니다")
+	}
+	d.Status = DeliveryPending
+	d.Attempts = 0
+	d.NextTime = time.Now().Format(time.RFC3339)
+	err = c.Update(bson.M{"id": id}, d)
+	if err != nil {
+		return err
+	}
+	select {
+	case webhookNotify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// processWebhookQueue 함수는 모든 프로젝트에서 전송시간이 된 웹훅을 전송한다.
+// 웹훅 secret은 전송시점의 값을 사용한다.
+func processWebhookQueue(session *mgo.Session, client *http.Client, now time.Time) error {
+	session.SetMode(mgo.Monotonic, true)
+	projects, err := session.DB("webhookdelivery").CollectionNames()
+	if err != nil {
+		return err
+	}
+	processWebhookProjects(projects, func(project string) error {
+		return processProjectWebhooks(session, client, project, now)
+	})
+	return nil
+}
+
+// processWebhookProjects 함수는 프로젝트마다 process를 실행하고 실패한 프로젝트를 반환한다.
+// 한 프로젝트가 실패하더라도 다른 프로젝트의 전송이 막히지 않도록 에러는 로그로 남기고 다음 프로젝트를 처리한다.
+func processWebhookProjects(projects []string, process func(project string) error) []string {
+	var failed []string
+	for _, project := range projects {
+		err := process(project)
+		if err != nil {
+			log.Printf("%s 프로젝트 웹훅 전송: %v", project, err)
+			failed = append(failed, project)
+		}
+	}
+	return failed
+}
+
+// processProjectWebhooks 함수는 프로젝트에서 전송시간이 된 웹훅을 전송한다.
+// 전송 하나를 처리하지 못하더라도 로그를 남기고 다음 전송을 처리한다. 처리하지 못한 전송은 다음 주기에 다시 처리된다.
+func processProjectWebhooks(session *mgo.Session, client *http.Client, project string, now time.Time) error {
+	c := session.DB("webhookdelivery").C(project)
+	var queue []WebhookDelivery
+	err := c.Find(bson.M{"status": DeliveryPending, "nexttime": bson.M{"$lte": now.Format(time.RFC3339)}}).Sort("nexttime").Limit(100).All(&queue)
+	if err != nil {
+		return err
+	}
+	for _, d := range queue {
+		var w Webhook
+		err = session.DB("webhook").C(project).Find(bson.M{"id": d.WebhookID}).One(&w)
+		if err == mgo.ErrNotFound {
+			d.Status = DeliveryFailed
+			d.Error = "웹훅이 삭제되었습니다"
+			d.Updatetime = now.Format(time.RFC3339)
+			err = c.Update(bson.M{"id": d.ID}, d)
+			if err != nil {
+				log.Printf("%s 프로젝트 %s 전송: %v", project, d.ID, err)
+			}
+			continue
+		}
+		if err != nil {
+			log.Printf("%s 프로젝트 %s 전송: %v", project, d.ID, err)
+			continue
+		}
+		code, sendErr := sendWebhook(client, d, w.Secret)
+		err = c.Update(bson.M{"id": d.ID}, nextDelivery(d, code, sendErr, time.Now()))
+		if err != nil {
+			log.Printf("%s 프로젝트 %s 전송: %v", project, d.ID, err)
+		}
+	}
+	return nil
+}
+
+// webhookWorker 함수는 웹훅 전송대기열을 처리한다.
+// 새 전송이 추가되면 바로, 그렇지 않으면 10초마다 재시도할 전송을 확인한다.
+func webhookWorker() {
+	client := &http.Client{Timeout: 10 * time.Second}
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-webhookNotify:
+		}
+		session, err := mgo.Dial(*flagDBIP)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		err = processWebhookQueue(session, client, time.Now())
+		if err != nil {
+			log.Println(err)
+		}
+		session.Close()
+	}
+}
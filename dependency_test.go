@@ -0,0 +1,80 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckDependency(t *testing.T) {
+	shot := Item{ID: "SS_0010_org", Type: "org"}
+	shot2 := Item{ID: "SS_0020_org", Type: "org"}
+	asset := Item{ID: "stone01_asset", Type: "asset"}
+	asset2 := Item{ID: "rock01_asset", Type: "asset"}
+	cases := []struct {
+		item   Item
+		target Item
+		kind   string
+		valid  bool
+	}{
+		{shot, asset, DependencyAsset, true},
+		{shot, asset, DependencyCrowd, true},
+		{asset2, asset, DependencyAsset, true},
+		{shot, shot2, DependencyShot, true},
+		{shot, shot2, DependencyAsset, false},
+		{shot, asset, DependencyShot, false},
+		{asset, shot, DependencyShot, false},
+		{shot, shot, DependencyShot, false},
+		{shot, asset, "prop", false},
+	}
+	for n, c := range cases {
+		err := checkDependency(c.item, c.target, c.kind)
+		if (err == nil) != c.valid {
+			t.Fatalf("cases[%d]: %v", n, err)
+		}
+	}
+}
+
+func TestHasDependencyCycle(t *testing.T) {
+	graph := map[string][]string{
+		"SS_0030_org": {"SS_0020_org"},
+		"SS_0020_org": {"SS_0010_org", "stone01_asset"},
+	}
+	cases := []struct {
+		from string
+		to   string
+		want bool
+	}{
+		{"SS_0010_org", "SS_0030_org", true}, // 0030 -> 0020 -> 0010
+		{"SS_0010_org", "SS_0020_org", true},
+		{"SS_0040_org", "SS_0030_org", false},
+		{"SS_0030_org", "SS_0010_org", false},
+		{"stone01_asset", "SS_0030_org", true},
+	}
+	for _, c := range cases {
+		if got := hasDependencyCycle(graph, c.from, c.to); got != c.want {
+			t.Fatalf("hasDependencyCycle(%s, %s) = %v, want %v", c.from, c.to, got, c.want)
+		}
+	}
+}
+
+func TestSetRemoveDependency(t *testing.T) {
+	var uses []Dependency
+	uses = setDependency(uses, Dependency{Kind: DependencyAsset, ID: "stone01_asset", Name: "stone01"})
+	uses = setDependency(uses, Dependency{Kind: DependencyShot, ID: "SS_0010_org", Name: "SS_0010"})
+	uses = setDependency(uses, Dependency{Kind: DependencyCrowd, ID: "stone01_asset", Name: "stone01"})
+	want := []Dependency{
+		{Kind: DependencyCrowd, ID: "stone01_asset", Name: "stone01"},
+		{Kind: DependencyShot, ID: "SS_0010_org", Name: "SS_0010"},
+	}
+	if !reflect.DeepEqual(uses, want) {
+		t.Fatalf("got %v, want %v", uses, want)
+	}
+	uses, removed, found := removeDependency(uses, "stone01_asset")
+	if !found || removed.Kind != DependencyCrowd || len(uses) != 1 || uses[0].ID != "SS_0010_org" {
+		t.Fatalf("got %v, %v, %v", uses, removed, found)
+	}
+	_, _, found = removeDependency(uses, "stone01_asset")
+	if found {
+		t.Fatal("이미 제거된 의존성을 찾았습니다")
+	}
+}
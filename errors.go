@@ -0,0 +1,22 @@
+package main
+
+// NotFoundError 는 프로젝트, 아이템, Task 처럼 요청한 대상이 존재하지 않을 때 발생하는 에러이다.
+type NotFoundError string
+
+func (e NotFoundError) Error() string {
+	return string(e)
+}
+
+// InvalidStatusError 는 프로젝트에 등록되지 않은 status를 사용했을 때 발생하는 에러이다.
+type InvalidStatusError string
+
+func (e InvalidStatusError) Error() string {
+	return string(e)
+}
+
+// InvalidDateError 는 날짜 문자열의 형식이 잘못되었을 때 발생하는 에러이다.
+type InvalidDateError string
+
+func (e InvalidDateError) Error() string {
+	return string(e)
+}
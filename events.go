@@ -0,0 +1,126 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 아이템 이벤트 종류이다.
+const (
+	ItemAdded   = "add"
+	ItemUpdated = "update"
+	ItemRemoved = "remove"
+)
+
+// ItemEvent 자료구조는 아이템이 추가, 수정, 삭제될 때 /api/events 로 전달되는 이벤트이다.
+type ItemEvent struct {
+	Type    string   `json:"type"`    // add, update, remove
+	Project string   `json:"project"` // 프로젝트
+	ID      string   `json:"id"`      // 아이템 ID
+	Fields  []string `json:"fields"`  // 변경된 필드. 중첩된 필드는 tasks.comp.status 형태로 표기한다.
+	Author  string   `json:"author"`  // 수정한 사용자 ID
+	Source  string   `json:"source"`  // 수정경로: web, rest, cli, excel
+	Time    string   `json:"time"`    // 이벤트 발생시간. RFC3339
+}
+
+// newItemEvent 함수는 아이템 변경이력으로 수정 이벤트를 만든다.
+func newItemEvent(project, id string, histories []History, editor Editor, now string) ItemEvent {
+	e := ItemEvent{
+		Type:    ItemUpdated,
+		Project: project,
+		ID:      id,
+		Fields:  []string{},
+		Author:  editor.ID,
+		Source:  editor.Source,
+		Time:    now,
+	}
+	keys := make(map[string]bool)
+	for _, h := range histories {
+		keys[h.Field] = true
+	}
+	for k := range keys {
+		e.Fields = append(e.Fields, k)
+	}
+	sort.Strings(e.Fields)
+	return e
+}
+
+// publishItemEvent 함수는 아이템이 추가되거나 삭제된 이벤트를 전달한다.
+func publishItemEvent(typ, project, id string) {
+	itemEvents.publish(ItemEvent{
+		Type:    typ,
+		Project: project,
+		ID:      id,
+		Fields:  []string{},
+		Time:    time.Now().Format(time.RFC3339),
+	})
+}
+
+// eventFilter 자료구조는 구독할 이벤트 조건이다. 빈 문자열은 모든 값을 허용한다.
+type eventFilter struct {
+	Project string
+	ID      string
+}
+
+// match 메소드는 이벤트가 조건에 맞는지 체크한다.
+func (f eventFilter) match(e ItemEvent) bool {
+	if f.Project != "" && f.Project != e.Project {
+		return false
+	}
+	if f.ID == "" {
+		return true
+	}
+	for _, id := range strings.Split(f.ID, ",") {
+		if id == e.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// eventBroker 자료구조는 아이템 이벤트를 구독자에게 전달한다.
+type eventBroker struct {
+	mu          sync.Mutex
+	subscribers map[chan ItemEvent]eventFilter
+}
+
+// itemEvents 는 서버에서 발생하는 아이템 이벤트를 전달한다.
+var itemEvents = newEventBroker()
+
+func newEventBroker() *eventBroker {
+	return &eventBroker{subscribers: make(map[chan ItemEvent]eventFilter)}
+}
+
+// subscribe 메소드는 조건에 맞는 이벤트를 받을 채널을 반환한다.
+func (b *eventBroker) subscribe(f eventFilter) chan ItemEvent {
+	ch := make(chan ItemEvent, 64)
+	b.mu.Lock()
+	b.subscribers[ch] = f
+	b.mu.Unlock()
+	return ch
+}
+
+// unsubscribe 메소드는 구독을 해제한다.
+func (b *eventBroker) unsubscribe(ch chan ItemEvent) {
+	b.mu.Lock()
+	delete(b.subscribers, ch)
+	b.mu.Unlock()
+}
+
+// publish 메소드는 조건에 맞는 구독자에게 이벤트를 전달한다.
+// 아이템 수정이 느린 구독자를 기다리지 않도록 채널이 가득 찬 구독자에게는 이벤트를 버린다.
+func (b *eventBroker) publish(e ItemEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch, f := range b.subscribers {
+		if !f.match(e) {
+			continue
+		}
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
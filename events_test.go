@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewItemEvent(t *testing.T) {
+	histories := []History{
+		{Field: "tasks.comp.status"},
+		{Field: "status"},
+		{Field: "tasks.comp.status"},
+	}
+	e := newItemEvent("TEMP", "SS_0010_org", histories, Editor{ID: "khw", Source: RESTSource}, "2020-01-01T00:00:00+09:00")
+	if e.Type != ItemUpdated || e.Author != "khw" || e.Source != RESTSource {
+		t.Fatalf("newItemEvent: 얻은 값 %v", e)
+	}
+	if !reflect.DeepEqual(e.Fields, []string{"status", "tasks.comp.status"}) {
+		t.Fatalf("Fields: 얻은 값 %v", e.Fields)
+	}
+}
+
+func TestEventBroker(t *testing.T) {
+	b := newEventBroker()
+	all := b.subscribe(eventFilter{})
+	temp := b.subscribe(eventFilter{Project: "TEMP"})
+	shot := b.subscribe(eventFilter{Project: "TEMP", ID: "SS_0010_org,SS_0020_org"})
+	b.publish(ItemEvent{Type: ItemUpdated, Project: "TEMP", ID: "SS_0020_org"})
+	b.publish(ItemEvent{Type: ItemUpdated, Project: "TEMP", ID: "SS_0030_org"})
+	b.publish(ItemEvent{Type: ItemAdded, Project: "CIRCLE", ID: "SS_0010_org"})
+	cases := []struct {
+		ch   chan ItemEvent
+		want int
+	}{
+		{ch: all, want: 3},
+		{ch: temp, want: 2},
+		{ch: shot, want: 1},
+	}
+	for n, c := range cases {
+		if len(c.ch) != c.want {
+			t.Fatalf("구독자 %d: 얻은 이벤트 %d개, 원하는 이벤트 %d개", n, len(c.ch), c.want)
+		}
+	}
+	// 구독을 해제하면 이벤트를 받지 않는다.
+	b.unsubscribe(shot)
+	b.publish(ItemEvent{Type: ItemUpdated, Project: "TEMP", ID: "SS_0010_org"})
+	if len(shot) != 1 {
+		t.Fatalf("구독을 해제한 채널에 이벤트가 전달되었습니다")
+	}
+	// 채널이 가득 찬 구독자가 있더라도 publish는 멈추지 않아야 한다.
+	for n := 0; n < 100; n++ {
+		b.publish(ItemEvent{Type: ItemUpdated, Project: "TEMP", ID: "SS_0010_org"})
+	}
+	if len(all) != cap(all) {
+		t.Fatalf("채널이 가득 차야 합니다: %d", len(all))
+	}
+}
+
+func TestServeEventsRecheck(t *testing.T) {
+	w := httptest.NewRecorder()
+	ch := make(chan ItemEvent, 2)
+	heartbeat := make(chan time.Time, 2)
+	ch <- ItemEvent{Type: ItemUpdated, Project: "TEMP", ID: "SS_0010_org"}
+	// 첫번째 heartbeat는 통과하고 두번째 heartbeat에서 토큰이 폐기된 것으로 처리한다.
+	checks := 0
+	recheck := func() (User, error) {
+		checks++
+		if checks > 1 {
+			return User{}, errors.New("토큰이 존재하지 않습니다")
+		}
+		return User{AccessLevel: AdminAccessLevel}, nil
+	}
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		serveEvents(w, w, done, ch, heartbeat, User{AccessLevel: AdminAccessLevel}, recheck)
+		close(finished)
+	}()
+	heartbeat <- time.Now()
+	heartbeat <- time.Now()
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		close(done)
+		t.Fatal("serveEvents: 체크에 실패하면 스트림을 닫아야 합니다")
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, ": ping") || !strings.HasSuffix(body, "event: close\ndata: {\"error\":\"토큰이 존재하지 않습니다\"}\n\n") {
+		t.Fatalf("serveEvents: 얻은 값 %q", body)
+	}
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/mgo.v2"
+)
+
+// externalUser 자료구조는 LDAP, OIDC 같은 외부 인증서비스에서 인증한 사용자 정보이다.
+type externalUser struct {
+	ID        string   // CSI 사용자 ID
+	Source    string   // 인증서비스. ldap, oidc
+	Email     string   // 메일
+	FirstName string   // 이름
+	LastName  string   // 성
+	Groups    []string // 그룹 리스트. LDAP은 그룹 DN, OIDC는 groups 클레임 값이다.
+	Issuer    string   // OIDC iss 클레임
+	Subject   string   // OIDC sub 클레임. Issuer와 함께 Provider의 사용자를 구분하는 변하지 않는 값이다.
+}
+
+// externalLinkError 는 외부 인증서비스의 사용자를 연결되지 않은 CSI 사용자로 로그인하려고 할 때 반환하는 에러이다.
+type externalLinkError struct {
+	ID     string
+	Source string
+}
+
+func (e externalLinkError) Error() string {
+	return fmt.Sprintf("%s 사용자는 %s 계정과 연결되어 있지 않습니다. 관리자에게 계정 연결을 요청해주세요", e.ID, e.Source)
+}
+
+// checkExternalLink 함수는 ID가 같은 기존 사용자를 외부 인증서비스의 사용자로 사용할 수 있는지 체크한다.
+// 관리자가 인증서비스를 연결한 사용자만 사용할 수 있다. OIDC는 연결된 iss, sub가 있다면 같아야 한다.
+// 퇴사처리된 사용자는 디렉토리, Provider에 계정이 남아있더라도 로그인할 수 없다.
+func checkExternalLink(u User, eu externalUser) error {
+	if u.IsLeave {
+		return errUserLeave
+	}
+	if u.AuthSource != eu.Source {
+		return externalLinkError{ID: u.ID, Source: eu.Source}
+	}
+	if eu.Source == "oidc" && u.OIDCSubject != "" && (u.OIDCIssuer != eu.Issuer || u.OIDCSubject != eu.Subject) {
+		return externalLinkError{ID: u.ID, Source: eu.Source}
+	}
+	return nil
+}
+
+// groupNames 함수는 그룹을 매핑에 사용할 수 있는 이름으로 바꾼다.
+// cn=vfx-comp,ou=groups,dc=example,dc=com 그룹은 DN 전체와 vfx-comp 이름을 모두 사용할 수 있다. 대소문자는 구분하지 않는다.
+func groupNames(group string) []string {
+	dn := strings.ToLower(strings.TrimSpace(group))
+	names := []string{dn}
+	first := strings.SplitN(dn, ",", 2)[0]
+	if strings.HasPrefix(first, "cn=") {
+		names = append(names, strings.TrimPrefix(first, "cn="))
+	}
+	return names
+}
+
+// groupMapping 함수는 "그룹:값" 형태의 매핑 문자열을 줄 순서대로 파싱한다.
+// 그룹 DN에도 :가 들어갈 수 있으므로 마지막 :를 기준으로 나눈다. 빈줄과 #으로 시작하는 줄은 무시한다.
+func groupMapping(s string) ([][2]string, error) {
+	var results [][2]string
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		n := strings.LastIndex(line, ":")
+		if n < 1 || strings.TrimSpace(line[n+1:]) == "" {
+			return nil, fmt.Errorf("%s: 그룹:값 형태로 입력해주세요", line)
+		}
+		results = append(results, [2]string{strings.ToLower(strings.TrimSpace(line[:n])), strings.TrimSpace(line[n+1:])})
+	}
+	return results, nil
+}
+
+// matchGroups 함수는 매핑에서 사용자 그룹과 일치하는 값을 매핑 순서대로 반환한다.
+func matchGroups(groups []string, mapping [][2]string) []string {
+	var names []string
+	for _, g := range groups {
+		names = append(names, groupNames(g)...)
+	}
+	var results []string
+	for _, m := range mapping {
+		if hasString(names, m[0]) {
+			results = append(results, m[1])
+		}
+	}
+	return results
+}
+
+// parseGroupAccessLevel 함수는 매핑 값을 AccessLevel로 바꾼다.
+func parseGroupAccessLevel(v string) (AccessLevel, error) {
+	n, err := strconv.Atoi(v)
+	if err != nil || AccessLevel(n) < UnknownAccessLevel || AccessLevel(n) > AdminAccessLevel {
+		return UnknownAccessLevel, fmt.Errorf("AccessLevel %s 는 %d~%d 사이의 숫자여야 합니다", v, UnknownAccessLevel, AdminAccessLevel)
+	}
+	return AccessLevel(n), nil
+}
+
+// groupAccessLevel 함수는 사용자 그룹에 매핑된 AccessLevel 중 가장 높은 값을 반환한다.
+// 매핑된 그룹이 없다면 false를 반환한다.
+func groupAccessLevel(groups []string, mapping string) (AccessLevel, bool, error) {
+	m, err := groupMapping(mapping)
+	if err != nil {
+		return UnknownAccessLevel, false, err
+	}
+	level := UnknownAccessLevel
+	found := false
+	for _, v := range matchGroups(groups, m) {
+		n, err := parseGroupAccessLevel(v)
+		if err != nil {
+			return UnknownAccessLevel, false, err
+		}
+		if !found || n > level {
+			level = n
+		}
+		found = true
+	}
+	return level, found, nil
+}
+
+// groupOrganizationsForm 함수는 사용자 그룹에 매핑된 "Division,Department,Team" 값을 조직정보 문자열(OrganizationsForm)로 바꾼다.
+// 비어있는 값은 unknown으로 처리하고, 처음 매핑된 조직을 주 조직(Primary)으로 사용한다. 매핑된 그룹이 없다면 빈 문자열을 반환한다.
+func groupOrganizationsForm(groups []string, mapping string) (string, error) {
+	m, err := groupMapping(mapping)
+	if err != nil {
+		return "", err
+	}
+	var orgs []string
+	for _, v := range matchGroups(groups, m) {
+		parts := strings.Split(v, ",")
+		if len(parts) > 3 {
+			return "", fmt.Errorf("%s: Division,Department,Team 형태로 입력해주세요", v)
+		}
+		org := []string{strconv.FormatBool(len(orgs) == 0), "unknown", "unknown", "unknown", "unknown", "unknown"}
+		for i, p := range parts {
+			if strings.TrimSpace(p) != "" {
+				org[i+1] = strings.TrimSpace(p)
+			}
+		}
+		orgs = append(orgs, strings.Join(org, ","))
+	}
+	return strings.Join(orgs, ":"), nil
+}
+
+// checkGroupMapping 함수는 AccessLevel, 조직 매핑 문자열이 올바른지 체크한다.
+func checkGroupMapping(levelMapping, orgMapping string) error {
+	levels, err := groupMapping(levelMapping)
+	if err != nil {
+		return err
+	}
+	for _, m := range levels {
+		_, err = parseGroupAccessLevel(m[1])
+		if err != nil {
+			return err
+		}
+	}
+	orgs, err := groupMapping(orgMapping)
+	if err != nil {
+		return err
+	}
+	for _, m := range orgs {
+		if len(strings.Split(m[1], ",")) > 3 {
+			return fmt.Errorf("%s: Division,Department,Team 형태로 입력해주세요", m[1])
+		}
+	}
+	return nil
+}
+
+// setExternalUser 함수는 외부 인증서비스에서 인증한 사용자를 DB에 등록하거나 그룹 정보로 업데이트하고 사용자를 반환한다.
+// 처음 로그인한 사용자는 자동으로 가입되며, 그룹 매핑이 있다면 AccessLevel과 조직정보를 그룹으로 설정한다.
+// OIDC 사용자는 iss, sub로 연결된 사용자를 먼저 찾는다. 인증서비스가 연결되지 않은 기존 사용자로는 로그인할 수 없다.
+func setExternalUser(session *mgo.Session, eu externalUser, levelMapping, orgMapping string) (User, error) {
+	level, levelFound, err := groupAccessLevel(eu.Groups, levelMapping)
+	if err != nil {
+		return User{}, err
+	}
+	orgsForm, err := groupOrganizationsForm(eu.Groups, orgMapping)
+	if err != nil {
+		return User{}, err
+	}
+	isNew := false
+	var u User
+	if eu.Source == "oidc" {
+		u, err = getUserByOIDC(session, eu.Issuer, eu.Subject)
+		if err == mgo.ErrNotFound {
+			u, err = getUser(session, eu.ID)
+		}
+	} else {
+		u, err = getUser(session, eu.ID)
+	}
+	if err == nil {
+		err = checkExternalLink(u, eu)
+	}
+	if err == mgo.ErrNotFound {
+		isNew = true
+		u = *NewUser(eu.ID)
+		// 외부 인증 사용자는 CSI 패스워드로 로그인하지 않는다. 알 수 없는 임의의 패스워드를 설정한다.
+		b := make([]byte, 24)
+		_, err = rand.Read(b)
+		if err != nil {
+			return u, err
+		}
+		u.Password, err = Encrypt(base64.StdEncoding.EncodeToString(b))
+		if err != nil {
+			return u, err
+		}
+		u.Token = base64.StdEncoding.EncodeToString([]byte(u.Password))
+		u.FirstNameEng = eu.FirstName
+		u.LastNameEng = eu.LastName
+		u.Email = eu.Email
+	} else if err != nil {
+		return u, err
+	}
+	u.AuthSource = eu.Source
+	if eu.Source == "oidc" {
+		u.OIDCIssuer = eu.Issuer
+		u.OIDCSubject = eu.Subject
+	}
+	levelChanged := levelFound && u.AccessLevel != level
+	if levelChanged {
+		u.AccessLevel = level
+	}
+	if orgsForm != "" {
+		u.Organizations, err = OrganizationsFormToOrganizations(session, orgsForm)
+		if err != nil {
+			return u, err
+		}
+		u.OrganizationsForm = orgsForm
+		u.SetTags()
+	}
+	if isNew {
+		err = addUser(session, u)
+		if err != nil {
+			return u, err
+		}
+		return u, addToken(session, u)
+	}
+	err = setUser(session, u)
+	if err != nil {
+		return u, err
+	}
+	if !levelChanged {
+		return u, nil
+	}
+	// 사용자 토큰을 업데이트한다.
+	t, err := getToken(session, u.ID)
+	if err != nil {
+		return u, err
+	}
+	t.AccessLevel = u.AccessLevel
+	return u, setToken(session, t)
+}
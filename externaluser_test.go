@@ -0,0 +1,100 @@
+package main
+
+import "testing"
+
+func TestGroupAccessLevel(t *testing.T) {
+	mapping := `# 그룹:AccessLevel
+vfx-artist:3
+cn=vfx-lead,ou=groups,dc=example,dc=com:4
+VFX-Sup:6`
+	cases := []struct {
+		groups []string
+		level  AccessLevel
+		found  bool
+	}{
+		{[]string{"cn=vfx-artist,ou=groups,dc=example,dc=com"}, ArtistAccessLevel, true},
+		{[]string{"CN=VFX-Artist,OU=Groups,DC=example,DC=com", "cn=vfx-lead,ou=groups,dc=example,dc=com"}, LeadAccessLevel, true},
+		{[]string{"cn=vfx-sup,ou=groups,dc=example,dc=com", "cn=vfx-artist,ou=groups,dc=example,dc=com"}, SupervisorAccessLevel, true},
+		{[]string{"cn=vfx-lead,ou=other,dc=example,dc=com"}, UnknownAccessLevel, false}, // DN이 다른 그룹
+		{[]string{"VFX-Artist"}, ArtistAccessLevel, true},                               // OIDC groups 클레임처럼 DN이 아닌 그룹
+		{nil, UnknownAccessLevel, false},
+	}
+	for _, c := range cases {
+		level, found, err := groupAccessLevel(c.groups, mapping)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if level != c.level || found != c.found {
+			t.Fatalf("%v: 얻은 값 %d %v 원하는 값 %d %v", c.groups, level, found, c.level, c.found)
+		}
+	}
+	for _, m := range []string{"vfx-artist:12", "vfx-artist:lead", "vfx-artist"} {
+		if _, _, err := groupAccessLevel([]string{"cn=vfx-artist"}, m); err == nil {
+			t.Fatalf("%s: 에러가 발생해야 합니다", m)
+		}
+	}
+}
+
+func TestGroupOrganizationsForm(t *testing.T) {
+	mapping := "vfx-comp:vfx,comp,comp1\nvfx-fx:vfx,fx\nmanagement:,pm"
+	cases := []struct {
+		groups []string
+		want   string
+	}{
+		{[]string{"cn=vfx-comp,ou=groups"}, "true,vfx,comp,comp1,unknown,unknown"},
+		{[]string{"cn=vfx-fx,ou=groups", "cn=vfx-comp,ou=groups"}, "true,vfx,comp,comp1,unknown,unknown:false,vfx,fx,unknown,unknown,unknown"},
+		{[]string{"cn=management,ou=groups"}, "true,unknown,pm,unknown,unknown,unknown"},
+		{[]string{"cn=other,ou=groups"}, ""},
+	}
+	for _, c := range cases {
+		got, err := groupOrganizationsForm(c.groups, mapping)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want {
+			t.Fatalf("%v: 얻은 값 %s 원하는 값 %s", c.groups, got, c.want)
+		}
+	}
+	if _, err := groupOrganizationsForm([]string{"cn=vfx-comp"}, "vfx-comp:a,b,c,d"); err == nil {
+		t.Fatal("Division,Department,Team 보다 많은 값은 에러가 발생해야 합니다")
+	}
+}
+
+func TestCheckExternalLink(t *testing.T) {
+	ldap := externalUser{ID: "alice", Source: "ldap"}
+	oidc := externalUser{ID: "alice", Source: "oidc", Issuer: "https://sso.example.com", Subject: "248289761001"}
+	cases := []struct {
+		name string
+		user User
+		eu   externalUser
+		ok   bool
+	}{
+		{"LDAP 사용자", User{ID: "alice", AuthSource: "ldap"}, ldap, true},
+		{"로컬 사용자의 LDAP 로그인", User{ID: "alice"}, ldap, false},
+		{"OIDC 사용자의 LDAP 로그인", User{ID: "alice", AuthSource: "oidc"}, ldap, false},
+		{"관리자가 연결한 OIDC 사용자", User{ID: "alice", AuthSource: "oidc"}, oidc, true},
+		{"같은 sub", User{ID: "alice", AuthSource: "oidc", OIDCIssuer: oidc.Issuer, OIDCSubject: oidc.Subject}, oidc, true},
+		{"다른 sub", User{ID: "alice", AuthSource: "oidc", OIDCIssuer: oidc.Issuer, OIDCSubject: "other"}, oidc, false},
+		{"다른 iss", User{ID: "alice", AuthSource: "oidc", OIDCIssuer: "https://evil.example.com", OIDCSubject: oidc.Subject}, oidc, false},
+		{"로컬 사용자의 OIDC 로그인", User{ID: "alice"}, oidc, false},
+		{"LDAP 사용자의 OIDC 로그인", User{ID: "alice", AuthSource: "ldap"}, oidc, false},
+	}
+	for _, c := range cases {
+		err := checkExternalLink(c.user, c.eu)
+		if (err == nil) != c.ok {
+			t.Fatalf("%s: 얻은 값 %v", c.name, err)
+		}
+		if err != nil {
+			if _, ok := err.(externalLinkError); !ok {
+				t.Fatalf("%s: externalLinkError가 아닙니다 %T", c.name, err)
+			}
+		}
+	}
+	// 퇴사처리된 사용자는 디렉토리 인증에 성공해도 로그인할 수 없다.
+	if err := checkExternalLink(User{ID: "alice", AuthSource: "ldap", IsLeave: true}, ldap); err != errUserLeave {
+		t.Fatalf("퇴사한 LDAP 사용자: 얻은 값 %v", err)
+	}
+	if err := checkExternalLink(User{ID: "alice", AuthSource: "oidc", OIDCIssuer: oidc.Issuer, OIDCSubject: oidc.Subject, IsLeave: true}, oidc); err != errUserLeave {
+		t.Fatalf("퇴사한 OIDC 사용자: 얻은 값 %v", err)
+	}
+}
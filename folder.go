@@ -0,0 +1,286 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/mgo.v2"
+)
+
+// Folder 자료구조는 관리자 설정의 경로 템플릿으로 만든 폴더 하나이다.
+type Folder struct {
+	Name       string `json:"name"`       // 설정 이름. 예) ShotPath
+	Path       string `json:"path"`       // 경로
+	Permission string `json:"permission"` // 8진수 권한. 빈 문자열이면 0775를 사용한다.
+	UID        string `json:"uid"`        // 소유자 ID. UID, GID 모두 설정되어야 소유자를 바꾼다.
+	GID        string `json:"gid"`        // 그룹 ID
+	Exists     bool   `json:"exists"`     // 이미 존재하는 경로인지 여부
+}
+
+// folderTemplate 자료구조는 관리자 설정의 경로 템플릿 하나이다.
+type folderTemplate struct {
+	Name       string
+	Path       string
+	Permission string
+	UID        string
+	GID        string
+}
+
+// projectFolderTemplates 함수는 프로젝트를 생성할 때 만드는 경로 템플릿을 순서대로 반환한다.
+func projectFolderTemplates(s Setting) []folderTemplate {
+	return []folderTemplate{
+		{"ProjectPath", s.ProjectPath, s.ProjectPathPermission, s.ProjectPathUID, s.ProjectPathGID},
+		{"ShotRootPath", s.ShotRootPath, s.ShotRootPathPermission, s.ShotRootPathUID, s.ShotRootPathGID},
+		{"AssetRootPath", s.AssetRootPath, s.AssetRootPathPermission, s.AssetRootPathUID, s.AssetRootPathGID},
+	}
+}
+
+// itemFolderTemplates 함수는 아이템 타입에 맞는 경로 템플릿을 상위 경로부터 순서대로 반환한다.
+// 샷(org, left), 에셋이 아니라면 빈 리스트를 반환한다.
+func itemFolderTemplates(s Setting, typ string) []folderTemplate {
+	switch typ {
+	case "org", "left":
+		return []folderTemplate{
+			{"ShotRootPath", s.ShotRootPath, s.ShotRootPathPermission, s.ShotRootPathUID, s.ShotRootPathGID},
+			{"SeqPath", s.SeqPath, s.SeqPathPermission, s.SeqPathUID, s.SeqPathGID},
+			{"ShotPath", s.ShotPath, s.ShotPathPermission, s.ShotPathUID, s.ShotPathGID},
+		}
+	case "asset":
+		return []folderTemplate{
+			{"AssetRootPath", s.AssetRootPath, s.AssetRootPathPermission, s.AssetRootPathUID, s.AssetRootPathGID},
+			{"AssetTypePath", s.AssetTypePath, s.AssetTypePathPermission, s.AssetTypePathUID, s.AssetTypePathGID},
+			{"AssetPath", s.AssetPath, s.AssetPathPermission, s.AssetPathUID, s.AssetPathGID},
+		}
+	}
+	return nil
+}
+
+// renderFolders 함수는 경로 템플릿에 data를 적용하여 폴더 리스트를 만든다. 빈 템플릿은 건너뛴다.
+func renderFolders(templates []folderTemplate, data interface{}) ([]Folder, error) {
+	var results []Folder
+	for _, t := range templates {
+		if strings.TrimSpace(t.Path) == "" {
+			continue
+		}
+		path, err := renderPath(t.Name, t.Path, data)
+		if err != nil {
+			return nil, err
+		}
+		_, err = parseFolderMode(t.Permission)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", t.Name, err)
+		}
+		_, _, err = parseFolderOwner(t.UID, t.GID)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", t.Name, err)
+		}
+		results = append(results, Folder{
+			Name:       t.Name,
+			Path:       filepath.Clean(path),
+			Permission: t.Permission,
+			UID:        t.UID,
+			GID:        t.GID,
+		})
+	}
+	return results, nil
+}
+
+// projectFolders 함수는 프로젝트를 생성할 때 만들 폴더 리스트를 반환한다.
+// 경로 템플릿에서는 {{.Project}} 를 사용한다.
+func projectFolders(s Setting, p Project) ([]Folder, error) {
+	return renderFolders(projectFolderTemplates(s), Item{Project: p.ID})
+}
+
+// itemFolders 함수는 아이템을 생성할 때 만들 폴더 리스트를 반환한다.
+// 경로 템플릿에서는 {{.Project}}, {{.Seq}}, {{.Name}}, {{.Assettype}} 처럼 Item 필드를 사용한다.
+// 프로젝트 생성시 만드는 temp 에셋처럼 에셋타입이 없는 에셋은 폴더를 만들지 않는다.
+func itemFolders(s Setting, i Item) ([]Folder, error) {
+	if i.Type == "asset" && i.Assettype == "" {
+		return nil, nil
+	}
+	return renderFolders(itemFolderTemplates(s, i.Type), i)
+}
+
+// parseFolderMode 함수는 8진수 권한 문자열을 FileMode로 바꾼다. 빈 문자열이면 0775를 반환한다.
+// 2775 처럼 setuid, setgid, sticky 비트를 사용할 수 있다.
+func parseFolderMode(perm string) (os.FileMode, error) {
+	if perm == "" {
+		return 0775, nil
+	}
+	n, err := strconv.ParseUint(perm, 8, 32)
+	if err != nil || n > 07777 {
+		return 0, fmt.Errorf("%s 는 0775 형태의 권한이 아닙니다", perm)
+	}
+	mode := os.FileMode(n).Perm()
+	if n&04000 != 0 {
+		mode |= os.ModeSetuid
+	}
+	if n&02000 != 0 {
+		mode |= os.ModeSetgid
+	}
+	if n&01000 != 0 {
+		mode |= os.ModeSticky
+	}
+	return mode, nil
+}
+
+// parseFolderOwner 함수는 UID, GID 문자열을 숫자로 바꾼다. 둘 중 하나라도 빈 문자열이면 -1을 반환한다.
+func parseFolderOwner(uid, gid string) (int, int, error) {
+	if uid == "" || gid == "" {
+		return -1, -1, nil
+	}
+	u, err := strconv.Atoi(uid)
+	if err != nil {
+		return -1, -1, fmt.Errorf("UID %s 는 숫자가 아닙니다", uid)
+	}
+	g, err := strconv.Atoi(gid)
+	if err != nil {
+		return -1, -1, fmt.Errorf("GID %s 는 숫자가 아닙니다", gid)
+	}
+	return u, g, nil
+}
+
+// parseUmask 함수는 8진수 Umask 문자열을 숫자로 바꾼다. 빈 문자열이면 0을 반환한다.
+func parseUmask(umask string) (int, error) {
+	if umask == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseUint(umask, 8, 32)
+	if err != nil || n > 0777 {
+		return 0, fmt.Errorf("%s 는 0002 형태의 Umask가 아닙니다", umask)
+	}
+	return int(n), nil
+}
+
+// checkFolders 함수는 폴더가 이미 존재하는지 체크하여 Exists 값을 설정한다.
+func checkFolders(folders []Folder) []Folder {
+	for n, f := range folders {
+		_, err := os.Stat(f.Path)
+		folders[n].Exists = err == nil
+	}
+	return folders
+}
+
+// mkFolders 함수는 존재하지 않는 폴더를 순서대로 생성하고 생성한 폴더 리스트를 반환한다.
+// 권한은 umask가 적용된 값으로 생성되고, 이미 존재하는 폴더의 권한과 소유자는 바꾸지 않는다.
+func mkFolders(folders []Folder, umask string) ([]Folder, error) {
+	mask, err := parseUmask(umask)
+	if err != nil {
+		return nil, err
+	}
+	var created []Folder
+	for _, f := range folders {
+		if _, err := os.Stat(f.Path); err == nil {
+			continue
+		}
+		mode, err := parseFolderMode(f.Permission)
+		if err != nil {
+			return created, err
+		}
+		err = os.MkdirAll(f.Path, mode)
+		if err != nil {
+			return created, err
+		}
+		uid, gid, err := parseFolderOwner(f.UID, f.GID)
+		if err != nil {
+			return created, err
+		}
+		if uid != -1 {
+			err = os.Chown(f.Path, uid, gid)
+			if err != nil {
+				return created, err
+			}
+		}
+		// 프로세스 umask는 다른 요청과 함께 사용하므로 바꾸지 않고, umask를 적용한 권한을 직접 설정한다.
+		// mkdir은 setuid, setgid 비트를 무시하기 때문에 chmod로 함께 설정하고, 상위 폴더에서 상속된 setgid 비트는 유지한다.
+		info, err := os.Stat(f.Path)
+		if err != nil {
+			return created, err
+		}
+		err = os.Chmod(f.Path, mode&^os.FileMode(mask)|info.Mode()&os.ModeSetgid)
+		if err != nil {
+			return created, err
+		}
+		created = append(created, f)
+	}
+	return created, nil
+}
+
+// MkProjectFolders 함수는 관리자 설정의 경로 템플릿으로 프로젝트 폴더를 생성한다.
+func MkProjectFolders(session *mgo.Session, p Project) ([]Folder, error) {
+	s, err := GetAdminSetting(session)
+	if err != nil {
+		return nil, err
+	}
+	folders, err := projectFolders(s, p)
+	if err != nil {
+		return nil, err
+	}
+	return mkFolders(folders, s.Umask)
+}
+
+// MkItemFolders 함수는 관리자 설정의 경로 템플릿으로 아이템 폴더를 생성한다.
+func MkItemFolders(session *mgo.Session, i Item) ([]Folder, error) {
+	s, err := GetAdminSetting(session)
+	if err != nil {
+		return nil, err
+	}
+	folders, err := itemFolders(s, i)
+	if err != nil {
+		return nil, err
+	}
+	return mkFolders(folders, s.Umask)
+}
+
+// autoMkProjectFolders 함수는 관리자 설정에서 AutoMkdir이 켜져 있을 때만 프로젝트 폴더를 생성한다.
+func autoMkProjectFolders(session *mgo.Session, p Project) error {
+	s, err := GetAdminSetting(session)
+	if err != nil {
+		return err
+	}
+	if !s.AutoMkdir {
+		return nil
+	}
+	_, err = MkProjectFolders(session, p)
+	return err
+}
+
+// autoMkItemFolders 함수는 관리자 설정에서 AutoMkdir이 켜져 있을 때만 아이템 폴더를 생성한다.
+func autoMkItemFolders(session *mgo.Session, i Item) error {
+	s, err := GetAdminSetting(session)
+	if err != nil {
+		return err
+	}
+	if !s.AutoMkdir {
+		return nil
+	}
+	_, err = MkItemFolders(session, i)
+	return err
+}
+
+// MkTaskFolders 함수는 Tasksetting의 폴더 구조로 아이템 Task 폴더를 생성한다.
+// Tasksetting이 없거나 폴더 구조가 설정되어 있지 않다면 아무것도 하지 않는다.
+func MkTaskFolders(session *mgo.Session, i Item, task string) ([]Folder, error) {
+	t, err := getTaskSetting(session, task+tasksettingType(i.Type))
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	folders, err := taskFolders(t, i, task)
+	if err != nil {
+		return nil, err
+	}
+	if len(folders) == 0 {
+		return nil, nil
+	}
+	s, err := GetAdminSetting(session)
+	if err != nil {
+		return nil, err
+	}
+	return mkFolders(folders, s.Umask)
+}
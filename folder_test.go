@@ -0,0 +1,186 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestItemFolders(t *testing.T) {
+	s := Setting{
+		ShotRootPath:  "/show/{{.Project}}/seq",
+		SeqPath:       "/show/{{.Project}}/seq/{{.Seq}}",
+		ShotPath:      "/show/{{.Project}}/seq/{{.Seq}}/{{.Name}}",
+		AssetTypePath: "/show/{{.Project}}/assets/{{.Assettype}}",
+		AssetPath:     "/show/{{.Project}}/assets/{{.Assettype}}/{{.Name}}/",
+	}
+	shot, err := itemFolders(s, Item{Project: "TEMP", Name: "SS_0010", Seq: "SS", Type: "org"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/show/TEMP/seq", "/show/TEMP/seq/SS", "/show/TEMP/seq/SS/SS_0010"}
+	if len(shot) != len(want) {
+		t.Fatalf("got %+v", shot)
+	}
+	for n, w := range want {
+		if shot[n].Path != w {
+			t.Fatalf("shot[%d] = %s, want %s", n, shot[n].Path, w)
+		}
+	}
+	// 빈 템플릿(AssetRootPath)은 건너뛴다.
+	asset, err := itemFolders(s, Item{Project: "TEMP", Name: "stone", Type: "asset", Assettype: "prop"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(asset) != 2 || asset[0].Name != "AssetTypePath" || asset[1].Path != "/show/TEMP/assets/prop/stone" {
+		t.Fatalf("got %+v", asset)
+	}
+	// 에셋타입이 없는 에셋, 샷, 에셋이 아닌 아이템은 폴더를 만들지 않는다.
+	for _, i := range []Item{{Project: "TEMP", Name: "temp", Type: "asset"}, {Project: "TEMP", Name: "SS_0010", Type: "src"}} {
+		folders, err := itemFolders(s, i)
+		if err != nil || len(folders) != 0 {
+			t.Fatalf("got %+v, %v", folders, err)
+		}
+	}
+	// 템플릿 문법이 틀리거나 권한 값이 잘못되면 에러를 반환한다.
+	if _, err := itemFolders(Setting{ShotPath: "/show/{{.Project"}, Item{Type: "org"}); err == nil {
+		t.Fatal("want template error")
+	}
+	if _, err := itemFolders(Setting{ShotPath: "/show/{{.Unknown}}"}, Item{Type: "org"}); err == nil {
+		t.Fatal("want unknown field error")
+	}
+	if _, err := itemFolders(Setting{ShotPath: "/show", ShotPathPermission: "0999"}, Item{Type: "org"}); err == nil {
+		t.Fatal("want permission error")
+	}
+}
+
+func TestProjectFolders(t *testing.T) {
+	s := Setting{
+		ProjectPath:           "/show/{{.Project}}",
+		ProjectPathPermission: "0755",
+		AssetRootPath:         "/show/{{.Project}}/assets",
+	}
+	folders, err := projectFolders(s, Project{ID: "TEMP"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(folders) != 2 || folders[0].Path != "/show/TEMP" || folders[0].Permission != "0755" || folders[1].Path != "/show/TEMP/assets" {
+		t.Fatalf("got %+v", folders)
+	}
+}
+
+func TestParseFolderMode(t *testing.T) {
+	cases := []struct {
+		perm  string
+		want  os.FileMode
+		valid bool
+	}{
+		{"", 0775, true},
+		{"0775", 0775, true},
+		{"755", 0755, true},
+		{"2775", 0775 | os.ModeSetgid, true},
+		{"17777", 0, false},
+		{"0778", 0, false},
+		{"rwx", 0, false},
+	}
+	for _, c := range cases {
+		got, err := parseFolderMode(c.perm)
+		if (err == nil) != c.valid || got != c.want {
+			t.Fatalf("parseFolderMode(%q) = %o, %v", c.perm, got, err)
+		}
+	}
+	for umask, want := range map[string]int{"": 0, "0002": 2, "0022": 18, "027": 23} {
+		got, err := parseUmask(umask)
+		if err != nil || got != want {
+			t.Fatalf("parseUmask(%q) = %d, %v", umask, got, err)
+		}
+	}
+	if _, err := parseUmask("0008"); err == nil {
+		t.Fatal("want umask error")
+	}
+}
+
+func TestMkFolders(t *testing.T) {
+	root, err := ioutil.TempDir("", "csi3_folder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	s := Setting{
+		Umask:              "0022",
+		ShotRootPath:       filepath.Join(root, "{{.Project}}/seq"),
+		SeqPath:            filepath.Join(root, "{{.Project}}/seq/{{.Seq}}"),
+		SeqPathPermission:  "2750",
+		ShotPath:           filepath.Join(root, "{{.Project}}/seq/{{.Seq}}/{{.Name}}"),
+		ShotPathPermission: "0777",
+		ShotPathUID:        strconv.Itoa(os.Getuid()),
+		ShotPathGID:        strconv.Itoa(os.Getgid()),
+	}
+	folders, err := itemFolders(s, Item{Project: "TEMP", Name: "SS_0010", Seq: "SS", Type: "org"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := checkFolders(folders)
+	for _, f := range before {
+		if f.Exists {
+			t.Fatalf("%s exists", f.Path)
+		}
+	}
+	created, err := mkFolders(folders, s.Umask)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 3 {
+		t.Fatalf("created %+v", created)
+	}
+	// Umask 0022가 적용된 권한으로 생성되고, 상위 폴더의 setgid 비트는 하위 폴더에 상속된다.
+	for path, want := range map[string]os.FileMode{
+		filepath.Join(root, "TEMP/seq"):            0755,
+		filepath.Join(root, "TEMP/seq/SS"):         0750 | os.ModeSetgid,
+		filepath.Join(root, "TEMP/seq/SS/SS_0010"): 0755 | os.ModeSetgid,
+	} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode()&(os.ModePerm|os.ModeSetgid) != want {
+			t.Fatalf("%s mode = %v, want %v", path, info.Mode(), want)
+		}
+	}
+	// 이미 존재하는 폴더는 다시 만들지 않는다.
+	created, err = mkFolders(folders, s.Umask)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 0 {
+		t.Fatalf("created %+v", created)
+	}
+	for _, f := range checkFolders(folders) {
+		if !f.Exists {
+			t.Fatalf("%s not exists", f.Path)
+		}
+	}
+}
+
+func TestMkFoldersUmask(t *testing.T) {
+	root, err := ioutil.TempDir("", "csi3_folder")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	// 프로세스 umask와 다른 Umask도 폴더 권한에 적용된다.
+	path := filepath.Join(root, "TEMP")
+	_, err = mkFolders([]Folder{{Name: "ProjectPath", Path: path, Permission: "0777"}}, "0077")
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&os.ModePerm != 0700 {
+		t.Fatalf("%s mode = %v, want 0700", path, info.Mode())
+	}
+}
@@ -13,6 +13,8 @@ require (
 	github.com/digital-idea/dipath v0.0.0-20190606073246-5cc149f252b0
 	github.com/digital-idea/ditime v0.0.4
 	github.com/disintegration/imaging v1.6.0
+	github.com/go-asn1-ber/asn1-ber v1.3.1
+	github.com/go-ldap/ldap/v3 v3.1.10
 	github.com/gorilla/securecookie v1.1.1
 	github.com/kr/pretty v0.1.0 // indirect
 	github.com/kr/pty v1.1.5 // indirect
@@ -22,7 +24,9 @@ require (
 	github.com/pkg/errors v0.8.1 // indirect
 	github.com/shurcooL/httpfs v0.0.0-20190527155220-6a4d4a70508b
 	github.com/shurcooL/vfsgen v0.0.0-20181202132449-6a9ea43bcacd
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
 	golang.org/x/crypto v0.0.0-20190611184440-5c40567a22f8
+	golang.org/x/image v0.0.0-20190910094157-69e4b8554b2a
 	golang.org/x/net v0.0.0-20190613194153-d28f0bde5980 // indirect
 	golang.org/x/sys v0.0.0-20190614160838-b47fdc937951
 	golang.org/x/text v0.3.2 // indirect
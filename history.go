@@ -0,0 +1,215 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/user"
+	"sort"
+	"strings"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// 아이템을 수정한 경로이다.
+const (
+	WebSource   = "web"
+	RESTSource  = "rest"
+	CLISource   = "cli"
+	ExcelSource = "excel"
+)
+
+// Editor 자료구조는 아이템을 수정한 사용자와 수정경로를 담는다.
+type Editor struct {
+	ID     string // 사용자 ID
+	Source string // web, rest, cli, excel
+}
+
+// History 자료구조는 아이템 필드 하나의 변경이력이다.
+type History struct {
+	ItemID string `json:"itemid"` // 아이템 ID
+	Author string `json:"author"` // 수정한 사용자 ID
+	Source string `json:"source"` // 수정경로: web, rest, cli, excel
+	Field  string `json:"field"`  // 변경된 필드. 중첩된 필드는 tasks.comp.status 형태로 표기한다.
+	Old    string `json:"old"`    // 변경전 값
+	New    string `json:"new"`    // 변경후 값
+	Time   string `json:"time"`   // 변경시간. RFC3339
+}
+
+// restEditor 함수는 REST API 요청으로 Editor를 만든다.
+// 웹페이지에서 로그인된 브라우저의 요청이라면 수정경로를 web으로 기록한다.
+func restEditor(r *http.Request, userID string) Editor {
+	if _, err := GetSessionID(r); err == nil {
+		return Editor{ID: userID, Source: WebSource}
+	}
+	return Editor{ID: userID, Source: RESTSource}
+}
+
+// cliEditor 함수는 명령어를 실행한 OS 사용자로 Editor를 만든다.
+func cliEditor() Editor {
+	e := Editor{ID: "unknown", Source: CLISource}
+	u, err := user.Current()
+	if err == nil {
+		e.ID = u.Username
+	}
+	return e
+}
+
+// flattenItem 함수는 아이템을 bson 키 기준으로 펼친다.
+// 중첩된 문서는 "."으로 연결된 키가 되고, 리스트는 하나의 값으로 취급한다.
+func flattenItem(i Item) (map[string]interface{}, error) {
+	data, err := bson.Marshal(i)
+	if err != nil {
+		return nil, err
+	}
+	doc := bson.M{}
+	err = bson.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]interface{})
+	flattenDoc("", doc, result)
+	return result, nil
+}
+
+// flattenDoc 함수는 bson 문서를 재귀적으로 펼쳐 result에 담는다.
+func flattenDoc(prefix string, doc bson.M, result map[string]interface{}) {
+	for k, v := range doc {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if sub, ok := v.(bson.M); ok {
+			flattenDoc(key, sub, result)
+			continue
+		}
+		result[key] = v
+	}
+}
+
+// historyValue 함수는 이력에 저장할 수 있도록 값을 문자열로 바꾼다.
+func historyValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}:
+		if len(v) == 0 {
+			return ""
+		}
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+// diffItem 함수는 변경전, 변경후 아이템을 비교하여 변경된 필드의 이력을 반환한다.
+// updatetime 처럼 매번 바뀌는 필드는 비교하지 않는다.
+func diffItem(before, after Item, editor Editor, now string) ([]History, error) {
+	b, err := flattenItem(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := flattenItem(after)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]bool)
+	for k := range b {
+		keys[k] = true
+	}
+	for k := range a {
+		keys[k] = true
+	}
+	var fields []string
+	for k := range keys {
+		if k == "updatetime" {
+			continue
+		}
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+	var results []History
+	for _, f := range fields {
+		oldValue := historyValue(b[f])
+		newValue := historyValue(a[f])
+		if oldValue == newValue {
+			continue
+		}
+		results = append(results, History{
+			ItemID: after.ID,
+			Author: editor.ID,
+			Source: editor.Source,
+			Field:  f,
+			Old:    oldValue,
+			New:    newValue,
+			Time:   now,
+		})
+	}
+	return results, nil
+}
+
+// Snapshot 자료구조는 아이템이 수정된 시점의 전체 아이템 정보이다.
+type Snapshot struct {
+	ItemID string `json:"itemid"` // 아이템 ID
+	Author string `json:"author"` // 수정한 사용자 ID
+	Source string `json:"source"` // 수정경로: web, rest, cli, excel
+	Time   string `json:"time"`   // 수정시간. RFC3339
+	Item   Item   `json:"item"`   // 수정된 이후의 아이템
+}
+
+// RevertFields 는 스냅샷에서 되돌릴 수 있는 필드 리스트이다.
+var RevertFields = []string{"tasks", "tag", "deadline", "note"}
+
+// revertItem 함수는 현재 아이템에 스냅샷 아이템의 필드를 덮어쓴 아이템을 반환한다.
+// fields 에는 tasks, tag, deadline(2D, 3D 마감일), note 를 사용할 수 있다.
+func revertItem(current, snapshot Item, fields []string) (Item, error) {
+	if len(fields) == 0 {
+		return current, errors.New("되돌릴 필드가 없습니다")
+	}
+	for _, f := range fields {
+		switch strings.ToLower(strings.TrimSpace(f)) {
+		case "tasks":
+			tasks := make(map[string]Task)
+			for k, v := range snapshot.Tasks {
+				tasks[k] = v
+			}
+			current.Tasks = tasks
+		case "tag":
+			current.Tag = append([]string{}, snapshot.Tag...)
+		case "deadline":
+			current.Ddline2d = snapshot.Ddline2d
+			current.Ddline3d = snapshot.Ddline3d
+		case "note":
+			current.Note = snapshot.Note
+		default:
+			return current, fmt.Errorf("%s 필드는 되돌릴 수 없습니다. (%s 만 사용가능합니다)", f, strings.Join(RevertFields, ", "))
+		}
+	}
+	return current, nil
+}
+
+// checkRevertTransitions 함수는 되돌린 아이템의 Task 상태변경이 프로젝트의 상태변경 규칙에 맞는지 사용자 AccessLevel로 체크한다.
+// 현재 아이템에 없는 Task는 다시 추가되는 Task이므로 Task를 배정할 때의 상태(assign)에서 바뀌는 것으로 체크한다.
+func checkRevertTransitions(statuses []Status, transitions []Transition, current, reverted Item, level AccessLevel) error {
+	var names []string
+	for name := range reverted.Tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		from := ASSIGN
+		if old, found := current.Tasks[name]; found {
+			from = old.Status
+		}
+		err := checkTransition(statuses, transitions, from, reverted.Tasks[name].Status, level)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
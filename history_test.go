@@ -0,0 +1,99 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestDiffItem(t *testing.T) {
+	before := Item{
+		ID:         "SS_0010_org",
+		Updatetime: "2020-01-01T00:00:00+09:00",
+		Tag:        []string{"fx"},
+		Tasks:      map[string]Task{"comp": Task{Title: "comp", Status: WIP}},
+	}
+	after := before
+	after.Updatetime = "2020-01-02T00:00:00+09:00"
+	after.Tag = []string{"fx", "crowd"}
+	after.Tasks = map[string]Task{"comp": Task{Title: "comp", Status: CONFIRM}}
+	editor := Editor{ID: "d10191", Source: RESTSource}
+	histories, err := diffItem(before, after, editor, "2020-01-02T00:00:00+09:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []History{{
+		ItemID: "SS_0010_org", Author: "d10191", Source: RESTSource, Field: "tag",
+		Old: `["fx"]`, New: `["fx","crowd"]`, Time: "2020-01-02T00:00:00+09:00",
+	}, {
+		ItemID: "SS_0010_org", Author: "d10191", Source: RESTSource, Field: "tasks.comp.status",
+		Old: WIP, New: CONFIRM, Time: "2020-01-02T00:00:00+09:00",
+	}}
+	if len(histories) != len(want) {
+		t.Fatalf("diffItem: 얻은 값 %v, 원하는 값 %v", histories, want)
+	}
+	for n := range want {
+		if histories[n] != want[n] {
+			t.Fatalf("diffItem: 얻은 값 %v, 원하는 값 %v", histories[n], want[n])
+		}
+	}
+}
+
+func TestRevertItem(t *testing.T) {
+	snapshot := Item{
+		Ddline2d: "2020-01-10T19:00:00+09:00",
+		Tag:      []string{"fx"},
+		Tasks:    map[string]Task{"comp": Task{Title: "comp", Status: WIP}},
+		Note:     Comment{Text: "old note"},
+	}
+	current := Item{
+		Ddline2d: "2020-02-10T19:00:00+09:00",
+		Tag:      []string{"fx", "crowd"},
+		Tasks:    map[string]Task{"comp": Task{Title: "comp", Status: DONE}},
+		Note:     Comment{Text: "new note"},
+	}
+	i, err := revertItem(current, snapshot, []string{"tasks", "tag"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if i.Tasks["comp"].Status != WIP || len(i.Tag) != 1 {
+		t.Fatalf("revertItem: tasks, tag 가 되돌려지지 않았습니다: %v", i)
+	}
+	if i.Ddline2d != current.Ddline2d || i.Note.Text != current.Note.Text {
+		t.Fatalf("revertItem: 선택하지 않은 필드가 변경되었습니다: %v", i)
+	}
+	_, err = revertItem(current, snapshot, []string{"name"})
+	if err == nil {
+		t.Fatal("revertItem: 되돌릴 수 없는 필드에 에러가 발생하지 않았습니다")
+	}
+}
+
+func TestCheckRevertTransitions(t *testing.T) {
+	statuses := DefaultStatuses()
+	transitions := []Transition{
+		{From: WIP, To: CONFIRM, AccessLevel: SupervisorAccessLevel},
+		{From: CONFIRM, To: DONE, AccessLevel: SupervisorAccessLevel},
+		{From: ASSIGN, To: WIP, AccessLevel: ArtistAccessLevel},
+	}
+	current := Item{Tasks: map[string]Task{"comp": Task{Status: DONE}, "fx": Task{Status: WIP}}}
+	// DONE 상태를 WIP로 되돌리는 규칙이 없으므로 Admin도 되돌릴 수 없다.
+	reverted := Item{Tasks: map[string]Task{"comp": Task{Status: WIP}, "fx": Task{Status: WIP}}}
+	err := checkRevertTransitions(statuses, transitions, current, reverted, AdminAccessLevel)
+	if _, ok := err.(TransitionError); !ok {
+		t.Fatalf("checkRevertTransitions: TransitionError가 발생해야 합니다: %v", err)
+	}
+	// 허용된 상태변경과 바뀌지 않은 Task, 다시 추가되는 Task는 통과한다.
+	reverted = Item{Tasks: map[string]Task{"comp": Task{Status: DONE}, "fx": Task{Status: CONFIRM}, "light": Task{Status: WIP}}}
+	err = checkRevertTransitions(statuses, transitions, current, reverted, SupervisorAccessLevel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = checkRevertTransitions(statuses, transitions, current, reverted, LeadAccessLevel)
+	if err == nil {
+		t.Fatal("checkRevertTransitions: 낮은 AccessLevel은 에러가 발생해야 합니다")
+	}
+	// 다시 추가되는 Task는 assign 상태에서 바뀌는 것으로 체크한다. assign에서 done으로 바뀌는 규칙은 없다.
+	reverted = Item{Tasks: map[string]Task{"comp": Task{Status: DONE}, "fx": Task{Status: WIP}, "light": Task{Status: DONE}}}
+	err = checkRevertTransitions(statuses, transitions, current, reverted, AdminAccessLevel)
+	if _, ok := err.(TransitionError); !ok {
+		t.Fatalf("checkRevertTransitions: 다시 추가되는 Task도 TransitionError가 발생해야 합니다: %v", err)
+	}
+}
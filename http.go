@@ -30,6 +30,11 @@ var funcMap = template.FuncMap{
 	"projectStatus2color": projectStatus2color,
 	"Status2capString":    Status2capString, // regacy
 	"Status2string":       Status2string,
+	"StatusColor":         StatusColor,
+	"StatusName":          StatusName,
+	"HasStatus":           HasStatus,
+	"HasString":           hasString,
+	"StatusIDs":           StatusIDs,
 	"name2seq":            name2seq,
 	"note2body":           note2body,
 	"pmnote2body":         pmnote2body,
@@ -41,6 +46,8 @@ var funcMap = template.FuncMap{
 	"ToShortTime":         ToShortTime,
 	"ToNormalTime":        ToNormalTime,
 	"List2str":            List2str,
+	"TaskFolders2str":     TaskFolders2str,
+	"PlaylistEntries2str": PlaylistEntries2str,
 	"CheckDate":           CheckDate,
 	"CheckUpdate":         CheckUpdate,
 	"CheckDdline":         CheckDdline,
@@ -149,6 +156,9 @@ func webserver(port string) {
 	http.HandleFunc("/addasset", handleAddAsset)
 	http.HandleFunc("/addasset_submit", handleAddAssetSubmit)
 	http.HandleFunc("/detail", handleItemDetail)
+	http.HandleFunc("/revertitem-submit", handleRevertItemSubmit)
+	http.HandleFunc("/adddependency-submit", handleAddDependencySubmit)
+	http.HandleFunc("/rmdependency-submit", handleRmDependencySubmit)
 
 	// Project
 	http.HandleFunc("/projectinfo", handleProjectinfo)
@@ -166,6 +176,10 @@ func webserver(port string) {
 	http.HandleFunc("/signin", handleSignin)
 	http.HandleFunc("/signin_submit", handleSigninSubmit)
 	http.HandleFunc("/signin_success", handleSigninSuccess)
+	http.HandleFunc("/signin_totp", handleSigninTOTP)
+	http.HandleFunc("/signin_totp_submit", handleSigninTOTPSubmit)
+	http.HandleFunc("/oidc_signin", handleOIDCSignin)
+	http.HandleFunc("/oidc_callback", handleOIDCCallback)
 	http.HandleFunc("/signout", handleSignout)
 	http.HandleFunc("/user", handleUser)
 	http.HandleFunc("/users", handleUsers)
@@ -182,6 +196,9 @@ func webserver(port string) {
 	http.HandleFunc("/adminsetting", handleAdminSetting)
 	http.HandleFunc("/adminsetting_submit", handleAdminSettingSubmit)
 	http.HandleFunc("/setadminsetting", handleSetAdminSetting)
+	http.HandleFunc("/addwebhook-submit", handleAddWebhookSubmit)
+	http.HandleFunc("/rmwebhook-submit", handleRmWebhookSubmit)
+	http.HandleFunc("/retrywebhook-submit", handleRetryWebhookSubmit)
 
 	// Organization
 	http.HandleFunc("/divisions", handleDivisions)
@@ -230,126 +247,203 @@ func webserver(port string) {
 	http.HandleFunc("/rmtasksetting-submit", handleRmTasksettingSubmit)
 	http.HandleFunc("/edittasksetting-submit", handleEditTasksettingSubmit)
 
+	// Status
+	http.HandleFunc("/statuses", handleStatuses)
+	http.HandleFunc("/addstatus-submit", handleAddStatusSubmit)
+	http.HandleFunc("/editstatus-submit", handleEditStatusSubmit)
+	http.HandleFunc("/rmstatus-submit", handleRmStatusSubmit)
+	http.HandleFunc("/addtransition-submit", handleAddTransitionSubmit)
+	http.HandleFunc("/rmtransition-submit", handleRmTransitionSubmit)
+
 	// Input
 	http.HandleFunc("/inputmode", handleInputMode)
 
+	// Playlist
+	http.HandleFunc("/playlists", handlePlaylists)
+	http.HandleFunc("/playlist", handlePlaylist)
+	http.HandleFunc("/addplaylist-submit", handleAddPlaylistSubmit)
+	http.HandleFunc("/editplaylist-submit", handleEditPlaylistSubmit)
+	http.HandleFunc("/rmplaylist-submit", handleRmPlaylistSubmit)
+	// Review
+	http.HandleFunc("/review", handleReview)
+	http.HandleFunc("/review-submit", handleReviewSubmit)
+	// API Token
+	http.HandleFunc("/usertoken-submit", handleAPITokenSubmit)
+	http.HandleFunc("/usertoken-revoke", handleAPITokenRevoke)
+	// TOTP
+	http.HandleFunc("/totp-enroll", handleTOTPEnroll)
+	http.HandleFunc("/totp-enroll-submit", handleTOTPEnrollSubmit)
+	http.HandleFunc("/totp-recoverycodes", handleTOTPRecoveryCodes)
+	http.HandleFunc("/totp-disable", handleTOTPDisable)
+	// Project Access
+	http.HandleFunc("/projectaccess", handleProjectAccess)
+	http.HandleFunc("/projectaccess-user", handleProjectAccessUserSubmit)
+	http.HandleFunc("/projectaccess-team", handleProjectAccessTeamSubmit)
+	// Contact Sheet
+	http.HandleFunc("/exportsheet", handleExportSheet)
+	http.HandleFunc("/exportsheet-submit", handleExportSheetSubmit)
+
+	// restAPI 명세
+	http.HandleFunc("/api/openapi.json", handleAPIOpenAPI)
+
 	// restAPI Project
-	http.HandleFunc("/api/project", handleAPIProject)
-	http.HandleFunc("/api/projects", handleAPIProjects)
-	http.HandleFunc("/api/addproject", handleAPIAddproject)
-	http.HandleFunc("/api/projecttags", handleAPIProjectTags)
+	http.HandleFunc("/api/project", apiHandler(handleAPIProject))
+	http.HandleFunc("/api/projects", apiHandler(handleAPIProjects))
+	http.HandleFunc("/api/addproject", apiHandler(handleAPIAddproject))
+	http.HandleFunc("/api/projecttags", apiHandler(handleAPIProjectTags))
 
 	// restAPI Onset(Setellite)
-	http.HandleFunc("/api/setellite", handleAPISetelliteItems)
-	http.HandleFunc("/api/setellitesearch", handleAPISetelliteSearch)
+	http.HandleFunc("/api/setellite", apiHandler(handleAPISetelliteItems))
+	http.HandleFunc("/api/setellitesearch", apiHandler(handleAPISetelliteSearch))
 
 	// restAPI Item
-	http.HandleFunc("/api/timeinfo", handleAPITimeinfo)
-	http.HandleFunc("/api/item", handleAPIItem)     // legacy
-	http.HandleFunc("/api/rmitem", handleAPIRmItem) // legacy
-	http.HandleFunc("/api/rmitemid", handleAPIRmItemID)
-	http.HandleFunc("/api/items", handleAPI2Items)
-	http.HandleFunc("/api2/items", handleAPI2Items) // legacy
-	http.HandleFunc("/api/searchname", handleAPISearchname)
-	http.HandleFunc("/api/seqs", handleAPISeqs)
-	http.HandleFunc("/api/shots", handleAPIShots)
-	http.HandleFunc("/api/shot", handleAPIShot)
-	http.HandleFunc("/api/setplatesize", handleAPISetPlateSize)
-	http.HandleFunc("/api/setundistortionsize", handleAPISetUnDistortionSize)
-	http.HandleFunc("/api/setrendersize", handleAPISetRenderSize)
-	http.HandleFunc("/api/setcamerapubpath", handleAPISetCameraPubPath)
-	http.HandleFunc("/api/setcamerapubtask", handleAPISetCameraPubTask)
-	http.HandleFunc("/api/setcameraprojection", handleAPISetCameraProjection)
-	http.HandleFunc("/api/setthummov", handleAPISetThummov)
-	http.HandleFunc("/api/setbeforemov", handleAPISetBeforemov)
-	http.HandleFunc("/api/setaftermov", handleAPISetAftermov)
-	http.HandleFunc("/api/settaskstatus", handleAPISetTaskStatus)
-	http.HandleFunc("/api/setassigntask", handleAPISetAssignTask)
-	http.HandleFunc("/api/rmtask", handleAPIRmTask)
-	http.HandleFunc("/api/settaskuser", handleAPISetTaskUser)
-	http.HandleFunc("/api/setplatein", handleAPISetPlateIn)
-	http.HandleFunc("/api/setplateout", handleAPISetPlateOut)
-	http.HandleFunc("/api/setjustin", handleAPISetJustIn)
-	http.HandleFunc("/api/setjustout", handleAPISetJustOut)
-	http.HandleFunc("/api/setscanin", handleAPISetScanIn)
-	http.HandleFunc("/api/setscanout", handleAPISetScanOut)
-	http.HandleFunc("/api/setscanframe", handleAPISetScanFrame)
-	http.HandleFunc("/api/sethandlein", handleAPISetHandleIn)
-	http.HandleFunc("/api/sethandleout", handleAPISetHandleOut)
-	http.HandleFunc("/api/setshottype", handleAPISetShotType)
-	http.HandleFunc("/api/setassettype", handleAPISetAssetType)
-	http.HandleFunc("/api/setoutputname", handleAPISetOutputName)
-	http.HandleFunc("/api/setrnum", handleAPISetRnum)
-	http.HandleFunc("/api/setdeadline2d", handleAPISetDeadline2D)
-	http.HandleFunc("/api/setdeadline3d", handleAPISetDeadline3D)
-	http.HandleFunc("/api/setscantimecodein", handleAPISetScanTimecodeIn)
-	http.HandleFunc("/api/setscantimecodeout", handleAPISetScanTimecodeOut)
-	http.HandleFunc("/api/setjusttimecodein", handleAPISetJustTimecodeIn)
-	http.HandleFunc("/api/setjusttimecodeout", handleAPISetJustTimecodeOut)
-	http.HandleFunc("/api/setfinver", handleAPISetFinver)
-	http.HandleFunc("/api/setfindate", handleAPISetFindate)
-	http.HandleFunc("/api/addtag", handleAPIAddTag)
-	http.HandleFunc("/api/renametag", handleAPIRenameTag)
-	http.HandleFunc("/api/rmtag", handleAPIRmTag)
-	http.HandleFunc("/api/settags", handleAPISetTags)
-	http.HandleFunc("/api/setnote", handleAPISetNote)
-	http.HandleFunc("/api/addcomment", handleAPIAddComment)
-	http.HandleFunc("/api/editcomment", handleAPIEditComment)
-	http.HandleFunc("/api/rmcomment", handleAPIRmComment)
-	http.HandleFunc("/api/addsource", handleAPIAddSource)
-	http.HandleFunc("/api/rmsource", handleAPIRmSource)
-	http.HandleFunc("/api/addreference", handleAPIAddReference)
-	http.HandleFunc("/api/rmreference", handleAPIRmReference)
-	http.HandleFunc("/api/search", handleAPISearch)
-	http.HandleFunc("/api/deadline2d", handleAPIDeadline2D)
-	http.HandleFunc("/api/deadline3d", handleAPIDeadline3D)
-	http.HandleFunc("/api/setstatus", handleAPISetTaskStatus)
-	http.HandleFunc("/api/settaskmov", handleAPISetTaskMov)
-	http.HandleFunc("/api/settaskusernote", handleAPISetTaskUserNote)
-	http.HandleFunc("/api/setretimeplate", handleAPISetRetimePlate)
-	http.HandleFunc("/api/settasklevel", handleAPISetTaskLevel)
-	http.HandleFunc("/api/setobjectid", handleAPISetObjectID)
-	http.HandleFunc("/api/setociocc", handleAPISetOCIOcc)
-	http.HandleFunc("/api/setrollmedia", handleAPISetRollmedia)
-	http.HandleFunc("/api/settaskdate", handleAPISetTaskDate)
-	http.HandleFunc("/api/settaskdue", handleAPISetTaskDue)
-	http.HandleFunc("/api/settaskpredate", handleAPISetTaskPredate)
-	http.HandleFunc("/api/settaskstartdate", handleAPISetTaskStartdate)
-	http.HandleFunc("/api/task", handleAPITask)
-	http.HandleFunc("/api/shottype", handleAPIShottype)
-	http.HandleFunc("/api/setcrowdasset", handleAPISetCrowdAsset)
-	http.HandleFunc("/api/mailinfo", handleAPIMailInfo)
+	http.HandleFunc("/api/timeinfo", apiHandler(handleAPITimeinfo))
+	http.HandleFunc("/api/item", apiHandler(handleAPIItem))     // legacy
+	http.HandleFunc("/api/rmitem", apiHandler(handleAPIRmItem)) // legacy
+	http.HandleFunc("/api/rmitemid", apiHandler(handleAPIRmItemID))
+	http.HandleFunc("/api/items", apiHandler(handleAPI2Items))
+	http.HandleFunc("/api2/items", apiHandler(handleAPI2Items)) // legacy
+	http.HandleFunc("/api/searchname", apiHandler(handleAPISearchname))
+	http.HandleFunc("/api/seqs", apiHandler(handleAPISeqs))
+	http.HandleFunc("/api/shots", apiHandler(handleAPIShots))
+	http.HandleFunc("/api/shot", apiHandler(handleAPIShot))
+	http.HandleFunc("/api/setplatesize", apiHandler(handleAPISetPlateSize))
+	http.HandleFunc("/api/setundistortionsize", apiHandler(handleAPISetUnDistortionSize))
+	http.HandleFunc("/api/setrendersize", apiHandler(handleAPISetRenderSize))
+	http.HandleFunc("/api/setcamerapubpath", apiHandler(handleAPISetCameraPubPath))
+	http.HandleFunc("/api/setcamerapubtask", apiHandler(handleAPISetCameraPubTask))
+	http.HandleFunc("/api/setcameraprojection", apiHandler(handleAPISetCameraProjection))
+	http.HandleFunc("/api/setthummov", apiHandler(handleAPISetThummov))
+	http.HandleFunc("/api/setbeforemov", apiHandler(handleAPISetBeforemov))
+	http.HandleFunc("/api/setaftermov", apiHandler(handleAPISetAftermov))
+	http.HandleFunc("/api/settaskstatus", apiHandler(handleAPISetTaskStatus))
+	http.HandleFunc("/api/setassigntask", apiHandler(handleAPISetAssignTask))
+	http.HandleFunc("/api/rmtask", apiHandler(handleAPIRmTask))
+	http.HandleFunc("/api/settaskuser", apiHandler(handleAPISetTaskUser))
+	http.HandleFunc("/api/setplatein", apiHandler(handleAPISetFrame("platein", "Plate In")))
+	http.HandleFunc("/api/setplateout", apiHandler(handleAPISetFrame("plateout", "Plate Out")))
+	http.HandleFunc("/api/setjustin", apiHandler(handleAPISetFrame("justin", "Just In")))
+	http.HandleFunc("/api/setjustout", apiHandler(handleAPISetFrame("justout", "Just Out")))
+	http.HandleFunc("/api/setscanin", apiHandler(handleAPISetFrame("scanin", "Scan In")))
+	http.HandleFunc("/api/setscanout", apiHandler(handleAPISetFrame("scanout", "Scan Out")))
+	http.HandleFunc("/api/setscanframe", apiHandler(handleAPISetFrame("scanframe", "Scan Frame")))
+	http.HandleFunc("/api/sethandlein", apiHandler(handleAPISetFrame("handlein", "Handle In")))
+	http.HandleFunc("/api/sethandleout", apiHandler(handleAPISetFrame("handleout", "Handle Out")))
+	http.HandleFunc("/api/setshottype", apiHandler(handleAPISetShotType))
+	http.HandleFunc("/api/setassettype", apiHandler(handleAPISetAssetType))
+	http.HandleFunc("/api/setoutputname", apiHandler(handleAPISetOutputName))
+	http.HandleFunc("/api/setrnum", apiHandler(handleAPISetRnum))
+	http.HandleFunc("/api/setdeadline2d", apiHandler(handleAPISetDeadline2D))
+	http.HandleFunc("/api/setdeadline3d", apiHandler(handleAPISetDeadline3D))
+	http.HandleFunc("/api/setscantimecodein", apiHandler(handleAPISetScanTimecodeIn))
+	http.HandleFunc("/api/setscantimecodeout", apiHandler(handleAPISetScanTimecodeOut))
+	http.HandleFunc("/api/setjusttimecodein", apiHandler(handleAPISetJustTimecodeIn))
+	http.HandleFunc("/api/setjusttimecodeout", apiHandler(handleAPISetJustTimecodeOut))
+	http.HandleFunc("/api/setfinver", apiHandler(handleAPISetFinver))
+	http.HandleFunc("/api/setfindate", apiHandler(handleAPISetFindate))
+	http.HandleFunc("/api/addtag", apiHandler(handleAPIAddTag))
+	http.HandleFunc("/api/renametag", apiHandler(handleAPIRenameTag))
+	http.HandleFunc("/api/rmtag", apiHandler(handleAPIRmTag))
+	http.HandleFunc("/api/settags", apiHandler(handleAPISetTags))
+	http.HandleFunc("/api/setnote", apiHandler(handleAPISetNote))
+	http.HandleFunc("/api/addcomment", apiHandler(handleAPIAddComment))
+	http.HandleFunc("/api/editcomment", apiHandler(handleAPIEditComment))
+	http.HandleFunc("/api/rmcomment", apiHandler(handleAPIRmComment))
+	http.HandleFunc("/api/addsource", apiHandler(handleAPIAddSource))
+	http.HandleFunc("/api/rmsource", apiHandler(handleAPIRmSource))
+	http.HandleFunc("/api/addreference", apiHandler(handleAPIAddReference))
+	http.HandleFunc("/api/rmreference", apiHandler(handleAPIRmReference))
+	http.HandleFunc("/api/search", apiHandler(handleAPISearch))
+	http.HandleFunc("/api/deadline2d", apiHandler(handleAPIDeadline2D))
+	http.HandleFunc("/api/deadline3d", apiHandler(handleAPIDeadline3D))
+	http.HandleFunc("/api/setstatus", apiHandler(handleAPISetTaskStatus))
+	http.HandleFunc("/api/settaskmov", apiHandler(handleAPISetTaskMov))
+	http.HandleFunc("/api/taskmovs", apiHandler(handleAPITaskMovs))
+	http.HandleFunc("/api/settaskusernote", apiHandler(handleAPISetTaskUserNote))
+	http.HandleFunc("/api/setretimeplate", apiHandler(handleAPISetRetimePlate))
+	http.HandleFunc("/api/settasklevel", apiHandler(handleAPISetTaskLevel))
+	http.HandleFunc("/api/setobjectid", apiHandler(handleAPISetObjectID))
+	http.HandleFunc("/api/setociocc", apiHandler(handleAPISetOCIOcc))
+	http.HandleFunc("/api/setrollmedia", apiHandler(handleAPISetRollmedia))
+	http.HandleFunc("/api/settaskdate", apiHandler(handleAPISetTaskDate))
+	http.HandleFunc("/api/settaskdue", apiHandler(handleAPISetTaskDue))
+	http.HandleFunc("/api/settaskpredate", apiHandler(handleAPISetTaskPredate))
+	http.HandleFunc("/api/settaskstartdate", apiHandler(handleAPISetTaskStartdate))
+	http.HandleFunc("/api/task", apiHandler(handleAPITask))
+	http.HandleFunc("/api/shottype", apiHandler(handleAPIShottype))
+	http.HandleFunc("/api/mailinfo", apiHandler(handleAPIMailInfo))
 
 	// restAPI USER
-	http.HandleFunc("/api/user", handleAPIUser)
-	http.HandleFunc("/api/users", handleAPISearchUser)
-	http.HandleFunc("/api/validuser", handleAPIValidUser) // 보안취약점 이슈가 있다. 다른 툴과 쉽게 연동할 때 편리하다. 보안레벨을 높게 올릴때는 허용하지 않도록 한다.
-	http.HandleFunc("/api/setleaveuser", handleAPISetLeaveUser)
-	http.HandleFunc("/api/autocompliteusers", handleAPIAutoCompliteUsers)
+	http.HandleFunc("/api/user", apiHandler(handleAPIUser))
+	http.HandleFunc("/api/users", apiHandler(handleAPISearchUser))
+	http.HandleFunc("/api/validuser", apiHandler(handleAPIValidUser)) // 보안취약점 이슈가 있다. 다른 툴과 쉽게 연동할 때 편리하다. 보안레벨을 높게 올릴때는 허용하지 않도록 한다.
+	http.HandleFunc("/api/setleaveuser", apiHandler(handleAPISetLeaveUser))
+	http.HandleFunc("/api/autocompliteusers", apiHandler(handleAPIAutoCompliteUsers))
 
 	// restAPI Organization
-	http.HandleFunc("/api/teams", handleAPIAllTeams)
+	http.HandleFunc("/api/teams", apiHandler(handleAPIAllTeams))
 
 	// restAPI Tasksetting
-	http.HandleFunc("/api/tasksetting", handleAPITasksetting)
-	http.HandleFunc("/api/shottasksetting", handleAPIShotTasksetting)
-	http.HandleFunc("/api/assettasksetting", handleAPIAssetTasksetting)
-	http.HandleFunc("/api/categorytasksettings", handleAPICategoryTasksettings)
+	http.HandleFunc("/api/tasksetting", apiHandler(handleAPITasksetting))
+	http.HandleFunc("/api/shottasksetting", apiHandler(handleAPIShotTasksetting))
+	http.HandleFunc("/api/assettasksetting", apiHandler(handleAPIAssetTasksetting))
+	http.HandleFunc("/api/taskpath", apiHandler(handleAPITaskPath))
+	http.HandleFunc("/api/categorytasksettings", apiHandler(handleAPICategoryTasksettings))
+
+	// restAPI Status
+	http.HandleFunc("/api/statuses", apiHandler(handleAPIStatuses))
+	http.HandleFunc("/api/transitions", apiHandler(handleAPITransitions))
+
+	// restAPI History
+	http.HandleFunc("/api/history", apiHandler(handleAPIHistory))
+	http.HandleFunc("/api/snapshots", apiHandler(handleAPISnapshots))
+	http.HandleFunc("/api/folders", apiHandler(handleAPIFolders))
+	http.HandleFunc("/api/paths", apiHandler(handleAPIPaths))
+	http.HandleFunc("/api/pathinfo", apiHandler(handleAPIPathInfo))
+	http.HandleFunc("/api/revertitem", apiHandler(handleAPIRevertItem))
+
+	// restAPI Playlist
+	http.HandleFunc("/api/playlists", apiHandler(handleAPIPlaylists))
+	http.HandleFunc("/api/playlist", apiHandler(handleAPIPlaylist))
+	http.HandleFunc("/api/addplaylist", apiHandler(handleAPIAddPlaylist))
+	http.HandleFunc("/api/setplaylist", apiHandler(handleAPISetPlaylist))
+	http.HandleFunc("/api/rmplaylist", apiHandler(handleAPIRmPlaylist))
+	http.HandleFunc("/api/exportplaylist", handleAPIExportPlaylist)
+
+	// restAPI Thumbnail
+	http.HandleFunc("/api/mkthumbnail", apiHandler(handleAPIMkThumbnail))
+	http.HandleFunc("/api/mkmissingthumbnails", apiHandler(handleAPIMkMissingThumbnails))
+	http.HandleFunc("/api/thumbnailjobs", apiHandler(handleAPIThumbnailJobs))
+
+	// restAPI Bulk
+	http.HandleFunc("/api/bulk", apiHandler(handleAPIBulk))
+
+	// restAPI Events
+	http.HandleFunc("/api/events", handleAPIEvents)
+
+	// restAPI v3
+	http.HandleFunc("/api/v3/", apiHandler(handleAPIv3))
 
 	// Deprecated: 사용하지 않는 url, 과거호환성을 위해서 남겨둠
-	http.HandleFunc("/edititem", handleEditItem)                    // legacy
-	http.HandleFunc("/editeditem", handleEditedItem)                // legacy
-	http.HandleFunc("/api/setmov", handleAPISetTaskMov)             // legacy
-	http.HandleFunc("/api/setstartdate", handleAPISetTaskStartdate) // legacy
-	http.HandleFunc("/edititem-submit", handleEditItemSubmitv2)     // legacy
+	http.HandleFunc("/edititem", handleEditItem)                                // legacy
+	http.HandleFunc("/editeditem", handleEditedItem)                            // legacy
+	http.HandleFunc("/api/setmov", apiHandler(handleAPISetTaskMov))             // legacy
+	http.HandleFunc("/api/setstartdate", apiHandler(handleAPISetTaskStartdate)) // legacy
+	http.HandleFunc("/edititem-submit", handleEditItemSubmitv2)                 // legacy
+	http.HandleFunc("/api/setcrowdasset", apiHandler(handleAPISetCrowdAsset))   // legacy
+
+	// 웹훅 전송대기열 처리
+	go webhookWorker()
+	// 썸네일 작업대기열 처리
+	go thumbnailWorker()
 
 	if port == ":443" || port == ":8443" { // https ports
-		err := http.ListenAndServeTLS(port, *flagCertFullchanin, *flagCertPrivkey, nil)
+		err := http.ListenAndServeTLS(port, *flagCertFullchanin, *flagCertPrivkey, projectAccessHandler(http.DefaultServeMux))
 		if err != nil {
 			log.Fatal(err)
 		}
 	} else {
-		err := http.ListenAndServe(port, nil)
+		err := http.ListenAndServe(port, projectAccessHandler(http.DefaultServeMux))
 		if err != nil {
 			log.Fatal(err)
 		}
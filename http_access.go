@@ -0,0 +1,158 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"gopkg.in/mgo.v2"
+)
+
+// handleProjectAccess 함수는 사용자, 팀별로 접근할 수 있는 프로젝트를 설정하는 Admin 페이지이다.
+func handleProjectAccess(w http.ResponseWriter, r *http.Request) {
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel != AdminAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	type recipe struct {
+		User        User
+		Devmode     bool
+		Projectlist []string
+		Users       []User
+		Teams       []Team
+		Updated     []string // 팀 권한을 변경한 사용자
+		Skipped     []string // 모든 프로젝트가 회수되어 변경하지 않은 사용자
+		SearchOption
+	}
+	rcp := recipe{}
+	err = rcp.SearchOption.LoadCookie(session, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Devmode = *flagDevmode
+	rcp.User, err = getUser(session, ssid.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Projectlist, err = Projectlist(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	users, err := allUsers(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	for _, u := range users {
+		if u.IsLeave {
+			continue
+		}
+		rcp.Users = append(rcp.Users, u)
+	}
+	rcp.Teams, err = allTeams(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	q := r.URL.Query()
+	rcp.Updated = Str2List(q.Get("updated"))
+	rcp.Skipped = Str2List(q.Get("skipped"))
+	err = TEMPLATES.ExecuteTemplate(w, "projectaccess", rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleProjectAccessUserSubmit 함수는 사용자가 접근할 수 있는 프로젝트를 설정한다. 프로젝트를 선택하지 않으면 모든 프로젝트에 접근할 수 있다.
+func handleProjectAccessUserSubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel != AdminAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	r.ParseForm()
+	err = SetAccessProjects(session, r.FormValue("id"), r.PostForm["projects"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	http.Redirect(w, r, "/projectaccess", http.StatusSeeOther)
+}
+
+// handleProjectAccessTeamSubmit 함수는 팀에 속한 사용자에게 프로젝트 접근권한을 한번에 부여(grant)하거나 회수(revoke)한다.
+func handleProjectAccessTeamSubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel != AdminAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	r.ParseForm()
+	projects := r.PostForm["projects"]
+	if len(projects) == 0 {
+		http.Error(w, "프로젝트를 선택해주세요", http.StatusBadRequest)
+		return
+	}
+	team, err := getTeam(session, r.FormValue("team"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	action := r.FormValue("action")
+	if action != "grant" && action != "revoke" {
+		http.Error(w, "action은 grant, revoke 중 하나를 사용해주세요", http.StatusBadRequest)
+		return
+	}
+	updated, skipped, err := SetTeamAccessProjects(session, team.ID, projects, action == "grant")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	q := url.Values{}
+	q.Set("updated", strings.Join(updated, ","))
+	q.Set("skipped", strings.Join(skipped, ","))
+	http.Redirect(w, r, "/projectaccess?"+q.Encode(), http.StatusSeeOther)
+}
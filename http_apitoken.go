@@ -0,0 +1,115 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"gopkg.in/mgo.v2"
+)
+
+// handleAPITokenSubmit 함수는 로그인한 사용자의 개인 토큰을 발급하고 토큰 키를 한번만 보여준다.
+func handleAPITokenSubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel == 0 {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	r.ParseForm()
+	t := APIToken{
+		UserID:    ssid.ID,
+		Name:      strings.TrimSpace(r.FormValue("name")),
+		ReadOnly:  str2bool(r.FormValue("readonly")),
+		Projects:  r.PostForm["projects"],
+		Endpoints: strings.Fields(strings.Replace(r.FormValue("endpoints"), ",", " ", -1)),
+	}
+	t.Expires, err = apiTokenExpires(r.FormValue("expires"), time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	type recipe struct {
+		User     User
+		Devmode  bool
+		APIToken APIToken
+		Key      string
+		SearchOption
+	}
+	rcp := recipe{}
+	err = rcp.SearchOption.LoadCookie(session, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Devmode = *flagDevmode
+	rcp.User, err = getUser(session, ssid.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// 사용자가 접근할 수 없는 프로젝트는 토큰에 설정할 수 없다.
+	err = checkProjectAccess(rcp.User, t.Projects)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	rcp.APIToken, rcp.Key, err = AddAPIToken(session, t)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = TEMPLATES.ExecuteTemplate(w, "apitoken", rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleAPITokenRevoke 함수는 개인 토큰을 폐기한다. 토큰을 발급한 사용자와 Admin만 폐기할 수 있다.
+func handleAPITokenRevoke(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	t, err := GetAPIToken(session, r.FormValue("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if t.UserID != ssid.ID && ssid.AccessLevel != AdminAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	err = RmAPIToken(session, t.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/user?id="+t.UserID, http.StatusSeeOther)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"gopkg.in/mgo.v2"
+)
+
+// handleExportSheet 함수는 현재 검색조건으로 컨택시트를 내보내기 위한 옵션 페이지이다.
+func handleExportSheet(w http.ResponseWriter, r *http.Request) {
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	type recipe struct {
+		User         User
+		Devmode      bool
+		Columns      []string
+		Search       SearchOption // 내보낼 검색조건
+		SearchOption              // navbar에서 사용하는 쿠키 검색옵션
+	}
+	rcp := recipe{
+		Columns: SheetColumns,
+		Search:  handleRequestToSearchOption(r),
+	}
+	err = rcp.SearchOption.LoadCookie(session, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Devmode = *flagDevmode
+	rcp.User, err = getUser(session, ssid.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	err = TEMPLATES.ExecuteTemplate(w, "exportsheet", rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleExportSheetSubmit 함수는 검색결과를 PDF 또는 컨택시트 이미지 파일로 내려준다.
+func handleExportSheetSubmit(w http.ResponseWriter, r *http.Request) {
+	_, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	o, err := sheetOption(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	op := handleRequestToSearchOption(r)
+	items, err := Searchv2(session, op)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	statuses, err := AllStatuses(session, op.Project)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	tasks, err := TasksettingNames(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data, err := exportSheet(sheetCells(items, statuses, tasks, o.Columns), o)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", sheetFormats[o.Format])
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", o.filename(op.Project)))
+	w.Write(data)
+}
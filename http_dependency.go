@@ -0,0 +1,69 @@
+package main
+
+import (
+	"net/http"
+
+	"gopkg.in/mgo.v2"
+)
+
+// handleAddDependencySubmit 함수는 아이템이 사용하는 에셋, 샷을 연결한다.
+func handleAddDependencySubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel < LeadAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	project := r.FormValue("project")
+	id := r.FormValue("id")
+	_, err = AddDependency(session, project, id, r.FormValue("target"), r.FormValue("kind"), Editor{ID: ssid.ID, Source: WebSource})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	http.Redirect(w, r, "/detail?project="+project+"&id="+id, http.StatusSeeOther)
+}
+
+// handleRmDependencySubmit 함수는 아이템이 사용하는 에셋, 샷 연결을 제거한다.
+func handleRmDependencySubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel < LeadAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	project := r.FormValue("project")
+	id := r.FormValue("id")
+	_, err = RmDependency(session, project, id, r.FormValue("target"), Editor{ID: ssid.ID, Source: WebSource})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	http.Redirect(w, r, "/detail?project="+project+"&id="+id, http.StatusSeeOther)
+}
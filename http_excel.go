@@ -432,7 +432,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if rnum != "" {
-			_, err := SetRnum(session, project, name, rnum)
+			_, err := SetRnum(session, project, name, rnum, Editor{ID: ssid.ID, Source: ExcelSource})
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
@@ -450,7 +450,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if shottype != "" {
-			_, err := SetShotType(session, project, name, shottype)
+			_, err := SetShotType(session, project, name, shottype, Editor{ID: ssid.ID, Source: ExcelSource})
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
@@ -468,7 +468,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if note != "" {
-			itemName, _, err := SetNote(session, project, name, ssid.ID, note, overwrite)
+			itemName, _, err := SetNote(session, project, name, ssid.ID, note, overwrite, Editor{ID: ssid.ID, Source: ExcelSource})
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: itemName, Error: err.Error()})
 				continue
@@ -486,7 +486,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if comment != "" {
-			_, err = AddComment(session, project, name, ssid.ID, time.Now().Format(time.RFC3339), comment, "")
+			_, err = AddComment(session, project, name, ssid.ID, time.Now().Format(time.RFC3339), comment, "", Editor{ID: ssid.ID, Source: ExcelSource})
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
@@ -505,7 +505,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 		}
 		if tags != "" {
 			for _, tag := range strings.Split(tags, ",") {
-				_, err = AddTag(session, project, name, tag)
+				_, err = AddTag(session, project, name, tag, Editor{ID: ssid.ID, Source: ExcelSource})
 				if err != nil {
 					rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 					continue
@@ -528,7 +528,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 				source := strings.Split(s, ":")
 				title := strings.TrimSpace(source[0])
 				path := strings.TrimSpace(source[1])
-				_, err = AddSource(session, project, name, ssid.ID, title, path)
+				_, err = AddSource(session, project, name, ssid.ID, title, path, Editor{ID: ssid.ID, Source: ExcelSource})
 				if err != nil {
 					rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 					continue
@@ -549,7 +549,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if justTimecodeIn != "" {
-			err = SetJustTimecodeIn(session, project, name, justTimecodeIn)
+			err = SetJustTimecodeIn(session, project, name, justTimecodeIn, Editor{ID: ssid.ID, Source: ExcelSource})
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
@@ -567,7 +567,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if justTimecodeOut != "" {
-			err = SetJustTimecodeOut(session, project, name, justTimecodeOut)
+			err = SetJustTimecodeOut(session, project, name, justTimecodeOut, Editor{ID: ssid.ID, Source: ExcelSource})
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
@@ -590,7 +590,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
 			}
-			_, err = SetDeadline2D(session, project, name, date)
+			_, err = SetDeadline2D(session, project, name, date, Editor{ID: ssid.ID, Source: ExcelSource})
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
@@ -613,7 +613,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
 			}
-			_, err = SetDeadline3D(session, project, name, date)
+			_, err = SetDeadline3D(session, project, name, date, Editor{ID: ssid.ID, Source: ExcelSource})
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
@@ -636,7 +636,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
 			}
-			err = SetFindate(session, project, name, date)
+			err = SetFindate(session, project, name, date, Editor{ID: ssid.ID, Source: ExcelSource})
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
@@ -654,7 +654,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if finver != "" {
-			err = SetFinver(session, project, name, finver)
+			err = SetFinver(session, project, name, finver, Editor{ID: ssid.ID, Source: ExcelSource})
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
@@ -677,7 +677,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
 			}
-			err = SetFrame(session, project, name, "handlein", num)
+			err = SetFrame(session, project, name, "handlein", num, Editor{ID: ssid.ID, Source: ExcelSource})
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
@@ -700,7 +700,7 @@ func handleExcelSubmit(w http.ResponseWriter, r *http.Request) {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
 			}
-			err = SetFrame(session, project, name, "handleout", num)
+			err = SetFrame(session, project, name, "handleout", num, Editor{ID: ssid.ID, Source: ExcelSource})
 			if err != nil {
 				rcp.ErrorItems = append(rcp.ErrorItems, ErrorItem{Name: name, Error: err.Error()})
 				continue
@@ -823,6 +823,11 @@ func handleExportExcelSubmit(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	statuses, err := AllStatuses(session, project)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	f := excelize.NewFile()
 	sheet := "Sheet1"
 	index := f.NewSheet(sheet)
@@ -921,11 +926,11 @@ func handleExportExcelSubmit(w http.ResponseWriter, r *http.Request) {
 				{"type":"top","color":"888888","style":1},
 				{"type":"bottom","color":"888888","style":1},
 				{"type":"right","color":"888888","style":1}]
-				}`, itemStatus2color(i.Status)))
+				}`, StatusColor(statuses, i.Status)))
 		if err != nil {
 			log.Println(err)
 		}
-		f.SetCellValue(sheet, pos, Status2capString(i.Status))
+		f.SetCellValue(sheet, pos, strings.ToUpper(StatusName(statuses, i.Status)))
 		f.SetCellStyle(sheet, pos, pos, statusStyle)
 		// 작업내용
 		pos, err = excelize.CoordinatesToCellName(6, n+2)
@@ -1013,11 +1018,11 @@ func handleExportExcelSubmit(w http.ResponseWriter, r *http.Request) {
 					{"type":"top","color":"888888","style":1},
 					{"type":"bottom","color":"888888","style":1},
 					{"type":"right","color":"888888","style":1}]
-					}`, itemStatus2color(i.Tasks[t].Status)))
+					}`, StatusColor(statuses, i.Tasks[t].Status)))
 			if err != nil {
 				log.Println(err)
 			}
-			text := Status2capString(i.Tasks[t].Status)
+			text := strings.ToUpper(StatusName(statuses, i.Tasks[t].Status))
 			text += "\n" + i.Tasks[t].User
 			text += "\n" + ToNormalTime(i.Tasks[t].Predate)
 			text += "\n" + ToNormalTime(i.Tasks[t].Date)
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"net/http"
+
+	"gopkg.in/mgo.v2"
+)
+
+// handleRevertItemSubmit 함수는 아이템의 필드를 선택한 스냅샷으로 되돌린다.
+func handleRevertItemSubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel < SupervisorAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	r.ParseForm()
+	project := r.FormValue("project")
+	id := r.FormValue("id")
+	_, err = RevertItem(session, project, id, r.FormValue("time"), r.PostForm["fields"], ssid.AccessLevel, Editor{ID: ssid.ID, Source: WebSource})
+	if err != nil {
+		if _, ok := err.(TransitionError); ok {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/detail?project="+project+"&id="+id, http.StatusSeeOther)
+}
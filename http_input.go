@@ -42,6 +42,7 @@ func handleInputMode(w http.ResponseWriter, r *http.Request) {
 		TasksettingNames    []string
 		TasksettingOrderMap map[string]float64
 		Dday                string
+		Statuses            []Status
 	}
 	rcp := recipe{}
 	_, rcp.OS, _ = GetInfoFromRequestHeader(r)
@@ -152,7 +153,13 @@ func handleInputMode(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	rcp.Totalnum.calculatePercent()
+	rcp.Statuses, err = AllStatuses(session, rcp.SearchOption.Project)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Totalnum.calculatePercent(rcp.Statuses)
 	if rcp.SearchOption.Project != "" {
 		rcp.Projectinfo, err = getProject(session, rcp.SearchOption.Project)
 		if err != nil {
@@ -250,6 +257,12 @@ func handleInputMode(w http.ResponseWriter, r *http.Request) {
 		MaxAge: 0,
 	}
 	http.SetCookie(w, &cookie)
+	cookie = http.Cookie{
+		Name:   "TrueStatus",
+		Value:  List2str(rcp.SearchOption.TrueStatus),
+		MaxAge: 0,
+	}
+	http.SetCookie(w, &cookie)
 	cookie = http.Cookie{
 		Name:   "Template",
 		Value:  rcp.SearchOption.Template,
@@ -1,23 +1,16 @@
 package main
 
 import (
-	"bytes"
 	"fmt"
-	"html/template"
 	"io"
 	"log"
 	"mime"
 	"net/http"
 	"os"
-	"path/filepath"
-	"strconv"
 	"strings"
 	"time"
 	"unicode"
 
-	"github.com/digital-idea/dipath"
-	"github.com/disintegration/imaging"
-	"golang.org/x/sys/unix"
 	"gopkg.in/mgo.v2"
 )
 
@@ -39,30 +32,15 @@ func handleSearchSubmitv2(w http.ResponseWriter, r *http.Request) {
 	Project := r.FormValue("Project")
 	Searchword := r.FormValue("Searchword")
 	Sortkey := r.FormValue("Sortkey")
-	Assign := str2bool(r.FormValue("Assign"))
-	Ready := str2bool(r.FormValue("Ready"))
-	Wip := str2bool(r.FormValue("Wip"))
-	Confirm := str2bool(r.FormValue("Confirm"))
-	Done := str2bool(r.FormValue("Done"))
-	Omit := str2bool(r.FormValue("Omit"))
-	Hold := str2bool(r.FormValue("Hold"))
-	Out := str2bool(r.FormValue("Out"))
-	None := str2bool(r.FormValue("None"))
+	r.ParseForm()
+	TrueStatus := List2str(r.PostForm["TrueStatus"])
 	Template := r.FormValue("Template")
 	Task := r.FormValue("Task")
-	redirectURL := fmt.Sprintf(`/inputmode?project=%s&searchword=%s&sortkey=%s&assign=%t&ready=%t&wip=%t&confirm=%t&done=%t&omit=%t&hold=%t&out=%t&none=%t&template=%s&task=%s`,
+	redirectURL := fmt.Sprintf(`/inputmode?project=%s&searchword=%s&sortkey=%s&truestatus=%s&template=%s&task=%s`,
 		Project,
 		Searchword,
 		Sortkey,
-		Assign,
-		Ready,
-		Wip,
-		Confirm,
-		Done,
-		Omit,
-		Hold,
-		Out,
-		None,
+		TrueStatus,
 		Template,
 		Task,
 	)
@@ -104,6 +82,12 @@ func handleItemDetail(w http.ResponseWriter, r *http.Request) {
 		Wfs   string
 		Item
 		TasksettingOrderMap map[string]float64
+		Statuses            []Status
+		Histories           []History
+		Snapshots           []Snapshot
+		RevertFields        []string
+		UsedIn              []Dependency
+		DependencyKinds     []string
 	}
 	rcp := recipe{}
 	rcp.Wfs = *flagWFS
@@ -147,6 +131,28 @@ func handleItemDetail(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	rcp.Statuses, err = AllStatuses(session, project)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Histories, err = Histories(session, project, id, "")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Snapshots, err = Snapshots(session, project, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.RevertFields = RevertFields
+	rcp.UsedIn, err = UsedIn(session, project, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.DependencyKinds = DependencyKinds
 	err = TEMPLATES.ExecuteTemplate(w, "detail", rcp)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -262,23 +268,20 @@ func handleIndex(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	// 아무 상태도 선택되어있지 않다면 기본 상태설정으로 변경한다.
+	// 아무 상태도 선택되어있지 않다면 프로젝트의 기본 상태설정으로 변경한다.
 	if rcp.SearchOption.isStatusOff() {
-		rcp.SearchOption.setStatusDefault()
+		statuses, err := AllStatuses(session, rcp.SearchOption.Project)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		rcp.SearchOption.setTrueStatus(defaultOnStatusIDs(statuses))
 	}
 
-	url := fmt.Sprintf("/inputmode?project=%s&sortkey=%s&template=index&endpoint=searchv2&assign=%t&ready=%t&wip=%t&confirm=%t&done=%t&omit=%t&hold=%t&out=%t&none=%t&task=%s&searchword=%s",
+	url := fmt.Sprintf("/inputmode?project=%s&sortkey=%s&template=index&endpoint=searchv2&truestatus=%s&task=%s&searchword=%s",
 		rcp.SearchOption.Project,
 		rcp.SearchOption.Sortkey,
-		rcp.SearchOption.Assign,
-		rcp.SearchOption.Ready,
-		rcp.SearchOption.Wip,
-		rcp.SearchOption.Confirm,
-		rcp.SearchOption.Done,
-		rcp.SearchOption.Omit,
-		rcp.SearchOption.Hold,
-		rcp.SearchOption.Out,
-		rcp.SearchOption.None,
+		List2str(rcp.SearchOption.checkedStatus()),
 		rcp.SearchOption.Task,
 		rcp.SearchOption.Searchword,
 	)
@@ -410,169 +413,12 @@ func handleAddShotSubmit(w http.ResponseWriter, r *http.Request) {
 		}
 		// 폴더 생성 옵션을 체크하면 폴더를 생성한다.
 		if mkdir {
-			adminSetting, err := GetAdminSetting(session)
-			if err != nil {
-				s.Error = err.Error()
-				fails = append(fails, s)
-				continue
-			}
-			var shotRootPath bytes.Buffer
-			var seqPath bytes.Buffer
-			var shotPath bytes.Buffer
-			shotRootPathTmpl, err := template.New("shotRootPath").Parse(adminSetting.ShotRootPath)
-			if err != nil {
-				s.Error = err.Error()
-				fails = append(fails, s)
-				continue
-			}
-			err = shotRootPathTmpl.Execute(&shotRootPath, i)
-			if err != nil {
-				s.Error = err.Error()
-				fails = append(fails, s)
-				continue
-			}
-			seqPathTmpl, err := template.New("seqPath").Parse(adminSetting.SeqPath)
-			if err != nil {
-				s.Error = err.Error()
-				fails = append(fails, s)
-				continue
-			}
-			err = seqPathTmpl.Execute(&seqPath, i)
-			if err != nil {
-				s.Error = err.Error()
-				fails = append(fails, s)
-				continue
-			}
-			shotPathTmpl, err := template.New("shotPath").Parse(adminSetting.ShotPath)
-			if err != nil {
-				s.Error = err.Error()
-				fails = append(fails, s)
-				continue
-			}
-			err = shotPathTmpl.Execute(&shotPath, i)
-			if err != nil {
-				s.Error = err.Error()
-				fails = append(fails, s)
-				continue
-			}
-			// Umask를 셋팅한다.
-			if adminSetting.Umask == "" {
-				unix.Umask(0)
-			} else {
-				umask, err := strconv.Atoi(adminSetting.Umask)
-				if err != nil {
-					s.Error = err.Error()
-					fails = append(fails, s)
-					continue
-				}
-				unix.Umask(umask)
-			}
-			// ShotRootPath를 점검하고 없다면 경로를 생성한다.
-			if _, err := os.Stat(shotRootPath.String()); os.IsNotExist(err) {
-				per, err := strconv.ParseInt(adminSetting.ShotRootPathPermission, 8, 64)
-				if err != nil {
-					s.Error = err.Error()
-					fails = append(fails, s)
-					continue
-				}
-				err = os.MkdirAll(shotRootPath.String(), os.FileMode(per))
-				if err != nil {
-					s.Error = err.Error()
-					fails = append(fails, s)
-					continue
-				}
-				// admin 셋팅에 UID와 GID가 선언되어 있다면, 설정을 해줍니다.
-				if adminSetting.ShotRootPathUID != "" && adminSetting.ShotRootPathGID != "" {
-					uid, err := strconv.Atoi(adminSetting.ShotRootPathUID)
-					if err != nil {
-						s.Error = err.Error()
-						fails = append(fails, s)
-						continue
-					}
-					gid, err := strconv.Atoi(adminSetting.ShotRootPathGID)
-					if err != nil {
-						s.Error = err.Error()
-						fails = append(fails, s)
-						continue
-					}
-					err = os.Chown(shotRootPath.String(), uid, gid)
-					if err != nil {
-						s.Error = err.Error()
-						fails = append(fails, s)
-						continue
-					}
-				}
-			}
-			// 개별 샷 경로를 생성한다.
-			per, err := strconv.ParseInt(adminSetting.ShotPathPermission, 8, 64)
-			if err != nil {
-				s.Error = err.Error()
-				fails = append(fails, s)
-				continue
-			}
-			err = os.MkdirAll(shotPath.String(), os.FileMode(per))
+			_, err = MkItemFolders(session, i)
 			if err != nil {
 				s.Error = err.Error()
 				fails = append(fails, s)
 				continue
 			}
-			// admin 셋팅에 UID와 GID가 선언되어 있다면, 설정을 해줍니다.
-			if adminSetting.ShotPathUID != "" && adminSetting.ShotPathGID != "" {
-				uid, err := strconv.Atoi(adminSetting.ShotPathUID)
-				if err != nil {
-					s.Error = err.Error()
-					fails = append(fails, s)
-					continue
-				}
-				gid, err := strconv.Atoi(adminSetting.ShotPathGID)
-				if err != nil {
-					s.Error = err.Error()
-					fails = append(fails, s)
-					continue
-				}
-				err = os.Chown(shotPath.String(), uid, gid)
-				if err != nil {
-					s.Error = err.Error()
-					fails = append(fails, s)
-					continue
-				}
-			}
-			// Seq 경로의 Permission을 설정한다.
-			if adminSetting.SeqPathPermission != "" {
-				per, err := strconv.ParseInt(adminSetting.SeqPathPermission, 8, 64)
-				if err != nil {
-					s.Error = err.Error()
-					fails = append(fails, s)
-					continue
-				}
-				err = os.Chmod(seqPath.String(), os.FileMode(per))
-				if err != nil {
-					s.Error = err.Error()
-					fails = append(fails, s)
-					continue
-				}
-			}
-			// Seq 경로의 UID, GID를 설정한다.
-			if adminSetting.SeqPathUID != "" && adminSetting.SeqPathGID != "" {
-				uid, err := strconv.Atoi(adminSetting.SeqPathUID)
-				if err != nil {
-					s.Error = err.Error()
-					fails = append(fails, s)
-					continue
-				}
-				gid, err := strconv.Atoi(adminSetting.SeqPathGID)
-				if err != nil {
-					s.Error = err.Error()
-					fails = append(fails, s)
-					continue
-				}
-				err = os.Chown(seqPath.String(), uid, gid)
-				if err != nil {
-					s.Error = err.Error()
-					fails = append(fails, s)
-					continue
-				}
-			}
 		}
 		success = append(success, s)
 		// slack log
@@ -689,7 +535,6 @@ func handleAddAssetSubmit(w http.ResponseWriter, r *http.Request) {
 	name := r.FormValue("Name")
 	assettype := r.FormValue("Assettype")
 	construction := r.FormValue("Construction")
-	crowdAsset := str2bool(r.FormValue("CrowdAsset"))
 	mkdir := str2bool(r.FormValue("Mkdir"))
 	f := func(c rune) bool {
 		return !unicode.IsLetter(c) && !unicode.IsNumber(c) && c != '_'
@@ -723,7 +568,6 @@ func handleAddAssetSubmit(w http.ResponseWriter, r *http.Request) {
 		i.Updatetime = time.Now().Format(time.RFC3339)
 		i.Assettype = assettype
 		i.Assettags = []string{assettype, construction}
-		i.CrowdAsset = crowdAsset
 		err = addItem(session, project, i)
 		if err != nil {
 			a.Error = err.Error()
@@ -732,169 +576,12 @@ func handleAddAssetSubmit(w http.ResponseWriter, r *http.Request) {
 		}
 		// 폴더 생성 옵션을 체크하면 폴더를 생성한다.
 		if mkdir {
-			adminSetting, err := GetAdminSetting(session)
-			if err != nil {
-				a.Error = err.Error()
-				fails = append(fails, a)
-				continue
-			}
-			var assetRootPath bytes.Buffer
-			var assetTypePath bytes.Buffer
-			var assetPath bytes.Buffer
-			assetRootPathTmpl, err := template.New("assetRootPath").Parse(adminSetting.AssetRootPath)
-			if err != nil {
-				a.Error = err.Error()
-				fails = append(fails, a)
-				continue
-			}
-			err = assetRootPathTmpl.Execute(&assetRootPath, i)
+			_, err = MkItemFolders(session, i)
 			if err != nil {
 				a.Error = err.Error()
 				fails = append(fails, a)
 				continue
 			}
-			AssetTypePathTmpl, err := template.New("assetTypePath").Parse(adminSetting.AssetTypePath)
-			if err != nil {
-				a.Error = err.Error()
-				fails = append(fails, a)
-				continue
-			}
-			err = AssetTypePathTmpl.Execute(&assetTypePath, i)
-			if err != nil {
-				a.Error = err.Error()
-				fails = append(fails, a)
-				continue
-			}
-			assetPathTmpl, err := template.New("assetPath").Parse(adminSetting.AssetPath)
-			if err != nil {
-				a.Error = err.Error()
-				fails = append(fails, a)
-				continue
-			}
-			err = assetPathTmpl.Execute(&assetPath, i)
-			if err != nil {
-				a.Error = err.Error()
-				fails = append(fails, a)
-				continue
-			}
-			// Umask를 셋팅한다.
-			if adminSetting.Umask == "" {
-				unix.Umask(0)
-			} else {
-				umask, err := strconv.Atoi(adminSetting.Umask)
-				if err != nil {
-					a.Error = err.Error()
-					fails = append(fails, a)
-					continue
-				}
-				unix.Umask(umask)
-			}
-			// ShotRootPath를 점검하고 없다면 경로를 생성한다.
-			if _, err := os.Stat(assetRootPath.String()); os.IsNotExist(err) {
-				per, err := strconv.ParseInt(adminSetting.AssetRootPathPermission, 8, 64)
-				if err != nil {
-					a.Error = err.Error()
-					fails = append(fails, a)
-					continue
-				}
-				err = os.MkdirAll(assetRootPath.String(), os.FileMode(per))
-				if err != nil {
-					a.Error = err.Error()
-					fails = append(fails, a)
-					continue
-				}
-				// admin 셋팅에 UID와 GID가 선언되어 있다면, 설정을 해줍니다.
-				if adminSetting.AssetRootPathUID != "" && adminSetting.AssetRootPathGID != "" {
-					uid, err := strconv.Atoi(adminSetting.AssetRootPathUID)
-					if err != nil {
-						a.Error = err.Error()
-						fails = append(fails, a)
-						continue
-					}
-					gid, err := strconv.Atoi(adminSetting.AssetRootPathGID)
-					if err != nil {
-						a.Error = err.Error()
-						fails = append(fails, a)
-						continue
-					}
-					err = os.Chown(assetRootPath.String(), uid, gid)
-					if err != nil {
-						a.Error = err.Error()
-						fails = append(fails, a)
-						continue
-					}
-				}
-			}
-			// 개별 샷 경로를 생성한다.
-			per, err := strconv.ParseInt(adminSetting.AssetPathPermission, 8, 64)
-			if err != nil {
-				a.Error = err.Error()
-				fails = append(fails, a)
-				continue
-			}
-			err = os.MkdirAll(assetPath.String(), os.FileMode(per))
-			if err != nil {
-				a.Error = err.Error()
-				fails = append(fails, a)
-				continue
-			}
-			// admin 셋팅에 UID와 GID가 선언되어 있다면, 설정을 해줍니다.
-			if adminSetting.AssetPathUID != "" && adminSetting.AssetPathGID != "" {
-				uid, err := strconv.Atoi(adminSetting.AssetPathUID)
-				if err != nil {
-					a.Error = err.Error()
-					fails = append(fails, a)
-					continue
-				}
-				gid, err := strconv.Atoi(adminSetting.AssetPathGID)
-				if err != nil {
-					a.Error = err.Error()
-					fails = append(fails, a)
-					continue
-				}
-				err = os.Chown(assetPath.String(), uid, gid)
-				if err != nil {
-					a.Error = err.Error()
-					fails = append(fails, a)
-					continue
-				}
-			}
-			// AssetType 경로의 Permission을 설정한다.
-			if adminSetting.AssetTypePathPermission != "" {
-				per, err := strconv.ParseInt(adminSetting.AssetTypePathPermission, 8, 64)
-				if err != nil {
-					a.Error = err.Error()
-					fails = append(fails, a)
-					continue
-				}
-				err = os.Chmod(assetTypePath.String(), os.FileMode(per))
-				if err != nil {
-					a.Error = err.Error()
-					fails = append(fails, a)
-					continue
-				}
-			}
-			// Seq 경로의 UID, GID를 설정한다.
-			if adminSetting.AssetTypePathUID != "" && adminSetting.AssetTypePathGID != "" {
-				uid, err := strconv.Atoi(adminSetting.AssetTypePathUID)
-				if err != nil {
-					a.Error = err.Error()
-					fails = append(fails, a)
-					continue
-				}
-				gid, err := strconv.Atoi(adminSetting.AssetTypePathGID)
-				if err != nil {
-					a.Error = err.Error()
-					fails = append(fails, a)
-					continue
-				}
-				err = os.Chown(assetTypePath.String(), uid, gid)
-				if err != nil {
-					a.Error = err.Error()
-					fails = append(fails, a)
-					continue
-				}
-			}
 		}
 		success = append(success, a)
 	}
@@ -979,51 +666,13 @@ func handleEditItemSubmitv2(w http.ResponseWriter, r *http.Request) {
 		tempFile.Close()
 		defer os.Remove(tempPath)
 		//fmt.Println(tempPath)
-		thumbnailPath := fmt.Sprintf("%s/%s/%s.jpg", *flagThumbPath, project, id)
-		thumbnailDir := filepath.Dir(thumbnailPath)
-		// 썸네일을 생성할 경로가 존재하지 않는다면 생성한다.
-		_, err = os.Stat(thumbnailDir)
-		if os.IsNotExist(err) {
-			err := os.MkdirAll(thumbnailDir, 0775)
-			if err != nil {
-				log.Println(err)
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-			// 디지털아이디어의 경우 스캔시스템에서 수동으로 이미지를 폴더에 생성하는 경우가 있다.
-			if *flagCompany == "digitalidea" {
-				err = dipath.Ideapath(thumbnailDir)
-				if err != nil {
-					log.Println(err)
-					http.Error(w, err.Error(), http.StatusInternalServerError)
-					return
-				}
-			}
-		}
 		// 이미지변환
-		src, err := imaging.Open(tempPath)
+		err = saveThumbnail(tempPath, thumbnailFilePath(project, id))
 		if err != nil {
 			log.Println(err)
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		// Resize the cropped image to width = 200px preserving the aspect ratio.
-		dst := imaging.Fill(src, 410, 222, imaging.Center, imaging.Lanczos)
-		err = imaging.Save(dst, thumbnailPath)
-		if err != nil {
-			log.Println(err)
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		// 디지털아이디어의 경우 스캔시스템에서 수동으로 이미지를 수정하는 경우도 있다.
-		if *flagCompany == "digitalidea" {
-			err = dipath.Ideapath(thumbnailPath)
-			if err != nil {
-				log.Println(err)
-				http.Error(w, err.Error(), http.StatusInternalServerError)
-				return
-			}
-		}
 	}
 	http.Redirect(w, r, "/editeditem", http.StatusSeeOther)
 }
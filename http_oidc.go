@@ -0,0 +1,113 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"gopkg.in/mgo.v2"
+)
+
+// oidcStateCookie 는 OIDC 로그인 요청의 state, nonce 값을 저장하는 쿠키 이름이다.
+const oidcStateCookie = "OIDCState"
+
+// handleOIDCSignin 함수는 OIDC Provider의 로그인 페이지로 이동한다.
+func handleOIDCSignin(w http.ResponseWriter, r *http.Request) {
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	s, err := GetAdminSetting(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !s.OIDC {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	p, err := oidcDiscover(s.OIDCIssuer)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	state, err := oidcRandom()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	nonce, err := oidcRandom()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	authURL, err := oidcAuthURL(s, p, state, nonce)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// Provider에서 돌아오는 요청은 다른 사이트에서 시작된 GET 요청이므로 SameSite Lax 쿠키를 사용한다.
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state + "." + nonce,
+		Path:     "/oidc_callback",
+		MaxAge:   600,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// handleOIDCCallback 함수는 OIDC Provider에서 받은 인증코드로 로그인하고 SSID 쿠키를 저장한다.
+// 2단계 인증이 필요한 사용자는 TOTP 코드 입력 페이지로 이동한다.
+// 처음 로그인하는 사용자는 자동으로 가입된다.
+func handleOIDCCallback(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("error") != "" {
+		http.Error(w, q.Get("error")+" "+q.Get("error_description"), http.StatusUnauthorized)
+		return
+	}
+	c, err := r.Cookie(oidcStateCookie)
+	if err != nil {
+		http.Error(w, "로그인 요청 정보가 없습니다. 다시 로그인 해주세요", http.StatusBadRequest)
+		return
+	}
+	// state, nonce 쿠키는 한번만 사용한다.
+	http.SetCookie(w, &http.Cookie{Name: oidcStateCookie, Path: "/oidc_callback", MaxAge: -1})
+	parts := strings.SplitN(c.Value, ".", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[0] != q.Get("state") {
+		http.Error(w, "state 값이 다릅니다. 다시 로그인 해주세요", http.StatusBadRequest)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	s, err := GetAdminSetting(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !s.OIDC {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	eu, err := oidcLogin(s, q.Get("code"), parts[1])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	u, err := setExternalUser(session, eu, s.OIDCGroupAccessLevel, s.OIDCGroupOrganization)
+	if _, ok := err.(externalLinkError); ok || err == errUserLeave {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	signinUser(w, r, session, u)
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"net/http"
+
+	"gopkg.in/mgo.v2"
+)
+
+// handlePlaylists 함수는 리뷰 플레이리스트 리스트와 플레이리스트 추가 폼을 보여주는 페이지이다.
+func handlePlaylists(w http.ResponseWriter, r *http.Request) {
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	type recipe struct {
+		User        User
+		Devmode     bool
+		Projectlist []string
+		Tasks       []string
+		Date        string
+		Playlists   []Playlist
+		SearchOption
+	}
+	rcp := recipe{}
+	err = rcp.SearchOption.LoadCookie(session, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Devmode = *flagDevmode
+	rcp.User, err = getUser(session, ssid.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Projectlist, err = Projectlist(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Tasks, err = TasksettingNames(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Date = r.URL.Query().Get("date")
+	rcp.Playlists, err = Playlists(session, rcp.Date)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	err = TEMPLATES.ExecuteTemplate(w, "playlists", rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// handlePlaylist 함수는 리뷰 플레이리스트를 보고 편집하는 페이지이다.
+func handlePlaylist(w http.ResponseWriter, r *http.Request) {
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	type recipe struct {
+		User     User
+		Devmode  bool
+		Formats  []string
+		Playlist Playlist
+		SearchOption
+	}
+	rcp := recipe{}
+	err = rcp.SearchOption.LoadCookie(session, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Devmode = *flagDevmode
+	rcp.Formats = playlistFormatNames()
+	rcp.User, err = getUser(session, ssid.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Playlist, err = GetPlaylist(session, r.URL.Query().Get("id"))
+	if err == mgo.ErrNotFound {
+		http.Error(w, r.URL.Query().Get("id")+" 플레이리스트가 존재하지 않습니다", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Playlist.Entries = accessiblePlaylistEntries(rcp.User, rcp.Playlist.Entries)
+	err = TEMPLATES.ExecuteTemplate(w, "playlist", rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleAddPlaylistSubmit 함수는 검색어 또는 데일리 날짜로 리뷰 플레이리스트를 추가한다.
+func handleAddPlaylistSubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel < LeadAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	p := Playlist{
+		Name:      r.FormValue("name"),
+		Date:      r.FormValue("date"),
+		Reviewers: splitFormList(r.FormValue("reviewers")),
+		Author:    ssid.ID,
+	}
+	search := PlaylistSearch{
+		Project:    r.FormValue("project"),
+		Searchword: r.FormValue("searchword"),
+		Task:       r.FormValue("task"),
+		Date:       r.FormValue("date"),
+	}
+	if search.Searchword != "" || search.Date != "" {
+		p.Entries, err = SearchPlaylistEntries(session, search)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	p, err = AddPlaylist(session, p)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	http.Redirect(w, r, "/playlist?id="+p.ID, http.StatusSeeOther)
+}
+
+// handleEditPlaylistSubmit 함수는 리뷰 플레이리스트의 이름, 날짜, 리뷰어, 항목 순서를 수정한다.
+func handleEditPlaylistSubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel < LeadAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	p, err := GetPlaylist(session, r.FormValue("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	p.Name = r.FormValue("name")
+	p.Date = r.FormValue("date")
+	p.Reviewers = splitFormList(r.FormValue("reviewers"))
+	p.Entries, err = parsePlaylistEntries(r.FormValue("entries"), p.Entries)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	_, err = SetPlaylist(session, p)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	http.Redirect(w, r, "/playlist?id="+p.ID, http.StatusSeeOther)
+}
+
+// handleRmPlaylistSubmit 함수는 리뷰 플레이리스트를 삭제한다.
+func handleRmPlaylistSubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel < LeadAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	err = RmPlaylist(session, r.FormValue("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/playlists", http.StatusSeeOther)
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"gopkg.in/mgo.v2"
+)
+
+// reviewEntry 자료구조는 리뷰모드 페이지에서 보여주는 플레이리스트 항목과 현재 Task 상태이다.
+type reviewEntry struct {
+	PlaylistEntry
+	Status string // 현재 Task 상태 ID
+}
+
+// handleReview 함수는 데일리 mov 또는 저장된 플레이리스트를 하나씩 넘겨보며 노트와 Task 상태를 입력하는 리뷰모드 페이지이다.
+// playlist가 없다면 date(기본값 오늘)에 등록된 mov를 -date 데일리 옵션과 같은 방식으로 모은다.
+func handleReview(w http.ResponseWriter, r *http.Request) {
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	type recipe struct {
+		User        User
+		Devmode     bool
+		Projectlist []string
+		Tasks       []string
+		Playlist    Playlist
+		Date        string
+		Project     string
+		Task        string
+		Entries     []reviewEntry
+		Statuses    map[string][]Status
+		SearchOption
+	}
+	rcp := recipe{}
+	err = rcp.SearchOption.LoadCookie(session, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Devmode = *flagDevmode
+	rcp.User, err = getUser(session, ssid.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Projectlist, err = Projectlist(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Tasks, err = TasksettingNames(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	q := r.URL.Query()
+	var entries []PlaylistEntry
+	if q.Get("playlist") != "" {
+		rcp.Playlist, err = GetPlaylist(session, q.Get("playlist"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		entries = rcp.Playlist.Entries
+	} else {
+		rcp.Date = q.Get("date")
+		if rcp.Date == "" {
+			rcp.Date = time.Now().Format("2006-01-02")
+		}
+		rcp.Project = q.Get("project")
+		rcp.Task = q.Get("task")
+		entries, err = SearchPlaylistEntries(session, PlaylistSearch{Project: rcp.Project, Task: rcp.Task, Date: rcp.Date})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	rcp.Statuses = make(map[string][]Status)
+	for _, e := range accessiblePlaylistEntries(rcp.User, entries) {
+		if _, found := rcp.Statuses[e.Project]; !found {
+			rcp.Statuses[e.Project], err = AllStatuses(session, e.Project)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+		entry := reviewEntry{PlaylistEntry: e}
+		item, err := getItem(session, e.Project, e.Item)
+		if err == nil {
+			entry.Status = item.Tasks[e.Task].Status
+		}
+		rcp.Entries = append(rcp.Entries, entry)
+	}
+	err = TEMPLATES.ExecuteTemplate(w, "review", rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleReviewSubmit 함수는 리뷰모드에서 입력한 노트를 아이템 코멘트로 등록하고 Task 상태를 한번에 변경한 뒤 결과를 보여준다.
+func handleReviewSubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	err = r.ParseForm()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	notes, err := reviewNotes(r.PostForm)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	type recipe struct {
+		User     User
+		Devmode  bool
+		Back     string
+		Results  []ReviewResult
+		Failed   int
+		Statuses map[string][]Status
+		SearchOption
+	}
+	rcp := recipe{}
+	err = rcp.SearchOption.LoadCookie(session, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Devmode = *flagDevmode
+	rcp.User, err = getUser(session, ssid.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var projects []string
+	for _, n := range notes {
+		projects = append(projects, n.Project)
+	}
+	err = checkProjectAccess(rcp.User, projects)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	rcp.Back = r.FormValue("back")
+	rcp.Results = SubmitReview(session, notes, ssid.ID, ssid.AccessLevel, Editor{ID: ssid.ID, Source: WebSource})
+	rcp.Statuses = make(map[string][]Status)
+	for _, result := range rcp.Results {
+		if result.Error != "" {
+			rcp.Failed++
+		}
+		if _, found := rcp.Statuses[result.Project]; found {
+			continue
+		}
+		rcp.Statuses[result.Project], err = AllStatuses(session, result.Project)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	err = TEMPLATES.ExecuteTemplate(w, "reviewresult", rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
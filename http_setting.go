@@ -3,6 +3,7 @@ package main
 import (
 	"log"
 	"net/http"
+	"strconv"
 
 	"gopkg.in/mgo.v2"
 )
@@ -27,9 +28,13 @@ func handleAdminSetting(w http.ResponseWriter, r *http.Request) {
 	}
 	defer session.Close()
 	type recipe struct {
-		User        User
-		Projectlist []string
-		Devmode     bool
+		User          User
+		Projectlist   []string
+		Devmode       bool
+		Project       string
+		Webhooks      []Webhook
+		Deliveries    []WebhookDelivery
+		WebhookEvents []string
 		SearchOption
 		Setting
 	}
@@ -58,6 +63,24 @@ func handleAdminSetting(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	// 웹훅 설정과 전송기록
+	rcp.Project = r.URL.Query().Get("project")
+	if rcp.Project == "" {
+		rcp.Project = rcp.SearchOption.Project
+	}
+	rcp.WebhookEvents = WebhookEventTypes
+	rcp.Webhooks, err = Webhooks(session, rcp.Project)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Deliveries, err = Deliveries(session, rcp.Project, 50)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	err = TEMPLATES.ExecuteTemplate(w, "adminsetting", rcp)
 	if err != nil {
 		log.Println(err)
@@ -86,7 +109,19 @@ func handleAdminSettingSubmit(w http.ResponseWriter, r *http.Request) {
 	defer session.Close()
 	s := Setting{}
 	s.ID = "admin"
+	// 패스워드, Secret 값은 페이지에 출력하지 않으므로 입력하지 않으면 기존 값을 유지한다.
+	old, err := GetAdminSetting(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 	s.Umask = r.FormValue("Umask")
+	_, err = parseUmask(s.Umask)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.AutoMkdir = str2bool(r.FormValue("AutoMkdir"))
 	s.RootPath = r.FormValue("RootPath")
 	s.ProjectPath = r.FormValue("ProjectPath")
 	s.ProjectPathPermission = r.FormValue("ProjectPathPermission")
@@ -121,6 +156,54 @@ func handleAdminSettingSubmit(w http.ResponseWriter, r *http.Request) {
 	s.RunScriptAfterEditUserProfile = r.FormValue("RunScriptAfterEditUserProfile")
 	s.ExcludeProject = r.FormValue("ExcludeProject")
 	s.OCIOConfig = r.FormValue("OCIOConfig")
+	s.AutoThumbnail = str2bool(r.FormValue("AutoThumbnail"))
+	s.ThumbnailDecoder = r.FormValue("ThumbnailDecoder")
+	_, err = decoderArgs(s.ThumbnailDecoder, ThumbnailDecode{Input: "input.mov", Output: "output.jpg"})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.LDAP = str2bool(r.FormValue("LDAP"))
+	s.LDAPURL = r.FormValue("LDAPURL")
+	s.LDAPInsecureSkipVerify = str2bool(r.FormValue("LDAPInsecureSkipVerify"))
+	s.LDAPBindDN = r.FormValue("LDAPBindDN")
+	s.LDAPBindPassword = r.FormValue("LDAPBindPassword")
+	if s.LDAPBindPassword == "" {
+		s.LDAPBindPassword = old.LDAPBindPassword
+	}
+	s.LDAPBaseDN = r.FormValue("LDAPBaseDN")
+	s.LDAPUserFilter = r.FormValue("LDAPUserFilter")
+	s.LDAPGroupAccessLevel = r.FormValue("LDAPGroupAccessLevel")
+	s.LDAPGroupOrganization = r.FormValue("LDAPGroupOrganization")
+	err = checkLDAPSetting(s)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.OIDC = str2bool(r.FormValue("OIDC"))
+	s.OIDCIssuer = r.FormValue("OIDCIssuer")
+	s.OIDCClientID = r.FormValue("OIDCClientID")
+	s.OIDCClientSecret = r.FormValue("OIDCClientSecret")
+	if s.OIDCClientSecret == "" {
+		s.OIDCClientSecret = old.OIDCClientSecret
+	}
+	s.OIDCRedirectURL = r.FormValue("OIDCRedirectURL")
+	s.OIDCScopes = r.FormValue("OIDCScopes")
+	s.OIDCIDClaim = r.FormValue("OIDCIDClaim")
+	s.OIDCGroupsClaim = r.FormValue("OIDCGroupsClaim")
+	s.OIDCGroupAccessLevel = r.FormValue("OIDCGroupAccessLevel")
+	s.OIDCGroupOrganization = r.FormValue("OIDCGroupOrganization")
+	err = checkOIDCSetting(s)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	s.TOTPAccessLevel, err = strconv.Atoi(r.FormValue("TOTPAccessLevel"))
+	if err != nil || s.TOTPAccessLevel < 0 || s.TOTPAccessLevel > int(AdminAccessLevel) {
+		http.Error(w, "TOTPAccessLevel 값이 올바르지 않습니다", http.StatusBadRequest)
+		return
+	}
 
 	err = SetAdminSetting(session, s)
 	if err != nil {
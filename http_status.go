@@ -0,0 +1,264 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+
+	"gopkg.in/mgo.v2"
+)
+
+// handleStatuses 함수는 프로젝트 상태설정을 보고 편집하는 페이지이다.
+func handleStatuses(w http.ResponseWriter, r *http.Request) {
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel != AdminAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	type recipe struct {
+		User        User
+		Devmode     bool
+		Projectlist []string
+		Project     string
+		Statuses    []Status
+		Transitions []Transition
+		SearchOption
+	}
+	rcp := recipe{}
+	err = rcp.SearchOption.LoadCookie(session, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Devmode = *flagDevmode
+	rcp.User, err = getUser(session, ssid.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Projectlist, err = Projectlist(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Project = r.URL.Query().Get("project")
+	if rcp.Project == "" {
+		rcp.Project = rcp.SearchOption.Project
+	}
+	rcp.Statuses, err = AllStatuses(session, rcp.Project)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.Transitions, err = AllTransitions(session, rcp.Project)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	err = TEMPLATES.ExecuteTemplate(w, "statuses", rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// requestToStatus 함수는 폼으로 전달된 값을 Status 자료구조로 바꾼다.
+func requestToStatus(r *http.Request) (Status, error) {
+	s := Status{
+		ID:        r.FormValue("id"),
+		Name:      r.FormValue("name"),
+		Color:     r.FormValue("color"),
+		Done:      str2bool(r.FormValue("done")),
+		Excluded:  str2bool(r.FormValue("excluded")),
+		DefaultOn: str2bool(r.FormValue("defaulton")),
+	}
+	if r.FormValue("order") == "" {
+		return s, nil
+	}
+	order, err := strconv.ParseFloat(r.FormValue("order"), 64)
+	if err != nil {
+		return s, err
+	}
+	s.Order = order
+	return s, nil
+}
+
+// handleAddStatusSubmit 함수는 프로젝트에 상태를 추가한다.
+func handleAddStatusSubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel != AdminAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	project := r.FormValue("project")
+	s, err := requestToStatus(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = AddStatus(session, project, s)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/statuses?project="+project, http.StatusSeeOther)
+}
+
+// handleEditStatusSubmit 함수는 프로젝트의 상태정보를 수정한다.
+func handleEditStatusSubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel != AdminAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	project := r.FormValue("project")
+	s, err := requestToStatus(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = SetStatus(session, project, s)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/statuses?project="+project, http.StatusSeeOther)
+}
+
+// handleRmStatusSubmit 함수는 프로젝트에서 상태를 삭제한다.
+func handleRmStatusSubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel != AdminAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	project := r.FormValue("project")
+	err = RmStatus(session, project, r.FormValue("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/statuses?project="+project, http.StatusSeeOther)
+}
+
+// handleAddTransitionSubmit 함수는 프로젝트에 상태변경 규칙을 추가한다.
+func handleAddTransitionSubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel != AdminAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	project := r.FormValue("project")
+	level, err := strconv.Atoi(r.FormValue("accesslevel"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	t := Transition{
+		From:        r.FormValue("from"),
+		To:          r.FormValue("to"),
+		AccessLevel: AccessLevel(level),
+	}
+	err = AddTransition(session, project, t)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/statuses?project="+project, http.StatusSeeOther)
+}
+
+// handleRmTransitionSubmit 함수는 프로젝트에서 상태변경 규칙을 삭제한다.
+func handleRmTransitionSubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel != AdminAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	project := r.FormValue("project")
+	err = RmTransition(session, project, r.FormValue("from"), r.FormValue("to"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/statuses?project="+project, http.StatusSeeOther)
+}
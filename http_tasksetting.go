@@ -296,6 +296,11 @@ func handleEditTasksettingSubmit(w http.ResponseWriter, r *http.Request) {
 	}
 	t.Order = floatOrder
 	t.Category = category
+	t.Folders, err = parseTaskFolders(r.FormValue("folders"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	err = SetTaskSetting(session, t)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -0,0 +1,475 @@
+package main
+
+import (
+	"errors"
+	"html/template"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"gopkg.in/mgo.v2"
+)
+
+// totpIssuer 함수는 인증앱에 표시할 서비스 이름을 반환한다.
+func totpIssuer() string {
+	return strings.Title(*flagCompany) + " CSI"
+}
+
+// signinSession 함수는 로그인에 성공한 사용자의 접속정보를 DB에 기록하고 SSID 쿠키를 저장한다.
+func signinSession(w http.ResponseWriter, r *http.Request, session *mgo.Session, u User) error {
+	// 로그인에 성공하면 접속한 아이피와 포트를 DB에 기록한다.
+	host, port, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return err
+	}
+	u.LastIP = host
+	u.LastPort = port
+	u.PasswordAttempt = 0 // 로그인에 성공하면 기존 시도한 패스워드 횟수를 초기화 한다.
+	err = setUser(session, u)
+	if err != nil {
+		return err
+	}
+	return SetSessionID(w, u.ID, u.AccessLevel, "")
+}
+
+// signinUser 함수는 패스워드 또는 SSO 인증을 마친 사용자를 로그인한다.
+// 2단계 인증이 필요한 사용자는 TOTP 대기 토큰을 저장하고 TOTP 코드 입력 페이지로 이동한다.
+func signinUser(w http.ResponseWriter, r *http.Request, session *mgo.Session, u User) {
+	s, err := GetAdminSetting(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if totpRequired(u, s) {
+		token, err := createTOTPSessionString(u.ID, time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{
+			Name:     totpSessionCookie,
+			Value:    token,
+			Path:     "/",
+			MaxAge:   int(totpSessionAge.Seconds()),
+			HttpOnly: true,
+		})
+		http.Redirect(w, r, "/signin_totp", http.StatusSeeOther)
+		return
+	}
+	// session을 저장후 로그인 성공페이지로 이동한다.
+	err = signinSession(w, r, session, u)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/signin_success", http.StatusSeeOther)
+}
+
+// totpSessionUser 함수는 TOTP 대기 토큰 쿠키에서 사용자 ID를 가지고 온다.
+func totpSessionUser(r *http.Request) (string, error) {
+	c, err := r.Cookie(totpSessionCookie)
+	if err != nil {
+		return "", err
+	}
+	return parseTOTPSessionString(c.Value)
+}
+
+// pendingTOTP 함수는 사용자의 등록중인 TOTP 비밀키와 QR코드를 반환한다. 등록중인 비밀키가 없다면 새로 만든다.
+func pendingTOTP(session *mgo.Session, u User) (string, template.URL, error) {
+	secret := u.TOTPPendingSecret
+	if secret == "" {
+		var err error
+		secret, err = newTOTPSecret()
+		if err != nil {
+			return "", "", err
+		}
+		err = setTOTPPendingSecret(session, u.ID, secret)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	qr, err := totpQRCode(totpURL(totpIssuer(), u.ID, secret))
+	if err != nil {
+		return "", "", err
+	}
+	return secret, qr, nil
+}
+
+// confirmTOTP 함수는 등록중인 TOTP 비밀키로 코드를 확인하고 TOTP를 활성화한다. 새로 만든 복구코드를 반환한다.
+func confirmTOTP(session *mgo.Session, u User, code string) ([]string, error) {
+	if u.TOTPPendingSecret == "" {
+		return nil, errors.New("등록중인 TOTP 비밀키가 없습니다")
+	}
+	counter, ok := totpVerify(u.TOTPPendingSecret, code, time.Now(), 0)
+	if !ok {
+		return nil, errors.New("TOTP 코드가 올바르지 않습니다")
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	err = enableTOTP(session, u.ID, u.TOTPPendingSecret, counter, hashes)
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// verifyTOTP 함수는 TOTP 코드 또는 복구코드를 확인한다. 한번 사용한 코드는 다시 사용할 수 없다.
+func verifyTOTP(session *mgo.Session, u User, code string) error {
+	if !u.TOTPEnabled {
+		return errors.New("TOTP를 등록하지 않은 사용자입니다")
+	}
+	if counter, ok := totpVerify(u.TOTPSecret, code, time.Now(), u.TOTPLastCounter); ok {
+		return useTOTPCounter(session, u.ID, counter)
+	}
+	if after, ok := useRecoveryCode(u.TOTPRecoveryCodes, code); ok {
+		return setTOTPRecoveryCodes(session, u.ID, u.TOTPRecoveryCodes, after)
+	}
+	return errors.New("TOTP 코드가 올바르지 않습니다")
+}
+
+// signinTOTPRecipe 자료구조는 로그인할 때 TOTP 코드를 입력하는 페이지에서 사용하는 자료구조이다.
+type signinTOTPRecipe struct {
+	Company       string
+	ID            string
+	Message       string
+	Enroll        bool // TOTP를 등록하지 않은 사용자라면 QR코드를 보여준다.
+	Secret        string
+	QRCode        template.URL
+	RecoveryCodes []string // 로그인하면서 TOTP를 등록했을 때 한번만 보여주는 복구코드
+}
+
+// handleSigninTOTP 함수는 로그인할 때 TOTP 코드를 입력하는 페이지이다.
+// 2단계 인증이 필요한 AccessLevel이지만 TOTP를 등록하지 않은 사용자는 이 페이지에서 등록한다.
+func handleSigninTOTP(w http.ResponseWriter, r *http.Request) {
+	id, err := totpSessionUser(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	u, err := getUser(session, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if u.PasswordAttempt > 4 {
+		http.Redirect(w, r, "/invalidpass", http.StatusSeeOther)
+		return
+	}
+	rcp := signinTOTPRecipe{}
+	rcp.Company = strings.Title(*flagCompany)
+	rcp.ID = u.ID
+	if r.URL.Query().Get("status") == "wrongcode" {
+		rcp.Message = "코드가 올바르지 않습니다. 다시 입력해주세요."
+	}
+	if !u.TOTPEnabled {
+		rcp.Enroll = true
+		rcp.Secret, rcp.QRCode, err = pendingTOTP(session, u)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	err = TEMPLATES.ExecuteTemplate(w, "signin_totp", rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleSigninTOTPSubmit 함수는 TOTP 코드 또는 복구코드를 확인하고 로그인한다.
+// 코드를 틀리면 패스워드 시도횟수를 추가하여 패스워드를 틀린 것과 같이 5회부터 로그인을 막는다.
+func handleSigninTOTPSubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	id, err := totpSessionUser(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	u, err := getUser(session, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if u.PasswordAttempt > 4 {
+		http.Redirect(w, r, "/invalidpass", http.StatusSeeOther)
+		return
+	}
+	var recoveryCodes []string
+	if u.TOTPEnabled {
+		err = verifyTOTP(session, u, r.FormValue("Code"))
+	} else {
+		recoveryCodes, err = confirmTOTP(session, u, r.FormValue("Code"))
+	}
+	if err != nil {
+		err = addPasswordAttempt(session, id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		http.Redirect(w, r, "/signin_totp?status=wrongcode", http.StatusSeeOther)
+		return
+	}
+	// TOTP 대기 토큰은 한번만 사용한다.
+	http.SetCookie(w, &http.Cookie{Name: totpSessionCookie, Path: "/", MaxAge: -1})
+	// TOTP 정보가 바뀌었으므로 사용자 정보를 다시 가지고 온다.
+	u, err = getUser(session, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	err = signinSession(w, r, session, u)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if recoveryCodes == nil {
+		http.Redirect(w, r, "/signin_success", http.StatusSeeOther)
+		return
+	}
+	// 로그인하면서 TOTP를 등록했다면 복구코드를 한번만 보여준다.
+	rcp := signinTOTPRecipe{}
+	rcp.Company = strings.Title(*flagCompany)
+	rcp.ID = u.ID
+	rcp.RecoveryCodes = recoveryCodes
+	err = TEMPLATES.ExecuteTemplate(w, "signin_totp", rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// totpRecipe 자료구조는 사용자 페이지의 TOTP 등록, 복구코드 페이지에서 사용하는 자료구조이다.
+type totpRecipe struct {
+	User          User
+	Devmode       bool
+	Message       string
+	Secret        string
+	QRCode        template.URL
+	RecoveryCodes []string
+	SearchOption
+}
+
+// newTOTPRecipe 함수는 로그인한 사용자의 totp 페이지 자료구조를 만든다.
+func newTOTPRecipe(session *mgo.Session, r *http.Request, id string) (totpRecipe, error) {
+	rcp := totpRecipe{}
+	rcp.Devmode = *flagDevmode
+	err := rcp.SearchOption.LoadCookie(session, r)
+	if err != nil {
+		return rcp, err
+	}
+	rcp.User, err = getUser(session, id)
+	if err != nil {
+		return rcp, err
+	}
+	return rcp, nil
+}
+
+// handleTOTPEnroll 함수는 로그인한 사용자가 TOTP를 등록하는 페이지이다.
+func handleTOTPEnroll(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel == 0 {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	rcp, err := newTOTPRecipe(session, r, ssid.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if rcp.User.TOTPEnabled {
+		http.Redirect(w, r, "/user?id="+ssid.ID, http.StatusSeeOther)
+		return
+	}
+	rcp.Secret, rcp.QRCode, err = pendingTOTP(session, rcp.User)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	err = TEMPLATES.ExecuteTemplate(w, "totp", rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleTOTPEnrollSubmit 함수는 인증앱의 코드를 확인하여 TOTP를 활성화하고 복구코드를 한번만 보여준다.
+func handleTOTPEnrollSubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel == 0 {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	rcp, err := newTOTPRecipe(session, r, ssid.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if rcp.User.TOTPEnabled {
+		http.Redirect(w, r, "/user?id="+ssid.ID, http.StatusSeeOther)
+		return
+	}
+	rcp.RecoveryCodes, err = confirmTOTP(session, rcp.User, r.FormValue("Code"))
+	if err != nil {
+		// 코드가 틀렸다면 같은 비밀키로 등록 페이지를 다시 보여준다.
+		rcp.Message = err.Error()
+		rcp.Secret, rcp.QRCode, err = pendingTOTP(session, rcp.User)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	err = TEMPLATES.ExecuteTemplate(w, "totp", rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleTOTPRecoveryCodes 함수는 TOTP 코드를 확인하고 복구코드를 새로 발급한다. 기존 복구코드는 사용할 수 없다.
+func handleTOTPRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel == 0 {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	rcp, err := newTOTPRecipe(session, r, ssid.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	err = verifyTOTP(session, rcp.User, r.FormValue("Code"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	err = setTOTPRecoveryCodes(session, ssid.ID, nil, hashes)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.RecoveryCodes = codes
+	err = TEMPLATES.ExecuteTemplate(w, "totp", rcp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// handleTOTPDisable 함수는 TOTP 코드를 확인하고 로그인한 사용자의 TOTP를 해제한다.
+// 2단계 인증이 필요한 AccessLevel의 사용자는 해제할 수 없다. 인증앱을 잃어버렸다면 관리자가 CLI로 초기화한다.
+func handleTOTPDisable(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel == 0 {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	s, err := GetAdminSetting(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	u, err := getUser(session, ssid.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if totpLevelRequired(u.AccessLevel, s) {
+		http.Error(w, "2단계 인증이 필요한 권한입니다. TOTP를 해제할 수 없습니다", http.StatusForbidden)
+		return
+	}
+	err = verifyTOTP(session, u, r.FormValue("Code"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	err = resetTOTP(session, ssid.ID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/user?id="+ssid.ID, http.StatusSeeOther)
+}
@@ -37,9 +37,12 @@ func handleUser(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/html")
 	type recipe struct {
 		User
-		QueryUser User
-		SessionID string
-		Devmode   bool
+		QueryUser   User
+		SessionID   string
+		Devmode     bool
+		APITokens   []APIToken // 개인 토큰. 본인 또는 Admin만 볼 수 있다.
+		Projectlist []string   // 개인 토큰에 설정할 수 있는 프로젝트
+		TOTPLevel   bool       // 2단계 인증이 필수인 AccessLevel
 		SearchOption
 	}
 	rcp := recipe{}
@@ -66,6 +69,25 @@ func handleUser(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if id == ssid.ID || ssid.AccessLevel == AdminAccessLevel {
+		rcp.APITokens, err = UserAPITokens(session, id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		plist, err := Projectlist(session)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		rcp.Projectlist = rcp.QueryUser.AccessibleProjects(plist)
+		setting, err := GetAdminSetting(session)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		rcp.TOTPLevel = totpLevelRequired(rcp.QueryUser.AccessLevel, setting)
+	}
 	err = TEMPLATES.ExecuteTemplate(w, "user", rcp)
 	if err != nil {
 		log.Println(err)
@@ -557,8 +579,6 @@ func handleSignupSubmit(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	// JWT 토큰으로 쿠키를 저장한다.
-	SetSessionID(w, u.ID, u.AccessLevel, "")
 	// 가입이후 처리할 스크립트가 admin setting에 선언되어 있다면, 실행합니다.
 	setting, err := GetAdminSetting(session)
 	if err != nil {
@@ -581,13 +601,8 @@ func handleSignupSubmit(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-
-	// 가입완료 페이지로 이동
-	err = TEMPLATES.ExecuteTemplate(w, "signup_success", u)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	// 가입한 사용자로 로그인한다. 2단계 인증이 필수인 사용자는 인증앱을 등록해야 로그인된다.
+	signinUser(w, r, session, u)
 }
 
 // handleSignin 함수는 로그인 페이지이다.
@@ -597,8 +612,21 @@ func handleSignin(w http.ResponseWriter, r *http.Request) {
 		Company string
 		Message string
 		ID      string
+		OIDC    bool // SSO 로그인 버튼 출력
 	}
 	rcp := recipe{}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	setting, err := GetAdminSetting(session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	rcp.OIDC = setting.OIDC
 	q := r.URL.Query()
 	errorCode := q.Get("status")
 	rcp.ID = q.Get("id")
@@ -621,7 +649,7 @@ func handleSignin(w http.ResponseWriter, r *http.Request) {
 			rcp.ID = c.Value
 		}
 	}
-	err := TEMPLATES.ExecuteTemplate(w, "signin", rcp)
+	err = TEMPLATES.ExecuteTemplate(w, "signin", rcp)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -647,19 +675,19 @@ func handleSigninSubmit(w http.ResponseWriter, r *http.Request) {
 	}
 	defer session.Close()
 	// 사용자가 과거에 패스워드를 5회이상 틀렸다면 로그인을 허용하지 않는다.
+	// LDAP 사용자는 처음 로그인할 때 DB에 등록되므로 아직 DB에 없을 수 있다.
 	u, err := getUser(session, id)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	if u.PasswordAttempt > 4 {
+	if err == nil && u.PasswordAttempt > 4 {
 		http.Redirect(w, r, "/invalidpass", http.StatusSeeOther)
 		return
 	}
 	err = vaildUser(session, id, pw)
 	if err != nil {
-		// 패스워드 시도횟수를 추가한다.
-		addPasswordAttempt(session, id)
+		// 패스워드 시도횟수를 추가한다. 존재하지 않는 사용자라면 에러를 출력한다.
+		if addPasswordAttempt(session, id) != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
 		// 패스워드 시도횟수를 가지고 오기 위해서 사용자 정보를 가지고 온다.
 		u, err := getUser(session, id)
 		if err != nil {
@@ -670,24 +698,13 @@ func handleSigninSubmit(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, fmt.Sprintf("/signin?status=wrongpw&passwordattempt=%d&id=%s", u.PasswordAttempt, id), http.StatusSeeOther)
 		return
 	}
-
-	// 로그인에 성공하면 접속한 아이피와 포트를 DB에 기록한다.
-	host, port, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	u.LastIP = host
-	u.LastPort = port
-	u.PasswordAttempt = 0 // 로그인에 성공하면 기존 시도한 패스워드 횟수를 초기화 한다.
-	err = setUser(session, u)
-	// session을 저장후 로그인 성공페이지로 이동한다.
-	err = SetSessionID(w, u.ID, u.AccessLevel, "")
+	// LDAP 로그인은 사용자 정보를 디렉토리 그룹으로 업데이트하므로 다시 가지고 온다.
+	u, err = getUser(session, id)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	http.Redirect(w, r, "/signin_success", http.StatusSeeOther)
+	signinUser(w, r, session, u)
 }
 
 func handleSigninSuccess(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"gopkg.in/mgo.v2"
+)
+
+// splitFormList 함수는 ,로 구분된 폼 문자열을 공백을 제거한 리스트로 바꾼다.
+func splitFormList(value string) []string {
+	var results []string
+	for _, v := range strings.Split(value, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		results = append(results, v)
+	}
+	return results
+}
+
+// handleAddWebhookSubmit 함수는 프로젝트에 웹훅을 추가한다.
+func handleAddWebhookSubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel != AdminAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	err = r.ParseForm()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	project := r.FormValue("project")
+	hook := Webhook{
+		URL:      strings.TrimSpace(r.FormValue("url")),
+		Secret:   r.FormValue("secret"),
+		Events:   r.PostForm["events"],
+		Tasks:    splitFormList(strings.ToLower(r.FormValue("tasks"))),
+		Statuses: splitFormList(r.FormValue("statuses")),
+		Author:   ssid.ID,
+	}
+	_, err = AddWebhook(session, project, hook)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	http.Redirect(w, r, "/adminsetting?project="+project, http.StatusSeeOther)
+}
+
+// handleRmWebhookSubmit 함수는 프로젝트에서 웹훅을 삭제한다.
+func handleRmWebhookSubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel != AdminAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	project := r.FormValue("project")
+	err = RmWebhook(session, project, r.FormValue("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/adminsetting?project="+project, http.StatusSeeOther)
+}
+
+// handleRetryWebhookSubmit 함수는 실패한 웹훅 전송을 다시 전송대기열에 넣는다.
+func handleRetryWebhookSubmit(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	ssid, err := GetSessionID(r)
+	if err != nil {
+		http.Redirect(w, r, "/signin", http.StatusSeeOther)
+		return
+	}
+	if ssid.AccessLevel != AdminAccessLevel {
+		http.Redirect(w, r, "/invalidaccess", http.StatusSeeOther)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	project := r.FormValue("project")
+	err = RetryDelivery(session, project, r.FormValue("id"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	http.Redirect(w, r, "/adminsetting?project="+project, http.StatusSeeOther)
+}
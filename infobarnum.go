@@ -6,29 +6,72 @@ import (
 
 // Infobarnum 검색결과에 대한 상태별 갯수를 담기위한 자료구조이다.
 type Infobarnum struct {
-	Assign  int
-	Ready   int
-	Wip     int
-	Confirm int
-	Done    int
-	Omit    int
-	Hold    int
-	Out     int
-	None    int
-	Total   int
-	Search  int
-	Shot    int
-	Shot2d  int
-	Shot3d  int
-	Assets  int
-	Percent float64
+	Assign    int
+	Ready     int
+	Wip       int
+	Confirm   int
+	Done      int
+	Omit      int
+	Hold      int
+	Out       int
+	None      int
+	StatusNum map[string]int // 프로젝트 상태ID별 갯수
+	Total     int
+	Search    int
+	Shot      int
+	Shot2d    int
+	Shot3d    int
+	Assets    int
+	Finished  int // 진행률 계산시 완료로 간주한 갯수
+	Workload  int // 진행률 계산시 사용한 전체 갯수
+	Percent   float64
+}
+
+// addStatusNum 메소드는 상태ID에 해당하는 갯수를 더한다.
+// 기본 상태ID는 기존 필드에도 갯수를 더한다.
+func (i *Infobarnum) addStatusNum(id string, num int) {
+	if i.StatusNum == nil {
+		i.StatusNum = make(map[string]int)
+	}
+	i.StatusNum[id] += num
+	switch id {
+	case ASSIGN:
+		i.Assign += num
+	case READY:
+		i.Ready += num
+	case WIP:
+		i.Wip += num
+	case CONFIRM:
+		i.Confirm += num
+	case DONE:
+		i.Done += num
+	case OMIT:
+		i.Omit += num
+	case HOLD:
+		i.Hold += num
+	case OUT:
+		i.Out += num
+	case NONE:
+		i.None += num
+	}
 }
 
 // Percent 메소드는 Infobarnum 자료구조를 분석해서 진행률을 계산한다.
-func (i *Infobarnum) calculatePercent() {
-	if i.Total == 0 {
+// 상태리스트의 Done 상태는 완료로, Excluded 상태는 전체 갯수에서 제외하여 계산한다.
+func (i *Infobarnum) calculatePercent(statuses []Status) {
+	i.Finished = 0
+	i.Workload = i.Total
+	for _, s := range statuses {
+		if s.Done {
+			i.Finished += i.StatusNum[s.ID]
+		}
+		if s.Excluded {
+			i.Workload -= i.StatusNum[s.ID]
+		}
+	}
+	if i.Total == 0 || i.Workload <= 0 {
 		i.Percent = 0.0
 		return
 	}
-	i.Percent = math.Round(float64(i.Done+i.Hold) / float64(i.Total-i.None-i.Omit) * 100)
+	i.Percent = math.Round(float64(i.Finished) / float64(i.Workload) * 100)
 }
@@ -81,7 +81,7 @@ type Item struct {
 	Cut         string          `json:"cut"`         // 시퀀스이름 SS_0010 에서 0010문자에 해당하는값. 에셋이면 "" 문자열이 들어간다.
 	Type        string          `json:"type"`        // org, org1, src, asset..
 	Assettype   string          `json:"assettype"`   // char, env, prop, comp, plant, vehicle, group
-	CrowdAsset  bool            `json:"crowdasset"`  // 군중씬에서 사용하는 에셋인지 여부 체크
+	CrowdAsset  bool            `json:"crowdasset"`  // 군중씬에서 사용하는 에셋인지 여부. 샷의 crowd 의존성으로 설정된다.
 	UseType     string          `json:"usetype"`     // 재스캔상황시 실제로 사용해야하는 타입표기
 	Scantime    string          `json:"scantime"`    // 스캔 등록시간 RFC3339
 	Thumpath    string          `json:"thumpath"`    // 썸네일경로
@@ -114,6 +114,7 @@ type Item struct {
 	References  []Source        `json:"references"`  // 레퍼런스
 	Comments    []Comment       `json:"comments"`    // 수정내용
 	Tasks       map[string]Task `json:"tasks"`       // Task 리스트
+	Uses        []Dependency    `json:"uses"`        // 사용하는 에셋, 샷
 
 	//시간에 관련된 데이터이다.
 	ScanFrame       int                    `json:"scanframe"`       // 스캔 프레임수
@@ -148,6 +149,7 @@ type Task struct {
 	Date         string             `json:"date"`         // 2차 마감일 RFC3339
 	Mov          string             `json:"mov"`          // mov 경로
 	Mdate        string             `json:"mdate"`        // mov 업데이트 날짜 RFC3339
+	Movs         []MovRecord        `json:"movs"`         // mov 등록이력. 오래된 순서이며 마지막 항목이 Mov와 같다.
 	Pubfile      string             `json:"pubfile"`      // Pubfile
 	Due          int                `json:"due"`          // 예측 멘데이
 	Promday      int                `json:"promday"`      // 실제 멘데이
@@ -156,11 +158,32 @@ type Task struct {
 	Version      `json:"version"`   // Pubfile 버전정보
 }
 
+// MovRecord 자료구조는 Task에 등록된 mov 하나의 이력이다.
+type MovRecord struct {
+	Mov     string `json:"mov"`     // mov 경로. 여러개라면 ;로 구분한다.
+	Date    string `json:"date"`    // 등록시간 RFC3339
+	Author  string `json:"author"`  // 등록한 사용자 ID
+	Version int    `json:"version"` // mov 파일명의 버전. 버전이 없다면 0이다.
+	Note    string `json:"note"`    // 등록시 입력한 메모
+}
+
 // updateStatus는 각 팀의 상태를 조합해서 샷 상태를 업데이트하는 함수이다.
-func (item *Item) updateStatus() {
-	maxstatus := "0"
+// 프로젝트 상태리스트의 Order가 가장 큰 상태가 샷 상태가 된다.
+// 상태리스트가 없다면 상태값 문자열을 비교한다.
+func (item *Item) updateStatus(statuses []Status) {
+	order := make(map[string]float64)
+	for _, s := range statuses {
+		order[s.ID] = s.Order
+	}
+	maxstatus := NONE
 	for _, value := range item.Tasks {
-		if value.Status > maxstatus {
+		if len(order) == 0 {
+			if value.Status > maxstatus {
+				maxstatus = value.Status
+			}
+			continue
+		}
+		if _, found := order[maxstatus]; !found || order[value.Status] > order[maxstatus] {
 			maxstatus = value.Status
 		}
 	}
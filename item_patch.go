@@ -0,0 +1,161 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/digital-idea/ditime"
+)
+
+// ReadOnlyItemFields 는 PATCH로 수정할 수 없는 아이템 필드이다.
+// tasks는 /tasks/{task} 경로를 사용해야 상태변경 규칙이 적용되고, uses는 /uses 경로를 사용해야 대상 아이템을 체크한다.
+var ReadOnlyItemFields = []string{"project", "id", "name", "type", "status", "updatetime", "tasks", "uses"}
+
+// ReadOnlyTaskFields 는 PATCH로 수정할 수 없는 Task 필드이다.
+var ReadOnlyTaskFields = []string{"title", "beforestatus"}
+
+// mergePatch 함수는 JSON Merge Patch(RFC 7396) 방식으로 target에 patch를 병합한다.
+// 오브젝트는 키별로 병합하고 null 값은 키를 삭제한다. 나머지 값은 교체한다.
+func mergePatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = make(map[string]interface{})
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergePatch(t[k], v)
+	}
+	return t
+}
+
+// patchStruct 함수는 v를 JSON 오브젝트로 바꾸어 patch를 병합한 뒤 다시 v에 담는다.
+// 존재하지 않는 키나 readonly 키가 있다면 에러를 반환한다.
+func patchStruct(v interface{}, patch map[string]interface{}, readonly []string) error {
+	if len(patch) == 0 {
+		return errors.New("수정할 값이 없습니다")
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	doc := make(map[string]interface{})
+	err = json.Unmarshal(data, &doc)
+	if err != nil {
+		return err
+	}
+	var keys []string
+	for k := range patch {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if _, found := doc[k]; !found {
+			return fmt.Errorf("%s 필드는 존재하지 않습니다", k)
+		}
+		for _, r := range readonly {
+			if k == r {
+				return fmt.Errorf("%s 필드는 수정할 수 없습니다", k)
+			}
+		}
+	}
+	data, err = json.Marshal(mergePatch(doc, patch))
+	if err != nil {
+		return err
+	}
+	// null로 삭제된 필드가 기존값으로 남지 않도록 초기화한 뒤 담는다.
+	rv := reflect.ValueOf(v).Elem()
+	rv.Set(reflect.Zero(rv.Type()))
+	return json.Unmarshal(data, v)
+}
+
+// patchItem 함수는 아이템에 patch를 적용하고 값을 체크한다.
+func patchItem(i Item, patch map[string]interface{}) (Item, error) {
+	err := patchStruct(&i, patch, ReadOnlyItemFields)
+	if err != nil {
+		return i, err
+	}
+	for _, tc := range []string{i.ScanTimecodeIn, i.ScanTimecodeOut, i.JustTimecodeIn, i.JustTimecodeOut} {
+		if !(regexpTimecode.MatchString(tc) || tc == "") {
+			return i, fmt.Errorf("%s 문자열은 00:00:00:00 형식의 문자열이 아닙니다", tc)
+		}
+	}
+	for key, size := range map[string]string{"platesize": i.Platesize, "dsize": i.Dsize, "rendersize": i.Rendersize} {
+		if _, found := patch[key]; found && !(regexpImageSize.MatchString(size) || size == "") {
+			return i, fmt.Errorf("%s 값은 2048x1152 형식이 아닙니다", size)
+		}
+	}
+	if _, found := patch["rnum"]; found && !(regexpRnum.MatchString(i.Rnum) || i.Rnum == "") {
+		return i, fmt.Errorf("%s 값은 A0001 형식이 아닙니다", i.Rnum)
+	}
+	err = checkPatchDates(patch, map[string]string{"ddline2d": i.Ddline2d, "ddline3d": i.Ddline3d, "findate": i.Findate})
+	if err != nil {
+		return i, err
+	}
+	if _, found := patch["shottype"]; found {
+		err = validShottype(i.Shottype)
+		if err != nil {
+			return i, err
+		}
+	}
+	if _, found := patch["assettype"]; found {
+		_, err = validAssettype(i.Assettype)
+		if err != nil {
+			return i, err
+		}
+		i.setAssettags()
+	}
+	if _, found := patch["tag"]; found {
+		for n, tag := range i.Tag {
+			i.Tag[n] = strings.Replace(tag, " ", "", -1) // 태그는 공백을 제거한다.
+		}
+	}
+	return i, nil
+}
+
+// patchTask 함수는 Task에 patch를 적용한다. status 값은 호출하는 곳에서 체크한다.
+func patchTask(t Task, patch map[string]interface{}) (Task, error) {
+	err := patchStruct(&t, patch, ReadOnlyTaskFields)
+	if err != nil {
+		return t, err
+	}
+	err = checkPatchDates(patch, map[string]string{"startdate": t.Startdate, "predate": t.Predate, "date": t.Date, "mdate": t.Mdate})
+	if err != nil {
+		return t, err
+	}
+	return t, nil
+}
+
+// checkPatchDates 함수는 patch로 수정한 날짜 필드가 RFC3339 형식인지 체크한다. 빈 문자열은 날짜를 지우는 것이다.
+func checkPatchDates(patch map[string]interface{}, dates map[string]string) error {
+	for key, date := range dates {
+		if _, found := patch[key]; !found || date == "" {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, date); err != nil {
+			return InvalidDateError(fmt.Sprintf("%s 값 %s 은(는) RFC3339 형식이 아닙니다", key, date))
+		}
+	}
+	return nil
+}
+
+// toFullTime 함수는 사용자가 입력한 날짜를 19시 기준의 RFC3339 문자열로 바꾼다.
+// 형식이 잘못되었다면 InvalidDateError를 반환한다.
+func toFullTime(date string) (string, error) {
+	t, err := ditime.ToFullTime(19, date)
+	if err != nil {
+		return "", InvalidDateError(err.Error())
+	}
+	return t, nil
+}
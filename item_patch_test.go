@@ -0,0 +1,86 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPatchItem(t *testing.T) {
+	i := Item{
+		Name:       "SS_0010",
+		Type:       "org",
+		ID:         "SS_0010_org",
+		PlateIn:    1001,
+		Tag:        []string{"fx"},
+		Note:       Comment{Text: "note", Author: "khw"},
+		Updatetime: "2020-01-01T00:00:00+09:00",
+	}
+	cases := []struct {
+		patch map[string]interface{}
+		want  bool
+	}{
+		{patch: map[string]interface{}{"platein": 1003}, want: true},
+		{patch: map[string]interface{}{"tag": []interface{}{"fx", "bg plate"}}, want: true},
+		{patch: map[string]interface{}{"note": map[string]interface{}{"text": "new"}}, want: true},
+		{patch: map[string]interface{}{"shottype": "2d"}, want: true},
+		{patch: map[string]interface{}{"shottype": "4d"}, want: false},
+		{patch: map[string]interface{}{"scantimecodein": "1:00"}, want: false},
+		{patch: map[string]interface{}{"platesize": "2048x1152"}, want: true},
+		{patch: map[string]interface{}{"rendersize": "2k"}, want: false},
+		{patch: map[string]interface{}{"rnum": "A0001"}, want: true},
+		{patch: map[string]interface{}{"rnum": ""}, want: true},
+		{patch: map[string]interface{}{"rnum": "0001"}, want: false},
+		{patch: map[string]interface{}{"ddline2d": "2020-01-01T19:00:00+09:00"}, want: true},
+		{patch: map[string]interface{}{"ddline2d": "2020-01-01"}, want: false},
+		{patch: map[string]interface{}{"id": "SS_0020_org"}, want: false}, // readonly
+		{patch: map[string]interface{}{"unknown": 1}, want: false},
+		{patch: map[string]interface{}{"platein": "a"}, want: false},
+		{patch: map[string]interface{}{}, want: false},
+	}
+	for _, c := range cases {
+		_, err := patchItem(i, c.patch)
+		if (err == nil) != c.want {
+			t.Fatalf("patchItem(%v): 얻은 값 %v, 원하는 값 %v", c.patch, err, c.want)
+		}
+	}
+	// 부분수정시 요청하지 않은 필드는 유지되어야 한다.
+	patched, err := patchItem(i, map[string]interface{}{
+		"note": map[string]interface{}{"text": "new"},
+		"tag":  []interface{}{"fx", "bg plate"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patched.Note.Text != "new" || patched.Note.Author != "khw" {
+		t.Fatalf("note 병합에 실패했습니다: %v", patched.Note)
+	}
+	if !reflect.DeepEqual(patched.Tag, []string{"fx", "bgplate"}) {
+		t.Fatalf("tag: 얻은 값 %v", patched.Tag)
+	}
+	if patched.PlateIn != 1001 || patched.ID != "SS_0010_org" {
+		t.Fatalf("수정하지 않은 필드가 바뀌었습니다: %v", patched)
+	}
+	// null 값은 필드를 삭제한다.
+	patched, err = patchItem(i, map[string]interface{}{"tag": nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patched.Tag) != 0 {
+		t.Fatalf("tag가 삭제되지 않았습니다: %v", patched.Tag)
+	}
+}
+
+func TestPatchTask(t *testing.T) {
+	task := Task{Title: "comp", User: "khw", Status: "wip"}
+	patched, err := patchTask(task, map[string]interface{}{"user": "kim"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patched.User != "kim" || patched.Status != "wip" || patched.Title != "comp" {
+		t.Fatalf("patchTask: 얻은 값 %v", patched)
+	}
+	_, err = patchTask(task, map[string]interface{}{"title": "fx"})
+	if err == nil {
+		t.Fatal("title 은 수정할 수 없어야 합니다")
+	}
+}
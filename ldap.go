@@ -0,0 +1,97 @@
+package main
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// errLDAPUserNotFound 는 디렉토리에 사용자가 없을 때 반환하는 에러이다. 이 경우 CSI에 등록된 패스워드로 로그인한다.
+var errLDAPUserNotFound = errors.New("디렉토리에 사용자가 존재하지 않습니다")
+
+// ldapUserAttributes 는 사용자를 검색할 때 가지고 오는 LDAP 속성이다. Active Directory와 OpenLDAP 속성을 모두 사용한다.
+var ldapUserAttributes = []string{"mail", "givenName", "sn", "memberOf"}
+
+// ldapFilter 함수는 사용자 검색필터의 {{.ID}}를 사용자 ID로 바꾼다. 특수문자는 이스케이프 처리한다.
+func ldapFilter(filter, id string) string {
+	return strings.Replace(filter, "{{.ID}}", ldap.EscapeFilter(id), -1)
+}
+
+// checkLDAPSetting 함수는 Admin Setting의 LDAP 설정이 올바른지 체크한다.
+func checkLDAPSetting(s Setting) error {
+	if !s.LDAP {
+		return nil
+	}
+	u, err := url.Parse(s.LDAPURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "ldap" && u.Scheme != "ldaps" {
+		return errors.New("LDAP URL은 ldap:// 또는 ldaps:// 로 시작해야 합니다")
+	}
+	if s.LDAPBaseDN == "" {
+		return errors.New("LDAP Base DN이 빈 문자열입니다")
+	}
+	if !strings.Contains(s.LDAPUserFilter, "{{.ID}}") {
+		return errors.New("LDAP 사용자 검색필터에 {{.ID}} 값이 필요합니다")
+	}
+	_, err = ldap.CompileFilter(ldapFilter(s.LDAPUserFilter, "id"))
+	if err != nil {
+		return err
+	}
+	return checkGroupMapping(s.LDAPGroupAccessLevel, s.LDAPGroupOrganization)
+}
+
+// ldapLogin 함수는 디렉토리에서 사용자를 검색하고, 사용자 DN과 패스워드로 바인드하여 인증한다.
+// 검색은 LDAPBindDN 계정으로 하며, LDAPBindDN이 비어있으면 익명으로 검색한다.
+func ldapLogin(s Setting, id, pw string) (externalUser, error) {
+	lu := externalUser{ID: id, Source: "ldap"}
+	// 빈 패스워드는 익명 바인드로 처리되어 인증에 성공하므로 허용하지 않는다.
+	if id == "" || pw == "" {
+		return lu, errors.New("ID 또는 Password 값이 빈 문자열 입니다")
+	}
+	conn, err := ldap.DialURL(s.LDAPURL, ldap.DialWithTLSConfig(&tls.Config{InsecureSkipVerify: s.LDAPInsecureSkipVerify}))
+	if err != nil {
+		return lu, err
+	}
+	defer conn.Close()
+	conn.SetTimeout(10 * time.Second)
+	if s.LDAPBindDN != "" {
+		err = conn.Bind(s.LDAPBindDN, s.LDAPBindPassword)
+		if err != nil {
+			return lu, fmt.Errorf("LDAP 검색 계정으로 바인드할 수 없습니다: %v", err)
+		}
+	}
+	req := ldap.NewSearchRequest(
+		s.LDAPBaseDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, 10, false,
+		ldapFilter(s.LDAPUserFilter, id),
+		ldapUserAttributes,
+		nil,
+	)
+	result, err := conn.Search(req)
+	if err != nil {
+		return lu, err
+	}
+	if len(result.Entries) == 0 {
+		return lu, errLDAPUserNotFound
+	}
+	if len(result.Entries) != 1 {
+		return lu, fmt.Errorf("%s 사용자가 디렉토리에 %d명 검색됩니다", id, len(result.Entries))
+	}
+	e := result.Entries[0]
+	err = conn.Bind(e.DN, pw)
+	if err != nil {
+		return lu, err
+	}
+	lu.Email = e.GetAttributeValue("mail")
+	lu.FirstName = e.GetAttributeValue("givenName")
+	lu.LastName = e.GetAttributeValue("sn")
+	lu.Groups = e.GetAttributeValues("memberOf")
+	return lu, nil
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// ldapTestEntry 자료구조는 테스트 LDAP 서버의 사용자이다.
+type ldapTestEntry struct {
+	DN       string
+	Password string
+	Attrs    map[string][]string
+}
+
+// ldapTestResult 함수는 테스트 LDAP 서버의 응답 패킷을 만든다.
+func ldapTestResult(id int64, tag ber.Tag, code int) *ber.Packet {
+	p := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "MessageID"))
+	r := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Response")
+	r.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "resultCode"))
+	r.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "matchedDN"))
+	r.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "errorMessage"))
+	p.AppendChild(r)
+	return p
+}
+
+// ldapTestSearchEntry 함수는 테스트 LDAP 서버의 검색결과 패킷을 만든다.
+func ldapTestSearchEntry(id int64, e ldapTestEntry) *ber.Packet {
+	p := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	p.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "MessageID"))
+	r := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+	r.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.DN, "objectName"))
+	attrs := ber.NewSequence("attributes")
+	for name, values := range e.Attrs {
+		attr := ber.NewSequence("attribute")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "type"))
+		set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "vals")
+		for _, v := range values {
+			set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "value"))
+		}
+		attr.AppendChild(set)
+		attrs.AppendChild(attr)
+	}
+	r.AppendChild(attrs)
+	p.AppendChild(r)
+	return p
+}
+
+// startLDAPTestServer 함수는 Bind, Search 요청만 처리하는 테스트 LDAP 서버를 실행한다.
+// 검색 필터 문자열이 entries의 키와 같은 사용자를 반환한다.
+func startLDAPTestServer(t *testing.T, service ldapTestEntry, entries map[string]ldapTestEntry) net.Listener {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	passwords := map[string]string{service.DN: service.Password}
+	for _, e := range entries {
+		passwords[e.DN] = e.Password
+	}
+	serve := func(conn net.Conn) {
+		defer conn.Close()
+		for {
+			p, err := ber.ReadPacket(conn)
+			if err != nil || len(p.Children) < 2 {
+				return
+			}
+			id, _ := p.Children[0].Value.(int64)
+			op := p.Children[1]
+			switch op.Tag {
+			case ldap.ApplicationBindRequest:
+				code := ldap.LDAPResultInvalidCredentials
+				pw, found := passwords[op.Children[1].Data.String()]
+				if found && pw != "" && pw == op.Children[2].Data.String() {
+					code = ldap.LDAPResultSuccess
+				}
+				conn.Write(ldapTestResult(id, ldap.ApplicationBindResponse, code).Bytes())
+			case ldap.ApplicationSearchRequest:
+				filter, err := ldap.DecompileFilter(op.Children[6])
+				if err != nil {
+					return
+				}
+				if e, found := entries[filter]; found {
+					conn.Write(ldapTestSearchEntry(id, e).Bytes())
+				}
+				conn.Write(ldapTestResult(id, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess).Bytes())
+			default:
+				return
+			}
+		}
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go serve(conn)
+		}
+	}()
+	return ln
+}
+
+func TestLDAPLogin(t *testing.T) {
+	service := ldapTestEntry{DN: "cn=csi,ou=service,dc=example,dc=com", Password: "service"}
+	alice := ldapTestEntry{
+		DN:       "uid=alice,ou=people,dc=example,dc=com",
+		Password: "alicepw",
+		Attrs: map[string][]string{
+			"mail":      {"alice@example.com"},
+			"givenName": {"Alice"},
+			"sn":        {"Kim"},
+			"memberOf":  {"cn=vfx-comp,ou=groups,dc=example,dc=com", "cn=vfx-lead,ou=groups,dc=example,dc=com"},
+		},
+	}
+	ln := startLDAPTestServer(t, service, map[string]ldapTestEntry{"(uid=alice)": alice})
+	defer ln.Close()
+	s := Setting{
+		LDAP:             true,
+		LDAPURL:          "ldap://" + ln.Addr().String(),
+		LDAPBindDN:       service.DN,
+		LDAPBindPassword: service.Password,
+		LDAPBaseDN:       "ou=people,dc=example,dc=com",
+		LDAPUserFilter:   "(uid={{.ID}})",
+	}
+	if err := checkLDAPSetting(s); err != nil {
+		t.Fatal(err)
+	}
+	lu, err := ldapLogin(s, "alice", "alicepw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := externalUser{
+		ID:        "alice",
+		Source:    "ldap",
+		Email:     "alice@example.com",
+		FirstName: "Alice",
+		LastName:  "Kim",
+		Groups:    alice.Attrs["memberOf"],
+	}
+	if !reflect.DeepEqual(lu, want) {
+		t.Fatalf("얻은 값 %+v 원하는 값 %+v", lu, want)
+	}
+	if _, err := ldapLogin(s, "alice", "wrong"); err == nil || err == errLDAPUserNotFound {
+		t.Fatalf("잘못된 패스워드: %v", err)
+	}
+	// 빈 패스워드는 익명 바인드가 되므로 서버에 요청하지 않고 실패해야 한다.
+	if _, err := ldapLogin(s, "alice", ""); err == nil {
+		t.Fatal("빈 패스워드로 로그인할 수 없어야 합니다")
+	}
+	if _, err := ldapLogin(s, "bob", "bobpw"); err != errLDAPUserNotFound {
+		t.Fatalf("디렉토리에 없는 사용자: %v", err)
+	}
+	// 필터에 사용되는 특수문자는 이스케이프 처리되어 다른 사용자를 검색할 수 없다.
+	if _, err := ldapLogin(s, "*", "alicepw"); err != errLDAPUserNotFound {
+		t.Fatalf("특수문자 ID: %v", err)
+	}
+	s.LDAPBindPassword = "wrong"
+	if _, err := ldapLogin(s, "alice", "alicepw"); err == nil {
+		t.Fatal("검색 계정 패스워드가 틀리면 로그인할 수 없어야 합니다")
+	}
+}
+
+func TestCheckLDAPSetting(t *testing.T) {
+	ok := Setting{LDAP: true, LDAPURL: "ldaps://dc.example.com:636", LDAPBaseDN: "dc=example,dc=com", LDAPUserFilter: "(sAMAccountName={{.ID}})"}
+	if err := checkLDAPSetting(ok); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkLDAPSetting(Setting{}); err != nil {
+		t.Fatal("LDAP을 사용하지 않으면 체크하지 않습니다")
+	}
+	bad := []Setting{ok, ok, ok, ok, ok}
+	bad[0].LDAPURL = "http://dc.example.com"
+	bad[1].LDAPBaseDN = ""
+	bad[2].LDAPUserFilter = "(sAMAccountName=alice)"
+	bad[3].LDAPGroupAccessLevel = "vfx-lead:99"
+	bad[4].LDAPGroupOrganization = "vfx-comp"
+	for i, s := range bad {
+		if err := checkLDAPSetting(s); err == nil {
+			t.Fatalf("%d: 에러가 발생해야 합니다", i)
+		}
+	}
+}
+
+func TestCheckLocalSignin(t *testing.T) {
+	if err := (User{ID: "admin"}).checkLocalSignin(); err != nil {
+		t.Fatal(err)
+	}
+	for _, u := range []User{
+		{ID: "alice", AuthSource: "ldap"},
+		{ID: "bob", AuthSource: "oidc"},
+		{ID: "carol", IsLeave: true},
+	} {
+		if err := u.checkLocalSignin(); err == nil {
+			t.Fatalf("%s: CSI 패스워드로 로그인할 수 없어야 합니다", u.ID)
+		}
+	}
+}
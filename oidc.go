@@ -0,0 +1,309 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+// oidcHTTPClient 는 OIDC Provider에 요청할 때 사용하는 HTTP 클라이언트이다.
+var oidcHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// oidcProvider 자료구조는 OIDC Discovery 문서(/.well-known/openid-configuration)에서 사용하는 값이다.
+type oidcProvider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// oidcRandom 함수는 state, nonce 값으로 사용할 임의의 문자열을 만든다.
+func oidcRandom() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// oidcGetJSON 함수는 OIDC Provider의 JSON 문서를 가지고 온다.
+func oidcGetJSON(u string, v interface{}) error {
+	resp, err := oidcHTTPClient.Get(u)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", u, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// oidcDiscover 함수는 Issuer의 Discovery 문서를 가지고 온다.
+func oidcDiscover(issuer string) (oidcProvider, error) {
+	p := oidcProvider{}
+	err := oidcGetJSON(strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration", &p)
+	if err != nil {
+		return p, err
+	}
+	if strings.TrimSuffix(p.Issuer, "/") != strings.TrimSuffix(issuer, "/") {
+		return p, fmt.Errorf("Discovery 문서의 issuer %s 가 설정된 Issuer %s 와 다릅니다", p.Issuer, issuer)
+	}
+	if p.AuthorizationEndpoint == "" || p.TokenEndpoint == "" || p.JWKSURI == "" {
+		return p, errors.New("Discovery 문서에 authorization_endpoint, token_endpoint, jwks_uri 값이 필요합니다")
+	}
+	return p, nil
+}
+
+// oidcScopes 함수는 요청할 scope를 반환한다. 설정이 비어있으면 openid profile email을 사용하고, openid는 항상 포함한다.
+func oidcScopes(s Setting) string {
+	scopes := strings.Fields(strings.Replace(s.OIDCScopes, ",", " ", -1))
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "profile", "email"}
+	}
+	if !hasString(scopes, "openid") {
+		scopes = append([]string{"openid"}, scopes...)
+	}
+	return strings.Join(scopes, " ")
+}
+
+// oidcAuthURL 함수는 Authorization Code Flow로 로그인할 Provider의 인증 URL을 만든다.
+func oidcAuthURL(s Setting, p oidcProvider, state, nonce string) (string, error) {
+	u, err := url.Parse(p.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", s.OIDCClientID)
+	q.Set("redirect_uri", s.OIDCRedirectURL)
+	q.Set("scope", oidcScopes(s))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// oidcExchange 함수는 인증코드를 Token Endpoint에서 ID 토큰으로 교환한다.
+// 클라이언트 인증은 client_secret_basic 방식을 사용한다.
+func oidcExchange(s Setting, p oidcProvider, code string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", s.OIDCRedirectURL)
+	form.Set("client_id", s.OIDCClientID)
+	req, err := http.NewRequest(http.MethodPost, p.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(s.OIDCClientID), url.QueryEscape(s.OIDCClientSecret))
+	resp, err := oidcHTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	rcp := struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}{}
+	err = json.NewDecoder(resp.Body).Decode(&rcp)
+	if err != nil {
+		return "", fmt.Errorf("Token Endpoint 응답: %s: %v", resp.Status, err)
+	}
+	if rcp.Error != "" {
+		return "", fmt.Errorf("Token Endpoint 에러: %s %s", rcp.Error, rcp.ErrorDescription)
+	}
+	if resp.StatusCode != http.StatusOK || rcp.IDToken == "" {
+		return "", fmt.Errorf("Token Endpoint 응답에 id_token이 없습니다: %s", resp.Status)
+	}
+	return rcp.IDToken, nil
+}
+
+// oidcKeys 함수는 jwks_uri의 RSA 공개키를 kid 별로 가지고 온다.
+func oidcKeys(p oidcProvider) (map[string]*rsa.PublicKey, error) {
+	jwks := struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}{}
+	err := oidcGetJSON(p.JWKSURI, &jwks)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("jwks %s: %v", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("jwks %s: %v", k.Kid, err)
+		}
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("jwks_uri에 사용할 수 있는 RSA 서명키가 없습니다")
+	}
+	return keys, nil
+}
+
+// oidcClaimString 함수는 클레임 값을 문자열로 가지고 온다.
+func oidcClaimString(claims jwt.MapClaims, name string) string {
+	v, _ := claims[name].(string)
+	return v
+}
+
+// oidcClaimList 함수는 배열 또는 문자열 클레임 값을 리스트로 가지고 온다.
+func oidcClaimList(claims jwt.MapClaims, name string) []string {
+	var results []string
+	switch v := claims[name].(type) {
+	case string:
+		if v != "" {
+			results = append(results, v)
+		}
+	case []interface{}:
+		for _, i := range v {
+			if s, ok := i.(string); ok && s != "" {
+				results = append(results, s)
+			}
+		}
+	}
+	return results
+}
+
+// oidcVerify 함수는 ID 토큰의 RS256 서명과 iss, aud, exp, nonce 클레임을 검증하고 클레임을 반환한다.
+func oidcVerify(s Setting, p oidcProvider, rawIDToken, nonce string) (jwt.MapClaims, error) {
+	keys, err := oidcKeys(p)
+	if err != nil {
+		return nil, err
+	}
+	token, err := jwt.Parse(rawIDToken, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("ID 토큰의 서명 알고리즘 %v 는 지원하지 않습니다", t.Header["alg"])
+		}
+		kid, _ := t.Header["kid"].(string)
+		if key, found := keys[kid]; found {
+			return key, nil
+		}
+		return nil, fmt.Errorf("ID 토큰의 서명키 %s 를 jwks_uri에서 찾을 수 없습니다", kid)
+	})
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("ID 토큰이 유효하지 않습니다")
+	}
+	if oidcClaimString(claims, "iss") != p.Issuer {
+		return nil, fmt.Errorf("ID 토큰의 iss %s 가 Issuer와 다릅니다", oidcClaimString(claims, "iss"))
+	}
+	if !hasString(oidcClaimList(claims, "aud"), s.OIDCClientID) {
+		return nil, errors.New("ID 토큰의 aud에 Client ID가 없습니다")
+	}
+	if _, found := claims["exp"]; !found {
+		return nil, errors.New("ID 토큰에 exp 클레임이 없습니다")
+	}
+	if nonce == "" || oidcClaimString(claims, "nonce") != nonce {
+		return nil, errors.New("ID 토큰의 nonce가 다릅니다")
+	}
+	return claims, nil
+}
+
+// oidcUser 함수는 ID 토큰 클레임을 사용자 정보로 바꾼다.
+// 사용자 ID는 OIDCIDClaim 클레임(기본값 preferred_username), 그룹은 OIDCGroupsClaim 클레임(기본값 groups)을 사용한다.
+// 계정 연결에는 바뀔 수 있는 사용자 ID 대신 iss, sub 클레임을 사용한다.
+func oidcUser(s Setting, claims jwt.MapClaims) (externalUser, error) {
+	idClaim := s.OIDCIDClaim
+	if idClaim == "" {
+		idClaim = "preferred_username"
+	}
+	groupsClaim := s.OIDCGroupsClaim
+	if groupsClaim == "" {
+		groupsClaim = "groups"
+	}
+	eu := externalUser{
+		ID:        strings.TrimSpace(oidcClaimString(claims, idClaim)),
+		Source:    "oidc",
+		Email:     oidcClaimString(claims, "email"),
+		FirstName: oidcClaimString(claims, "given_name"),
+		LastName:  oidcClaimString(claims, "family_name"),
+		Groups:    oidcClaimList(claims, groupsClaim),
+		Issuer:    oidcClaimString(claims, "iss"),
+		Subject:   oidcClaimString(claims, "sub"),
+	}
+	if eu.ID == "" {
+		return eu, fmt.Errorf("ID 토큰에 사용자 ID로 사용할 %s 클레임이 없습니다", idClaim)
+	}
+	if eu.Issuer == "" || eu.Subject == "" {
+		return eu, errors.New("ID 토큰에 iss, sub 클레임이 없습니다")
+	}
+	return eu, nil
+}
+
+// oidcLogin 함수는 인증코드를 ID 토큰으로 교환하고 검증하여 사용자 정보를 반환한다.
+func oidcLogin(s Setting, code, nonce string) (externalUser, error) {
+	if code == "" {
+		return externalUser{}, errors.New("인증코드가 없습니다")
+	}
+	p, err := oidcDiscover(s.OIDCIssuer)
+	if err != nil {
+		return externalUser{}, err
+	}
+	rawIDToken, err := oidcExchange(s, p, code)
+	if err != nil {
+		return externalUser{}, err
+	}
+	claims, err := oidcVerify(s, p, rawIDToken, nonce)
+	if err != nil {
+		return externalUser{}, err
+	}
+	return oidcUser(s, claims)
+}
+
+// checkOIDCSetting 함수는 Admin Setting의 OIDC 설정이 올바른지 체크한다.
+func checkOIDCSetting(s Setting) error {
+	if !s.OIDC {
+		return nil
+	}
+	for _, v := range []struct {
+		name  string
+		value string
+	}{
+		{"OIDC Issuer", s.OIDCIssuer},
+		{"OIDC Redirect URL", s.OIDCRedirectURL},
+	} {
+		u, err := url.Parse(v.value)
+		if err != nil {
+			return err
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%s 는 http:// 또는 https:// 로 시작하는 URL이어야 합니다", v.name)
+		}
+	}
+	if s.OIDCClientID == "" {
+		return errors.New("OIDC Client ID가 빈 문자열입니다")
+	}
+	return checkGroupMapping(s.OIDCGroupAccessLevel, s.OIDCGroupOrganization)
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+// oidcTestProvider 자료구조는 테스트용 OIDC Provider이다. 인증코드별로 발급할 ID 토큰 클레임을 가진다.
+type oidcTestProvider struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	secret string
+	codes  map[string]jwt.MapClaims
+}
+
+// newOIDCTestProvider 함수는 Discovery, JWKS, Token Endpoint를 가진 테스트 OIDC Provider를 실행한다.
+func newOIDCTestProvider(t *testing.T) *oidcTestProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &oidcTestProvider{key: key, secret: "secret", codes: make(map[string]jwt.MapClaims)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(oidcProvider{
+			Issuer:                p.server.URL,
+			AuthorizationEndpoint: p.server.URL + "/auth",
+			TokenEndpoint:         p.server.URL + "/token",
+			JWKSURI:               p.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		claims, found := p.codes[r.PostFormValue("code")]
+		switch {
+		case !ok || id != "csi" || secret != p.secret:
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_client"})
+			return
+		case !found || r.PostFormValue("grant_type") != "authorization_code" || r.PostFormValue("redirect_uri") != "https://csi.example.com/oidc_callback":
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "test"
+		s, err := token.SignedString(key)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "access", "token_type": "Bearer", "id_token": s})
+	})
+	p.server = httptest.NewServer(mux)
+	return p
+}
+
+// claims 메소드는 테스트 Provider가 발급하는 기본 ID 토큰 클레임을 만든다.
+func (p *oidcTestProvider) claims(nonce string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":                p.server.URL,
+		"aud":                "csi",
+		"sub":                "248289761001",
+		"exp":                time.Now().Add(time.Hour).Unix(),
+		"iat":                time.Now().Unix(),
+		"nonce":              nonce,
+		"preferred_username": "alice",
+		"email":              "alice@example.com",
+		"given_name":         "Alice",
+		"family_name":        "Kim",
+		"groups":             []string{"vfx-comp", "vfx-lead"},
+	}
+}
+
+func TestOIDCLogin(t *testing.T) {
+	p := newOIDCTestProvider(t)
+	defer p.server.Close()
+	s := Setting{
+		OIDC:             true,
+		OIDCIssuer:       p.server.URL,
+		OIDCClientID:     "csi",
+		OIDCClientSecret: "secret",
+		OIDCRedirectURL:  "https://csi.example.com/oidc_callback",
+	}
+	if err := checkOIDCSetting(s); err != nil {
+		t.Fatal(err)
+	}
+	p.codes["ok"] = p.claims("n0nce")
+	eu, err := oidcLogin(s, "ok", "n0nce")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := externalUser{
+		ID:        "alice",
+		Source:    "oidc",
+		Email:     "alice@example.com",
+		FirstName: "Alice",
+		LastName:  "Kim",
+		Groups:    []string{"vfx-comp", "vfx-lead"},
+		Issuer:    p.server.URL,
+		Subject:   "248289761001",
+	}
+	if !reflect.DeepEqual(eu, want) {
+		t.Fatalf("얻은 값 %+v 원하는 값 %+v", eu, want)
+	}
+	// ID, 그룹 클레임을 바꿀 수 있다.
+	s.OIDCIDClaim = "email"
+	s.OIDCGroupsClaim = "roles"
+	p.codes["roles"] = p.claims("n0nce")
+	p.codes["roles"]["roles"] = "csi-admin"
+	eu, err = oidcLogin(s, "roles", "n0nce")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eu.ID != "alice@example.com" || !reflect.DeepEqual(eu.Groups, []string{"csi-admin"}) {
+		t.Fatalf("클레임 설정: %+v", eu)
+	}
+	s.OIDCIDClaim = ""
+	s.OIDCGroupsClaim = ""
+
+	expired := p.claims("n0nce")
+	expired["exp"] = time.Now().Add(-time.Minute).Unix()
+	otherAud := p.claims("n0nce")
+	otherAud["aud"] = []string{"other"}
+	otherIss := p.claims("n0nce")
+	otherIss["iss"] = "https://evil.example.com"
+	noID := p.claims("n0nce")
+	delete(noID, "preferred_username")
+	noSub := p.claims("n0nce")
+	delete(noSub, "sub")
+	noExp := p.claims("n0nce")
+	delete(noExp, "exp")
+	p.codes["expired"] = expired
+	p.codes["aud"] = otherAud
+	p.codes["iss"] = otherIss
+	p.codes["noid"] = noID
+	p.codes["nosub"] = noSub
+	p.codes["noexp"] = noExp
+	cases := []struct {
+		name  string
+		code  string
+		nonce string
+	}{
+		{"nonce", "ok", "other"},
+		{"빈 nonce", "ok", ""},
+		{"만료", "expired", "n0nce"},
+		{"aud", "aud", "n0nce"},
+		{"iss", "iss", "n0nce"},
+		{"사용자 ID 클레임", "noid", "n0nce"},
+		{"sub 클레임", "nosub", "n0nce"},
+		{"exp 클레임", "noexp", "n0nce"},
+		{"인증코드", "unknown", "n0nce"},
+		{"빈 인증코드", "", "n0nce"},
+	}
+	for _, c := range cases {
+		if _, err := oidcLogin(s, c.code, c.nonce); err == nil {
+			t.Fatalf("%s: 에러가 발생해야 합니다", c.name)
+		}
+	}
+	s.OIDCClientSecret = "wrong"
+	if _, err := oidcLogin(s, "ok", "n0nce"); err == nil {
+		t.Fatal("Client Secret이 틀리면 에러가 발생해야 합니다")
+	}
+}
+
+func TestOIDCVerifySignature(t *testing.T) {
+	p := newOIDCTestProvider(t)
+	defer p.server.Close()
+	s := Setting{OIDCIssuer: p.server.URL, OIDCClientID: "csi"}
+	provider, err := oidcDiscover(s.OIDCIssuer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Provider 키가 아닌 키로 서명한 토큰
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, p.claims("n0nce"))
+	token.Header["kid"] = "test"
+	forged, err := token.SignedString(other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := oidcVerify(s, provider, forged, "n0nce"); err == nil {
+		t.Fatal("다른 키로 서명한 토큰은 에러가 발생해야 합니다")
+	}
+	// HS256 토큰은 허용하지 않는다.
+	hs, err := jwt.NewWithClaims(jwt.SigningMethodHS256, p.claims("n0nce")).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := oidcVerify(s, provider, hs, "n0nce"); err == nil {
+		t.Fatal("HS256 토큰은 에러가 발생해야 합니다")
+	}
+}
+
+func TestOIDCAuthURL(t *testing.T) {
+	s := Setting{OIDCClientID: "csi", OIDCRedirectURL: "https://csi.example.com/oidc_callback", OIDCScopes: "profile,groups"}
+	p := oidcProvider{AuthorizationEndpoint: "https://sso.example.com/auth?prompt=login"}
+	got, err := oidcAuthURL(s, p, "state1", "nonce1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := url.Values{
+		"prompt":        {"login"},
+		"response_type": {"code"},
+		"client_id":     {"csi"},
+		"redirect_uri":  {"https://csi.example.com/oidc_callback"},
+		"scope":         {"openid profile groups"},
+		"state":         {"state1"},
+		"nonce":         {"nonce1"},
+	}
+	if u.Host != "sso.example.com" || u.Path != "/auth" || !reflect.DeepEqual(u.Query(), want) {
+		t.Fatalf("얻은 값 %s", got)
+	}
+	if got := oidcScopes(Setting{}); got != "openid profile email" {
+		t.Fatalf("기본 scope: %s", got)
+	}
+}
+
+func TestCheckOIDCSetting(t *testing.T) {
+	ok := Setting{OIDC: true, OIDCIssuer: "https://sso.example.com", OIDCClientID: "csi", OIDCRedirectURL: "https://csi.example.com/oidc_callback"}
+	if err := checkOIDCSetting(ok); err != nil {
+		t.Fatal(err)
+	}
+	bad := []Setting{ok, ok, ok, ok}
+	bad[0].OIDCIssuer = "sso.example.com"
+	bad[1].OIDCClientID = ""
+	bad[2].OIDCRedirectURL = "/oidc_callback"
+	bad[3].OIDCGroupAccessLevel = "vfx-lead:lead"
+	for i, s := range bad {
+		if err := checkOIDCSetting(s); err == nil {
+			t.Fatalf("%d: 에러가 발생해야 합니다", i)
+		}
+	}
+}
@@ -0,0 +1,387 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// APIRoute 자료구조는 /api 경로 하나의 명세이다.
+// 명세는 /api/openapi.json 으로 제공되며, 핸들러가 읽는 요청 키와 다르면 테스트가 실패한다.
+type APIRoute struct {
+	Path     string      // 경로. {project} 처럼 중괄호로 감싼 부분은 경로 인수이다.
+	Methods  []string    // 허용하는 HTTP 메소드
+	Handler  string      // 핸들러 함수이름
+	Summary  string      // 설명
+	Params   []string    // 요청 키. GET은 query string, 그 외에는 form 또는 JSON 오브젝트로 전달한다.
+	Level    AccessLevel // 필요한 최소 AccessLevel. UnknownAccessLevel 이라면 토큰이 필요없다.
+	Body     string      // JSON Body 자료구조. 예) []BulkOperation
+	Response string      // 응답 data 자료구조. 예) Item, []Item. 빈 문자열이라면 object 이다.
+	Raw      bool        // 공통 응답 형태를 사용하지 않는 경로
+	Stream   bool        // 응답이 text/event-stream 인 경로
+}
+
+// APIRoutes 는 모든 /api 경로의 명세이다. http.go 에 /api 경로를 추가하면 이곳에도 추가해야 한다.
+var APIRoutes = []APIRoute{
+	{Path: "/api/addcomment", Methods: []string{http.MethodPost}, Handler: "handleAPIAddComment", Summary: "아이템에 수정사항을 추가합니다.", Params: []string{"media", "name", "project", "text", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/addplaylist", Methods: []string{http.MethodPost}, Handler: "handleAPIAddPlaylist", Summary: "리뷰 플레이리스트를 추가한다. entries 없이 project, searchword, task, date를 입력하면 검색결과의 mov로 항목을 만든다.", Params: []string{"date", "entries", "name", "project", "reviewers", "searchword", "task"}, Level: LeadAccessLevel, Body: "Playlist", Response: "Playlist"},
+	{Path: "/api/addproject", Methods: []string{http.MethodPost}, Handler: "handleAPIAddproject", Summary: "프로젝트를 추가한다.", Params: []string{"id"}, Level: ClientsAccessLevel, Response: "Project"},
+	{Path: "/api/addreference", Methods: []string{http.MethodPost}, Handler: "handleAPIAddReference", Summary: "아이템에 레퍼런스를 추가합니다.", Params: []string{"name", "path", "project", "title", "url", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/addsource", Methods: []string{http.MethodPost}, Handler: "handleAPIAddSource", Summary: "아이템에 소스를 추가합니다.", Params: []string{"name", "path", "project", "title", "url", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/addtag", Methods: []string{http.MethodPost}, Handler: "handleAPIAddTag", Summary: "아이템에 태그를 설정합니다.", Params: []string{"name", "project", "tag", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/assettasksetting", Methods: []string{http.MethodGet}, Handler: "handleAPIAssetTasksetting", Summary: "Asset Task 항목을 반환한다.", Level: ClientsAccessLevel},
+	{Path: "/api/autocompliteusers", Methods: []string{http.MethodGet}, Handler: "handleAPIAutoCompliteUsers", Summary: "form에서 autocomplite 에 사용되는 사용자 데이터를 반환한다.", Level: ClientsAccessLevel},
+	{Path: "/api/bulk", Methods: []string{http.MethodPost}, Handler: "handleAPIBulk", Summary: "JSON 리스트로 전달된 여러 아이템 수정을 한번에 처리한다.", Body: "[]BulkOperation", Level: ClientsAccessLevel, Response: "[]BulkResult"},
+	{Path: "/api/categorytasksettings", Methods: []string{http.MethodPost}, Handler: "handleAPICategoryTasksettings", Summary: "Category에 해당하는 Task 항목을 반환한다.", Params: []string{"category"}, Level: ClientsAccessLevel},
+	{Path: "/api/deadline2d", Methods: []string{http.MethodPost}, Handler: "handleAPIDeadline2D", Summary: "프로젝트에 사용중인 2D 마감일 리스트를 반환한다.", Params: []string{"project"}, Level: ClientsAccessLevel, Response: "[]string"},
+	{Path: "/api/deadline3d", Methods: []string{http.MethodPost}, Handler: "handleAPIDeadline3D", Summary: "프로젝트에 사용중인 3D 마감일 리스트를 반환한다.", Params: []string{"project"}, Level: ClientsAccessLevel, Response: "[]string"},
+	{Path: "/api/editcomment", Methods: []string{http.MethodPost}, Handler: "handleAPIEditComment", Summary: "아이템에 수정사항을 수정합니다.", Params: []string{"id", "media", "project", "text", "time"}, Level: ClientsAccessLevel},
+	{Path: "/api/exportplaylist", Methods: []string{http.MethodGet}, Handler: "handleAPIExportPlaylist", Summary: "리뷰 플레이리스트를 RV 세션(rv), EDL(edl), 텍스트(txt) 파일로 내려받는다. 토큰이 없다면 로그인 세션을 사용한다.", Params: []string{"format", "fps", "id"}, Level: ClientsAccessLevel, Raw: true},
+	{Path: "/api/folders", Methods: []string{http.MethodGet}, Handler: "handleAPIFolders", Summary: "관리자 설정의 경로 템플릿으로 생성될 폴더 리스트를 반환한다. 폴더는 생성하지 않는다.", Params: []string{"assettype", "name", "project", "type"}, Level: ClientsAccessLevel, Response: "[]Folder"},
+	{Path: "/api/history", Methods: []string{http.MethodGet}, Handler: "handleAPIHistory", Summary: "아이템의 변경이력을 반환한다.", Params: []string{"field", "id", "project"}, Level: ClientsAccessLevel, Response: "[]History"},
+	{Path: "/api/item", Methods: []string{http.MethodGet}, Handler: "handleAPIItem", Summary: "아이템 자료구조를 불러온다.", Params: []string{"id", "project", "slug"}, Level: ClientsAccessLevel, Response: "Item"},
+	{Path: "/api/items", Methods: []string{http.MethodGet}, Handler: "handleAPI2Items", Summary: "아이템을 검색한다.", Params: []string{"assign", "confirm", "done", "hold", "none", "omit", "out", "project", "ready", "searchword", "shot", "sortkey", "truestatus", "type2d", "type3d", "wip"}, Level: ClientsAccessLevel, Response: "[]Item"},
+	{Path: "/api/mailinfo", Methods: []string{http.MethodPost}, Handler: "handleAPIMailInfo", Summary: "Email을 전송할 때 필요한 정보를 가지고 온다.", Params: []string{"id", "project"}, Level: UnknownAccessLevel},
+	{Path: "/api/mkmissingthumbnails", Methods: []string{http.MethodPost}, Handler: "handleAPIMkMissingThumbnails", Summary: "썸네일 파일이 없는 프로젝트 아이템의 썸네일 생성작업을 대기열에 추가한다.", Params: []string{"project"}, Level: LeadAccessLevel},
+	{Path: "/api/mkthumbnail", Methods: []string{http.MethodPost}, Handler: "handleAPIMkThumbnail", Summary: "아이템의 썸네일 생성작업을 대기열에 추가한다.", Params: []string{"id", "project"}, Level: ClientsAccessLevel, Response: "ThumbnailJob"},
+	{Path: "/api/pathinfo", Methods: []string{http.MethodGet}, Handler: "handleAPIPathInfo", Summary: "파일시스템 경로가 속한 프로젝트, 아이템, Task를 반환한다.", Params: []string{"path"}, Level: ClientsAccessLevel, Response: "PathInfo"},
+	{Path: "/api/paths", Methods: []string{http.MethodGet}, Handler: "handleAPIPaths", Summary: "경로 템플릿으로 만든 프로젝트, 아이템, Task 경로를 반환한다.", Params: []string{"id", "name", "project", "task"}, Level: ClientsAccessLevel, Response: "ItemPaths"},
+	{Path: "/api/playlist", Methods: []string{http.MethodGet}, Handler: "handleAPIPlaylist", Summary: "리뷰 플레이리스트를 가지고 온다.", Params: []string{"id"}, Level: ClientsAccessLevel, Response: "Playlist"},
+	{Path: "/api/playlists", Methods: []string{http.MethodGet}, Handler: "handleAPIPlaylists", Summary: "리뷰 플레이리스트 리스트를 최신 날짜부터 가지고 온다.", Params: []string{"date"}, Level: ClientsAccessLevel, Response: "[]Playlist"},
+	{Path: "/api/project", Methods: []string{http.MethodGet}, Handler: "handleAPIProject", Summary: "프로젝트 정보를 불러온다.", Params: []string{"id"}, Level: ClientsAccessLevel, Response: "Project"},
+	{Path: "/api/projects", Methods: []string{http.MethodGet}, Handler: "handleAPIProjects", Summary: "프로젝트 리스트를 반환한다.", Params: []string{"status"}, Level: ClientsAccessLevel, Response: "[]string"},
+	{Path: "/api/projecttags", Methods: []string{http.MethodGet}, Handler: "handleAPIProjectTags", Summary: "프로젝트에 사용되는 태그리스트를 불러온다.", Params: []string{"project"}, Level: ClientsAccessLevel, Response: "[]string"},
+	{Path: "/api/renametag", Methods: []string{http.MethodPost}, Handler: "handleAPIRenameTag", Summary: "아이템의 태그를 변경합니다.", Params: []string{"after", "before", "project", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/revertitem", Methods: []string{http.MethodPost}, Handler: "handleAPIRevertItem", Summary: "아이템의 필드를 입력된 시간의 스냅샷으로 되돌린다.", Params: []string{"field", "fields", "id", "project", "time"}, Level: SupervisorAccessLevel},
+	{Path: "/api/rmcomment", Methods: []string{http.MethodPost}, Handler: "handleAPIRmComment", Summary: "아이템에서 수정사항을 삭제합니다.", Params: []string{"date", "name", "project", "text", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/rmitem", Methods: []string{http.MethodPost}, Handler: "handleAPIRmItem", Summary: "아이템을 삭제한다.", Params: []string{"name", "project", "type"}, Level: ClientsAccessLevel},
+	{Path: "/api/rmitemid", Methods: []string{http.MethodPost}, Handler: "handleAPIRmItemID", Summary: "아이템을 삭제한다.", Params: []string{"id", "project"}, Level: PmAccessLevel},
+	{Path: "/api/rmplaylist", Methods: []string{http.MethodPost}, Handler: "handleAPIRmPlaylist", Summary: "리뷰 플레이리스트를 삭제한다.", Params: []string{"id"}, Level: LeadAccessLevel},
+	{Path: "/api/rmreference", Methods: []string{http.MethodPost}, Handler: "handleAPIRmReference", Summary: "아이템에서 레퍼런스를 삭제합니다.", Params: []string{"name", "project", "title", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/rmsource", Methods: []string{http.MethodPost}, Handler: "handleAPIRmSource", Summary: "아이템에서 링크소스를 삭제합니다.", Params: []string{"name", "project", "title", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/rmtag", Methods: []string{http.MethodPost}, Handler: "handleAPIRmTag", Summary: "아이템에 태그를 삭제합니다.", Params: []string{"name", "project", "tag", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/rmtask", Methods: []string{http.MethodPost}, Handler: "handleAPIRmTask", Summary: "아이템의 task를 제거한다.", Params: []string{"id", "project", "task"}, Level: ClientsAccessLevel},
+	{Path: "/api/search", Methods: []string{http.MethodPost}, Handler: "handleAPISearch", Summary: "아이템을 검색합니다. format을 지정하면 검색결과를 컨택시트 파일로 내보냅니다.", Params: []string{"columns", "format", "layout", "page", "project", "searchword", "sort", "sortkey", "title", "word"}, Level: ClientsAccessLevel, Response: "[]Item"},
+	{Path: "/api/searchname", Methods: []string{http.MethodGet}, Handler: "handleAPISearchname", Summary: "입력 문자열을 포함하는 샷,에셋 정보를 검색한다.", Params: []string{"name", "project"}, Level: ClientsAccessLevel, Response: "[]Item"},
+	{Path: "/api/seqs", Methods: []string{http.MethodGet}, Handler: "handleAPISeqs", Summary: "프로젝트의 시퀀스를 가져온다.", Params: []string{"project"}, Level: ClientsAccessLevel, Response: "[]string"},
+	{Path: "/api/setaftermov", Methods: []string{http.MethodPost}, Handler: "handleAPISetAftermov", Summary: "아이템의 After mov 값을 설정한다.", Params: []string{"name", "path", "project", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setassettype", Methods: []string{http.MethodPost}, Handler: "handleAPISetAssetType", Summary: "아이템의 asset type을 설정한다.", Params: []string{"assettype", "name", "project", "type", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setassigntask", Methods: []string{http.MethodPost}, Handler: "handleAPISetAssignTask", Summary: "아이템의 task에 대한 Assign 상태를 설정한다.", Params: []string{"name", "project", "status", "task", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setbeforemov", Methods: []string{http.MethodPost}, Handler: "handleAPISetBeforemov", Summary: "아이템의 Before mov 값을 설정한다.", Params: []string{"name", "path", "project", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setcameraprojection", Methods: []string{http.MethodPost}, Handler: "handleAPISetCameraProjection", Summary: "아이템의 Camera Projection 여부를 설정한다.", Params: []string{"name", "project", "projection", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setcamerapubpath", Methods: []string{http.MethodPost}, Handler: "handleAPISetCameraPubPath", Summary: "아이템의 Camera PubPath를 설정한다.", Params: []string{"name", "path", "project", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setcamerapubtask", Methods: []string{http.MethodPost}, Handler: "handleAPISetCameraPubTask", Summary: "아이템의 Camera PubTask를 설정한다.", Params: []string{"name", "project", "task", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setcrowdasset", Methods: []string{http.MethodPost}, Handler: "handleAPISetCrowdAsset", Summary: "CrowdAsset을 설정한다.", Params: []string{"name", "project"}, Level: ClientsAccessLevel},
+	{Path: "/api/setdeadline2d", Methods: []string{http.MethodPost}, Handler: "handleAPISetDeadline2D", Summary: "아이템의 2D 마감일을 설정한다.", Params: []string{"date", "name", "project", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setdeadline3d", Methods: []string{http.MethodPost}, Handler: "handleAPISetDeadline3D", Summary: "아이템의 3D 마감일을 설정한다.", Params: []string{"date", "name", "project", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setellite", Methods: []string{http.MethodGet}, Handler: "handleAPISetelliteItems", Summary: "project, rollmedia을 받아서 setellite 정보를 반환한다.", Params: []string{"project", "rollmedia"}, Level: ClientsAccessLevel, Response: "[]Setellite"},
+	{Path: "/api/setellitesearch", Methods: []string{http.MethodGet}, Handler: "handleAPISetelliteSearch", Summary: "project, searchword의 검색어를 통해 setellite 정보를 반환한다.", Params: []string{"project", "searchword"}, Level: ClientsAccessLevel, Response: "[]Setellite"},
+	{Path: "/api/setfindate", Methods: []string{http.MethodPost}, Handler: "handleAPISetFindate", Summary: "데이터가 최종으로 나간 날짜를 설정한다.", Params: []string{"date", "name", "project"}, Level: ClientsAccessLevel},
+	{Path: "/api/setfinver", Methods: []string{http.MethodPost}, Handler: "handleAPISetFinver", Summary: "아이템에 파이널 버전값을 설정한다.", Params: []string{"name", "project", "userid", "version"}, Level: ClientsAccessLevel},
+	{Path: "/api/sethandlein", Methods: []string{http.MethodPost}, Handler: "handleAPISetFrame", Summary: "아이템에 Handle In 값을 설정한다.", Params: []string{"frame", "name", "project", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/sethandleout", Methods: []string{http.MethodPost}, Handler: "handleAPISetFrame", Summary: "아이템에 Handle Out 값을 설정한다.", Params: []string{"frame", "name", "project", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setjustin", Methods: []string{http.MethodPost}, Handler: "handleAPISetFrame", Summary: "아이템에 Just In 값을 설정한다.", Params: []string{"frame", "name", "project", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setjustout", Methods: []string{http.MethodPost}, Handler: "handleAPISetFrame", Summary: "아이템에 Just Out 값을 설정한다.", Params: []string{"frame", "name", "project", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setjusttimecodein", Methods: []string{http.MethodPost}, Handler: "handleAPISetJustTimecodeIn", Summary: "아이템에 Just TimecodeIn 값을 설정한다.", Params: []string{"name", "project", "timecode", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setjusttimecodeout", Methods: []string{http.MethodPost}, Handler: "handleAPISetJustTimecodeOut", Summary: "아이템에 Just TimecodeOut 값을 설정한다.", Params: []string{"name", "project", "timecode", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setleaveuser", Methods: []string{http.MethodPost}, Handler: "handleAPISetLeaveUser", Summary: "사용자의 퇴사여부를 셋팅하는 핸들러 입니다.", Params: []string{"id", "leave"}, Level: ClientsAccessLevel},
+	{Path: "/api/setmov", Methods: []string{http.MethodPost}, Handler: "handleAPISetTaskMov", Summary: "Task에 mov를 설정하고 mov 등록이력에 추가한다.", Params: []string{"asset", "mov", "name", "note", "project", "shot", "task", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setnote", Methods: []string{http.MethodPost}, Handler: "handleAPISetNote", Summary: "아이템에 작업내용을 설정합니다.", Params: []string{"id", "overwrite", "project", "text", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setobjectid", Methods: []string{http.MethodPost}, Handler: "handleAPISetObjectID", Summary: "아이템의 ObjectID 값을 설정한다.", Params: []string{"in", "name", "out", "project", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setociocc", Methods: []string{http.MethodPost}, Handler: "handleAPISetOCIOcc", Summary: "아이템의 OCIO .cc 파일을 설정합니다.", Params: []string{"cc", "name", "ocio", "ociocc", "path", "project", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setoutputname", Methods: []string{http.MethodPost}, Handler: "handleAPISetOutputName", Summary: "아이템의 shot의 아웃풋 이름을 설정합니다.", Params: []string{"name", "outputname", "project"}, Level: ClientsAccessLevel},
+	{Path: "/api/setplatein", Methods: []string{http.MethodPost}, Handler: "handleAPISetFrame", Summary: "아이템에 Plate In 값을 설정한다.", Params: []string{"frame", "name", "project", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setplateout", Methods: []string{http.MethodPost}, Handler: "handleAPISetFrame", Summary: "아이템에 Plate Out 값을 설정한다.", Params: []string{"frame", "name", "project", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setplatesize", Methods: []string{http.MethodPost}, Handler: "handleAPISetPlateSize", Summary: "아이템의 PlateSize를 설정한다.", Params: []string{"name", "project", "size", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setplaylist", Methods: []string{http.MethodPost}, Handler: "handleAPISetPlaylist", Summary: "리뷰 플레이리스트를 수정한다. 입력한 키만 수정하며 entries는 입력한 순서로 교체한다.", Params: []string{"date", "entries", "id", "name", "reviewers"}, Level: LeadAccessLevel, Body: "Playlist", Response: "Playlist"},
+	{Path: "/api/setrendersize", Methods: []string{http.MethodPost}, Handler: "handleAPISetRenderSize", Summary: "아이템에 RenderSize를 설정한다.", Params: []string{"name", "project", "size", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setretimeplate", Methods: []string{http.MethodPost}, Handler: "handleAPISetRetimePlate", Summary: "아이템의 retimeplate 값을 설정합니다.", Params: []string{"name", "path", "plate", "project", "retimeplate", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setrnum", Methods: []string{http.MethodPost}, Handler: "handleAPISetRnum", Summary: "아이템에 롤넘버를 설정합니다.", Params: []string{"name", "project", "rnum", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setrollmedia", Methods: []string{http.MethodPost}, Handler: "handleAPISetRollmedia", Summary: "아이템의 Setellite Rollmedia를 설정합니다.", Params: []string{"name", "project", "rollmedia", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setscanframe", Methods: []string{http.MethodPost}, Handler: "handleAPISetFrame", Summary: "아이템에 Scan Frame 값을 설정한다.", Params: []string{"frame", "name", "project", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setscanin", Methods: []string{http.MethodPost}, Handler: "handleAPISetFrame", Summary: "아이템에 Scan In 값을 설정한다.", Params: []string{"frame", "name", "project", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setscanout", Methods: []string{http.MethodPost}, Handler: "handleAPISetFrame", Summary: "아이템에 Scan Out 값을 설정한다.", Params: []string{"frame", "name", "project", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setscantimecodein", Methods: []string{http.MethodPost}, Handler: "handleAPISetScanTimecodeIn", Summary: "아이템에 Scan TimecodeIn 값을 설정한다.", Params: []string{"name", "project", "timecode", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setscantimecodeout", Methods: []string{http.MethodPost}, Handler: "handleAPISetScanTimecodeOut", Summary: "아이템에 Scan TimecodeOut 값을 설정한다.", Params: []string{"name", "project", "timecode", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setshottype", Methods: []string{http.MethodPost}, Handler: "handleAPISetShotType", Summary: "아이템의 shot type을 설정한다.", Params: []string{"name", "project", "shottype", "type", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setstartdate", Methods: []string{http.MethodPost}, Handler: "handleAPISetTaskStartdate", Summary: "아이템의 task에 대한 시작일을 설정한다.", Params: []string{"date", "name", "project", "task", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setstatus", Methods: []string{http.MethodPost}, Handler: "handleAPISetTaskStatus", Summary: "아이템의 task에 대한 상태를 설정한다. 프로젝트의 상태변경 규칙에 필요한 AccessLevel이 적용된다.", Params: []string{"name", "project", "status", "task", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/settags", Methods: []string{http.MethodPost}, Handler: "handleAPISetTags", Summary: "아이템에 태그를 교체합니다.", Params: []string{"name", "project", "tag", "tags"}, Level: ClientsAccessLevel},
+	{Path: "/api/settaskdate", Methods: []string{http.MethodPost}, Handler: "handleAPISetTaskDate", Summary: "아이템의 task에 대한 최종마감일을 설정한다.", Params: []string{"date", "name", "project", "task", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/settaskdue", Methods: []string{http.MethodPost}, Handler: "handleAPISetTaskDue", Summary: "Task에 마감일을 설정한다.", Params: []string{"due", "name", "project", "task", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/settasklevel", Methods: []string{http.MethodPost}, Handler: "handleAPISetTaskLevel", Summary: "Task에 level을 설정한다.", Params: []string{"level", "name", "project", "task", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/settaskmov", Methods: []string{http.MethodPost}, Handler: "handleAPISetTaskMov", Summary: "Task에 mov를 설정하고 mov 등록이력에 추가한다.", Params: []string{"asset", "mov", "name", "note", "project", "shot", "task", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/settaskpredate", Methods: []string{http.MethodPost}, Handler: "handleAPISetTaskPredate", Summary: "아이템의 task에 대한 1차마감일을 설정한다.", Params: []string{"date", "name", "project", "task", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/settaskstartdate", Methods: []string{http.MethodPost}, Handler: "handleAPISetTaskStartdate", Summary: "아이템의 task에 대한 시작일을 설정한다.", Params: []string{"date", "name", "project", "task", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/settaskstatus", Methods: []string{http.MethodPost}, Handler: "handleAPISetTaskStatus", Summary: "아이템의 task에 대한 상태를 설정한다. 프로젝트의 상태변경 규칙에 필요한 AccessLevel이 적용된다.", Params: []string{"name", "project", "status", "task", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/settaskuser", Methods: []string{http.MethodPost}, Handler: "handleAPISetTaskUser", Summary: "아이템의 task에 대한 유저를 설정한다.", Params: []string{"name", "project", "task", "user", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/settaskusernote", Methods: []string{http.MethodPost}, Handler: "handleAPISetTaskUserNote", Summary: "아이템의 task에 대한 사용자 노트를 설정한다.", Params: []string{"name", "note", "project", "task", "userid", "usernote"}, Level: ClientsAccessLevel},
+	{Path: "/api/setthummov", Methods: []string{http.MethodPost}, Handler: "handleAPISetThummov", Summary: "아이템의 Thummov 값을 설정한다.", Params: []string{"name", "path", "project", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/setundistortionsize", Methods: []string{http.MethodPost}, Handler: "handleAPISetUnDistortionSize", Summary: "아이템의 DistortionSize를 설정한다.", Params: []string{"name", "project", "size", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/shot", Methods: []string{http.MethodGet}, Handler: "handleAPIShot", Summary: "project, name을 받아서 shot을 반환한다.", Params: []string{"name", "project"}, Level: ClientsAccessLevel, Response: "Item"},
+	{Path: "/api/shots", Methods: []string{http.MethodGet}, Handler: "handleAPIShots", Summary: "project, seq를 입력받아서 샷정보를 출력한다.", Params: []string{"project", "seq"}, Level: ClientsAccessLevel, Response: "[]string"},
+	{Path: "/api/shottasksetting", Methods: []string{http.MethodGet}, Handler: "handleAPIShotTasksetting", Summary: "Shot Task 항목을 반환한다.", Level: ClientsAccessLevel},
+	{Path: "/api/shottype", Methods: []string{http.MethodPost}, Handler: "handleAPIShottype", Summary: "Shottype 정보를 가지고온다.", Params: []string{"name", "project"}, Level: ClientsAccessLevel},
+	{Path: "/api/snapshots", Methods: []string{http.MethodGet}, Handler: "handleAPISnapshots", Summary: "아이템의 스냅샷 리스트를 반환한다.", Params: []string{"id", "project"}, Level: ClientsAccessLevel, Response: "[]Snapshot"},
+	{Path: "/api/statuses", Methods: []string{http.MethodGet}, Handler: "handleAPIStatuses", Summary: "프로젝트에 설정된 상태리스트를 반환한다.", Params: []string{"project"}, Level: ClientsAccessLevel, Response: "[]Status"},
+	{Path: "/api/task", Methods: []string{http.MethodPost}, Handler: "handleAPITask", Summary: "Task정보를 가지고온다.", Params: []string{"name", "project", "task", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/taskmovs", Methods: []string{http.MethodGet}, Handler: "handleAPITaskMovs", Summary: "Task의 mov 등록이력을 최신 순서로 반환한다.", Params: []string{"name", "project", "task"}, Level: ClientsAccessLevel, Response: "[]MovRecord"},
+	{Path: "/api/taskpath", Methods: []string{http.MethodGet}, Handler: "handleAPITaskPath", Summary: "Tasksetting 경로 템플릿으로 아이템 Task의 OS별 경로를 반환한다.", Params: []string{"id", "name", "project", "task"}, Level: ClientsAccessLevel, Response: "TaskPath"},
+	{Path: "/api/tasksetting", Methods: []string{http.MethodPost}, Handler: "handleAPITasksetting", Summary: "Task에 설정된 경로정보를 반환한다.", Params: []string{"assettype", "cut", "name", "os", "project", "seq", "task", "type", "userid"}, Level: ClientsAccessLevel},
+	{Path: "/api/teams", Methods: []string{http.MethodGet}, Handler: "handleAPIAllTeams", Summary: "모든 팀 조직정보를 반환한다.", Level: ClientsAccessLevel, Response: "[]Team"},
+	{Path: "/api/thumbnailjobs", Methods: []string{http.MethodGet}, Handler: "handleAPIThumbnailJobs", Summary: "프로젝트의 썸네일 작업을 최신순으로 반환한다.", Params: []string{"limit", "project"}, Level: ClientsAccessLevel, Response: "[]ThumbnailJob"},
+	{Path: "/api/timeinfo", Methods: []string{http.MethodPost}, Handler: "handleAPITimeinfo", Summary: "아이템의 시간정보를 불러온다.", Params: []string{"id", "project"}, Level: ClientsAccessLevel},
+	{Path: "/api/transitions", Methods: []string{http.MethodGet}, Handler: "handleAPITransitions", Summary: "프로젝트에 설정된 상태변경 규칙을 반환한다.", Params: []string{"project"}, Level: ClientsAccessLevel, Response: "[]Transition"},
+	{Path: "/api/user", Methods: []string{http.MethodGet}, Handler: "handleAPIUser", Summary: "사용자의 id를 받아서 사용자 정보를 반환한다.", Params: []string{"id"}, Level: ClientsAccessLevel, Response: "User"},
+	{Path: "/api/users", Methods: []string{http.MethodGet}, Handler: "handleAPISearchUser", Summary: "단어를 받아서 조건에 맞는 사용자 정보를 반환한다.", Params: []string{"searchword"}, Level: ClientsAccessLevel, Response: "[]User"},
+	{Path: "/api/validuser", Methods: []string{http.MethodPost}, Handler: "handleAPIValidUser", Summary: "사용자가 유효한지 체크하는 핸들러 입니다.", Params: []string{"id", "pw"}, Level: ClientsAccessLevel},
+	{Path: "/api2/items", Methods: []string{http.MethodGet}, Handler: "handleAPI2Items", Summary: "아이템을 검색한다.", Params: []string{"assign", "confirm", "done", "hold", "none", "omit", "out", "project", "ready", "searchword", "shot", "sortkey", "truestatus", "type2d", "type3d", "wip"}, Level: ClientsAccessLevel, Response: "[]Item"},
+	{Path: "/api/events", Methods: []string{http.MethodGet}, Handler: "handleAPIEvents", Summary: "아이템이 추가, 수정, 삭제될 때 ItemEvent를 Server-Sent Events로 전송한다. 토큰이 없다면 로그인 세션을 사용한다.", Params: []string{"id", "project"}, Level: ClientsAccessLevel, Response: "ItemEvent", Raw: true, Stream: true},
+	{Path: "/api/openapi.json", Methods: []string{http.MethodGet}, Handler: "handleAPIOpenAPI", Summary: "/api 명세를 OpenAPI 3.0 형식으로 반환한다.", Level: UnknownAccessLevel, Raw: true},
+	{Path: "/api/v3/projects/{project}/items/{id}", Methods: []string{http.MethodGet, http.MethodPatch}, Handler: "handleAPIv3", Summary: "아이템을 가지고 오거나 필드를 부분수정(JSON Merge Patch)한다.", Level: ClientsAccessLevel, Body: "Item", Response: "Item"},
+	{Path: "/api/v3/projects/{project}/items/{id}/tasks", Methods: []string{http.MethodGet}, Handler: "handleAPIv3", Summary: "아이템의 Task 리스트를 가지고 온다.", Level: ClientsAccessLevel, Response: "map[string]Task"},
+	{Path: "/api/v3/projects/{project}/items/{id}/tasks/{task}", Methods: []string{http.MethodGet, http.MethodPatch}, Handler: "handleAPIv3", Summary: "Task를 가지고 오거나 부분수정한다. status는 상태변경 규칙이 적용된다.", Level: ClientsAccessLevel, Body: "Task", Response: "Task"},
+	{Path: "/api/v3/projects/{project}/items/{id}/comments", Methods: []string{http.MethodGet, http.MethodPost}, Handler: "handleAPIv3", Summary: "코멘트 리스트를 가지고 오거나 코멘트를 추가한다.", Params: []string{"media", "text"}, Level: ClientsAccessLevel, Response: "[]Comment"},
+	{Path: "/api/v3/projects/{project}/items/{id}/comments/{date}", Methods: []string{http.MethodPatch, http.MethodDelete}, Handler: "handleAPIv3", Summary: "코멘트를 수정하거나 삭제한다.", Params: []string{"media", "text"}, Level: ClientsAccessLevel, Response: "[]Comment"},
+	{Path: "/api/v3/projects/{project}/items/{id}/tags", Methods: []string{http.MethodGet, http.MethodPost, http.MethodPut}, Handler: "handleAPIv3", Summary: "태그 리스트를 가지고 오거나, 태그를 추가(POST tag)하거나 교체(PUT tags)한다.", Params: []string{"tag", "tags"}, Level: ClientsAccessLevel, Response: "[]string"},
+	{Path: "/api/v3/projects/{project}/items/{id}/tags/{tag}", Methods: []string{http.MethodDelete}, Handler: "handleAPIv3", Summary: "태그를 삭제한다.", Level: ClientsAccessLevel, Response: "[]string"},
+	{Path: "/api/v3/projects/{project}/items/{id}/uses", Methods: []string{http.MethodGet, http.MethodPost}, Handler: "handleAPIv3", Summary: "사용하는 에셋, 샷 리스트를 가지고 오거나 의존성을 추가한다. kind는 asset, crowd, shot 이다.", Params: []string{"kind", "target"}, Level: ClientsAccessLevel, Response: "[]Dependency"},
+	{Path: "/api/v3/projects/{project}/items/{id}/uses/{target}", Methods: []string{http.MethodDelete}, Handler: "handleAPIv3", Summary: "의존성을 제거한다.", Level: ClientsAccessLevel, Response: "[]Dependency"},
+	{Path: "/api/v3/projects/{project}/items/{id}/usedin", Methods: []string{http.MethodGet}, Handler: "handleAPIv3", Summary: "아이템을 사용하는 아이템 리스트를 가지고 온다.", Level: ClientsAccessLevel, Response: "[]Dependency"},
+	{Path: "/api/v3/projects/{project}/items/{id}/publishes", Methods: []string{http.MethodGet, http.MethodPost}, Handler: "handleAPIv3", Summary: "퍼블리시 리스트를 최신 버전부터 가지고 오거나(GET task) 퍼블리시를 등록한다. 등록하면 Task의 version, pubfile이 설정된다.", Params: []string{"colorspace", "comment", "files", "framein", "frameout", "task", "uses", "version"}, Level: ClientsAccessLevel, Response: "[]Publish"},
+	{Path: "/api/v3/projects/{project}/items/{id}/publishes/latest", Methods: []string{http.MethodGet}, Handler: "handleAPIv3", Summary: "Task의 가장 높은 버전의 퍼블리시를 가지고 온다. approved=true 이면 승인된 퍼블리시 중에서 찾는다.", Params: []string{"approved", "task"}, Level: ClientsAccessLevel, Response: "Publish"},
+	{Path: "/api/v3/projects/{project}/items/{id}/publishes/{publish}", Methods: []string{http.MethodGet, http.MethodPatch, http.MethodDelete}, Handler: "handleAPIv3", Summary: "퍼블리시를 가지고 오거나, 승인(PATCH approved, Supervisor 이상)하거나, 삭제(PM 이상)한다.", Params: []string{"approved"}, Level: ClientsAccessLevel, Response: "Publish"},
+}
+
+// APISchemas 는 /api 명세에서 사용하는 자료구조이다.
+var APISchemas = map[string]interface{}{
+	"Item":          Item{},
+	"Task":          Task{},
+	"MovRecord":     MovRecord{},
+	"Comment":       Comment{},
+	"Dependency":    Dependency{},
+	"Folder":        Folder{},
+	"Publish":       Publish{},
+	"Version":       Version{},
+	"ItemPaths":     ItemPaths{},
+	"PathInfo":      PathInfo{},
+	"Playlist":      Playlist{},
+	"PlaylistEntry": PlaylistEntry{},
+	"Source":        Source{},
+	"Project":       Project{},
+	"User":          User{},
+	"Team":          Team{},
+	"Tasksetting":   Tasksetting{},
+	"TaskFolder":    TaskFolder{},
+	"TaskPath":      TaskPath{},
+	"Setellite":     Setellite{},
+	"Status":        Status{},
+	"Transition":    Transition{},
+	"History":       History{},
+	"Snapshot":      Snapshot{},
+	"BulkOperation": BulkOperation{},
+	"BulkResult":    BulkResult{},
+	"ItemEvent":     ItemEvent{},
+	"ThumbnailJob":  ThumbnailJob{},
+	"APIError":      APIError{},
+}
+
+// regexpPathParam 은 경로 인수 {project} 를 찾을 때 사용한다.
+var regexpPathParam = regexp.MustCompile(`{(\w+)}`)
+
+// schemaRef 함수는 Response, Body 에 사용하는 자료구조 이름을 스키마로 바꾼다.
+func schemaRef(name string) map[string]interface{} {
+	switch {
+	case name == "":
+		return map[string]interface{}{"type": "object"}
+	case strings.HasPrefix(name, "[]"):
+		return map[string]interface{}{"type": "array", "items": schemaRef(strings.TrimPrefix(name, "[]"))}
+	case strings.HasPrefix(name, "map[string]"):
+		return map[string]interface{}{"type": "object", "additionalProperties": schemaRef(strings.TrimPrefix(name, "map[string]"))}
+	case name == "string":
+		return map[string]interface{}{"type": "string"}
+	}
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
+
+// typeSchema 함수는 Go 자료형을 JSON 스키마로 바꾼다.
+// APISchemas 에 등록된 자료구조는 참조로 표기한다.
+func typeSchema(t reflect.Type, names map[reflect.Type]string) map[string]interface{} {
+	if name, ok := names[t]; ok {
+		return schemaRef(name)
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return typeSchema(t.Elem(), names)
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": typeSchema(t.Elem(), names)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": typeSchema(t.Elem(), names)}
+	case reflect.Struct:
+		return structSchema(t, names)
+	}
+	return map[string]interface{}{}
+}
+
+// structSchema 함수는 자료구조를 json 태그 기준의 object 스키마로 바꾼다.
+func structSchema(t reflect.Type, names map[reflect.Type]string) map[string]interface{} {
+	props := make(map[string]interface{})
+	for n := 0; n < t.NumField(); n++ {
+		f := t.Field(n)
+		tag := strings.Split(f.Tag.Get("json"), ",")[0]
+		if tag == "-" || f.PkgPath != "" {
+			continue
+		}
+		// 태그가 없는 임베디드 자료구조는 필드를 펼친다.
+		if f.Anonymous && tag == "" && f.Type.Kind() == reflect.Struct {
+			for k, v := range structSchema(f.Type, names)["properties"].(map[string]interface{}) {
+				props[k] = v
+			}
+			continue
+		}
+		if tag == "" {
+			tag = f.Name
+		}
+		props[tag] = typeSchema(f.Type, names)
+	}
+	return map[string]interface{}{"type": "object", "properties": props}
+}
+
+// openAPIOperation 함수는 경로 하나의 HTTP 메소드에 해당하는 명세를 만든다.
+func openAPIOperation(route APIRoute, method string) map[string]interface{} {
+	op := map[string]interface{}{
+		"summary":       route.Summary,
+		"operationId":   strings.ToLower(method) + "_" + strings.Replace(strings.Trim(regexpPathParam.ReplaceAllString(route.Path, "$1"), "/"), "/", "_", -1),
+		"x-accesslevel": route.Level,
+	}
+	var params []interface{}
+	for _, p := range regexpPathParam.FindAllStringSubmatch(route.Path, -1) {
+		params = append(params, map[string]interface{}{"name": p[1], "in": "path", "required": true, "schema": schemaRef("string")})
+	}
+	if method == http.MethodGet {
+		for _, p := range route.Params {
+			params = append(params, map[string]interface{}{"name": p, "in": "query", "schema": schemaRef("string")})
+		}
+	} else if route.Body != "" {
+		op["requestBody"] = map[string]interface{}{
+			"content": map[string]interface{}{"application/json": map[string]interface{}{"schema": schemaRef(route.Body)}},
+		}
+	} else if len(route.Params) != 0 {
+		props := make(map[string]interface{})
+		for _, p := range route.Params {
+			props[p] = schemaRef("string")
+		}
+		schema := map[string]interface{}{"type": "object", "properties": props}
+		op["requestBody"] = map[string]interface{}{
+			"content": map[string]interface{}{
+				"application/x-www-form-urlencoded": map[string]interface{}{"schema": schema},
+				"application/json":                  map[string]interface{}{"schema": schema},
+			},
+		}
+	}
+	if params != nil {
+		op["parameters"] = params
+	}
+	if route.Level != UnknownAccessLevel {
+		op["security"] = []interface{}{map[string]interface{}{"token": []string{}}}
+	}
+	data := schemaRef(route.Response)
+	if !route.Raw {
+		data = map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"data":  data,
+				"error": map[string]interface{}{"nullable": true, "allOf": []interface{}{schemaRef("APIError")}},
+			},
+		}
+	}
+	contentType := "application/json"
+	if route.Stream {
+		contentType = "text/event-stream"
+	}
+	op["responses"] = map[string]interface{}{
+		"200": map[string]interface{}{
+			"description": "OK",
+			"content":     map[string]interface{}{contentType: map[string]interface{}{"schema": data}},
+		},
+		"default": map[string]interface{}{
+			"description": "Error",
+			"content":     map[string]interface{}{"application/json": map[string]interface{}{"schema": schemaRef("APIResponse")}},
+		},
+	}
+	return op
+}
+
+// openAPISpec 함수는 APIRoutes, APISchemas 로 OpenAPI 3.0 명세를 만든다.
+func openAPISpec() map[string]interface{} {
+	names := make(map[reflect.Type]string)
+	for name, v := range APISchemas {
+		names[reflect.TypeOf(v)] = name
+	}
+	schemas := make(map[string]interface{})
+	for name, v := range APISchemas {
+		schemas[name] = structSchema(reflect.TypeOf(v), names)
+	}
+	schemas["APIResponse"] = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"data":  map[string]interface{}{},
+			"error": map[string]interface{}{"nullable": true, "allOf": []interface{}{schemaRef("APIError")}},
+		},
+	}
+	paths := make(map[string]interface{})
+	for _, route := range APIRoutes {
+		item := make(map[string]interface{})
+		for _, m := range route.Methods {
+			item[strings.ToLower(m)] = openAPIOperation(route, m)
+		}
+		paths[route.Path] = item
+	}
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "CSI RestAPI",
+			"version":     SHA1VER,
+			"description": "모든 응답은 {\"data\":..., \"error\":null} 형태이다. x-accesslevel은 필요한 최소 AccessLevel 이다.",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+			"securitySchemes": map[string]interface{}{
+				"token": map[string]interface{}{
+					"type":        "apiKey",
+					"in":          "header",
+					"name":        "Authorization",
+					"description": "Basic {TOKEN} 형태로 사용자 토큰을 전달한다.",
+				},
+			},
+		},
+	}
+}
+
+// handleAPIOpenAPI 함수는 /api 명세를 OpenAPI 3.0 형식으로 반환한다.
+// Swagger UI 같은 툴에서 바로 사용할 수 있도록 공통 응답 형태로 감싸지 않는다.
+func handleAPIOpenAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	data, err := json.Marshal(openAPISpec())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// handlerSource 자료구조는 핸들러 소스코드에서 읽은 정보이다.
+type handlerSource struct {
+	Keys    []string // 요청에서 읽는 키
+	Methods []string // 비교하는 HTTP 메소드
+	Auth    bool     // TokenHandler 사용여부
+	Level   string   // 비교하는 AccessLevel 상수이름
+	Data    string   // 응답 Recipe의 Data 필드 자료형
+}
+
+// parseSources 함수는 테스트 파일을 제외한 소스코드의 함수를 이름으로 가지고 온다.
+func parseSources(t *testing.T) (map[string]*ast.FuncDecl, *ast.File) {
+	fset := token.NewFileSet()
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	funcs := make(map[string]*ast.FuncDecl)
+	var httpFile *ast.File
+	for _, f := range files {
+		if strings.HasSuffix(f, "_test.go") || f == "assets_vfsdata.go" {
+			continue
+		}
+		file, err := parser.ParseFile(fset, f, nil, parser.ParseComments)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f == "http.go" {
+			httpFile = file
+		}
+		for _, d := range file.Decls {
+			if fn, ok := d.(*ast.FuncDecl); ok && fn.Recv == nil {
+				funcs[fn.Name.Name] = fn
+			}
+		}
+	}
+	return funcs, httpFile
+}
+
+// sourceRoutes 함수는 http.go 에 등록된 /api 경로와 핸들러 함수이름을 가지고 온다.
+func sourceRoutes(httpFile *ast.File) map[string]string {
+	routes := make(map[string]string)
+	ast.Inspect(httpFile, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "HandleFunc" {
+			return true
+		}
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok {
+			return true
+		}
+		path, _ := strconv.Unquote(lit.Value)
+		if !strings.HasPrefix(path, "/api") {
+			return true
+		}
+		h := call.Args[1]
+		if c, ok := h.(*ast.CallExpr); ok {
+			if id, ok := c.Fun.(*ast.Ident); ok && id.Name == "apiHandler" {
+				h = c.Args[0]
+			}
+		}
+		switch h := h.(type) {
+		case *ast.Ident:
+			routes[path] = h.Name
+		case *ast.CallExpr:
+			if id, ok := h.Fun.(*ast.Ident); ok {
+				routes[path] = id.Name
+			}
+		}
+		return true
+	})
+	return routes
+}
+
+// stringArg 함수는 함수호출의 첫번째 인수가 문자열이라면 반환한다.
+func stringArg(call *ast.CallExpr) (string, bool) {
+	if len(call.Args) != 1 {
+		return "", false
+	}
+	lit, ok := call.Args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	s, err := strconv.Unquote(lit.Value)
+	return s, err == nil
+}
+
+// inspectHandler 함수는 핸들러 함수에서 요청 키, HTTP 메소드, 권한체크를 읽는다.
+// r.Method 를 전달받는 함수가 있다면 해당 함수의 HTTP 메소드도 함께 읽는다.
+func inspectHandler(fn *ast.FuncDecl, funcs map[string]*ast.FuncDecl) handlerSource {
+	keys := make(map[string]bool)
+	methods := make(map[string]bool)
+	var src handlerSource
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Field:
+			// type Recipe struct { Data []Item `json:"data"` }
+			if len(n.Names) == 1 && n.Names[0].Name == "Data" {
+				src.Data = types.ExprString(n.Type)
+			}
+		case *ast.SwitchStmt:
+			// for key, values := range r.PostForm { switch key { case "project": ... } }
+			if id, ok := n.Tag.(*ast.Ident); ok && id.Name == "key" {
+				for _, s := range n.Body.List {
+					for _, e := range s.(*ast.CaseClause).List {
+						if lit, ok := e.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+							k, _ := strconv.Unquote(lit.Value)
+							keys[k] = true
+						}
+					}
+				}
+			}
+		case *ast.CallExpr:
+			if id, ok := n.Fun.(*ast.Ident); ok && id.Name == "TokenHandler" {
+				src.Auth = true
+			}
+			if id, ok := n.Fun.(*ast.Ident); ok && funcs[id.Name] != nil {
+				for _, a := range n.Args {
+					// eventAccess(r, project) 처럼 요청을 넘겨받아 토큰을 체크하는 함수도 인증으로 본다.
+					if arg, ok := a.(*ast.Ident); ok && arg.Name == "r" && inspectHandler(funcs[id.Name], funcs).Auth {
+						src.Auth = true
+					}
+					if sel, ok := a.(*ast.SelectorExpr); ok && sel.Sel.Name == "Method" {
+						for _, m := range inspectHandler(funcs[id.Name], funcs).Methods {
+							methods[m] = true
+						}
+					}
+				}
+			}
+			sel, ok := n.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			query := false
+			switch sel.Sel.Name {
+			case "FormValue", "PostFormValue":
+				query = true
+			case "Get":
+				// q.Get("project"), r.URL.Query().Get("project")
+				switch x := sel.X.(type) {
+				case *ast.Ident:
+					query = x.Name == "q"
+				case *ast.CallExpr:
+					if s, ok := x.Fun.(*ast.SelectorExpr); ok && s.Sel.Name == "Query" {
+						query = true
+					}
+				}
+			}
+			if k, ok := stringArg(n); ok && query {
+				keys[k] = true
+			}
+		case *ast.IndexExpr:
+			// r.PostForm["fields"]
+			if sel, ok := n.X.(*ast.SelectorExpr); ok && sel.Sel.Name == "PostForm" {
+				if lit, ok := n.Index.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					k, _ := strconv.Unquote(lit.Value)
+					keys[k] = true
+				}
+			}
+		case *ast.SelectorExpr:
+			if x, ok := n.X.(*ast.Ident); ok && x.Name == "http" && strings.HasPrefix(n.Sel.Name, "Method") {
+				methods[strings.ToUpper(strings.TrimPrefix(n.Sel.Name, "Method"))] = true
+			}
+		case *ast.BinaryExpr:
+			// if SupervisorAccessLevel > level, if level < SupervisorAccessLevel
+			if id, ok := n.X.(*ast.Ident); ok && n.Op == token.GTR && strings.HasSuffix(id.Name, "AccessLevel") {
+				src.Level = id.Name
+			}
+			if id, ok := n.Y.(*ast.Ident); ok && n.Op == token.LSS && strings.HasSuffix(id.Name, "AccessLevel") {
+				src.Level = id.Name
+			}
+		}
+		return true
+	})
+	for k := range keys {
+		src.Keys = append(src.Keys, k)
+	}
+	for m := range methods {
+		src.Methods = append(src.Methods, m)
+	}
+	sort.Strings(src.Keys)
+	sort.Strings(src.Methods)
+	return src
+}
+
+// accessLevels 는 핸들러 소스에서 사용하는 AccessLevel 상수이름과 값이다.
+var accessLevels = map[string]AccessLevel{
+	"UnknownAccessLevel":    UnknownAccessLevel,
+	"GuestAccessLevel":      GuestAccessLevel,
+	"ClientsAccessLevel":    ClientsAccessLevel,
+	"ArtistAccessLevel":     ArtistAccessLevel,
+	"LeadAccessLevel":       LeadAccessLevel,
+	"PmAccessLevel":         PmAccessLevel,
+	"SupervisorAccessLevel": SupervisorAccessLevel,
+	"IoAccessLevel":         IoAccessLevel,
+	"PdAccessLevel":         PdAccessLevel,
+	"HqAccessLevel":         HqAccessLevel,
+	"DeveloperAccessLevel":  DeveloperAccessLevel,
+	"AdminAccessLevel":      AdminAccessLevel,
+}
+
+func TestAPIRoutes(t *testing.T) {
+	funcs, httpFile := parseSources(t)
+	routes := sourceRoutes(httpFile)
+	specs := make(map[string]APIRoute)
+	for _, r := range APIRoutes {
+		if _, found := specs[r.Path]; found {
+			t.Fatalf("%s 경로가 명세에 중복되어 있습니다", r.Path)
+		}
+		specs[r.Path] = r
+	}
+	for path, handler := range routes {
+		fn, found := funcs[handler]
+		if !found {
+			t.Fatalf("%s 경로의 핸들러 %s 를 찾을 수 없습니다", path, handler)
+		}
+		src := inspectHandler(fn, funcs)
+		level := UnknownAccessLevel
+		if src.Auth {
+			level = ClientsAccessLevel // TokenHandler 가 허용하는 최소 AccessLevel
+		}
+		if src.Level != "" {
+			level = accessLevels[src.Level]
+		}
+		// /api/v3/ 처럼 "/"로 끝나는 경로는 하위 경로의 명세를 모두 합쳐서 비교한다.
+		var matched []APIRoute
+		for p, r := range specs {
+			if p == path || (strings.HasSuffix(path, "/") && strings.HasPrefix(p, path)) {
+				matched = append(matched, r)
+			}
+		}
+		if len(matched) == 0 {
+			t.Fatalf("%s 경로가 APIRoutes 명세에 없습니다", path)
+		}
+		methods := make(map[string]bool)
+		for _, r := range matched {
+			if r.Handler != handler {
+				t.Fatalf("%s 명세의 핸들러: 얻은 값 %s, 원하는 값 %s", r.Path, r.Handler, handler)
+			}
+			if r.Level != level {
+				t.Fatalf("%s 명세의 AccessLevel: 얻은 값 %d, 원하는 값 %d", r.Path, r.Level, level)
+			}
+			for _, m := range r.Methods {
+				methods[strings.ToUpper(m)] = true
+			}
+			if strings.Contains(r.Path, "{") {
+				continue // JSON Body를 직접 읽는 리소스 경로는 요청 키를 비교하지 않는다.
+			}
+			params := append([]string{}, r.Params...)
+			sort.Strings(params)
+			if strings.Join(params, ",") != strings.Join(src.Keys, ",") {
+				t.Fatalf("%s 명세의 요청 키: 얻은 값 %v, 핸들러 %s 가 읽는 키 %v", r.Path, params, handler, src.Keys)
+			}
+			if src.Data != "" && r.Response != src.Data {
+				t.Fatalf("%s 명세의 응답: 얻은 값 %q, 핸들러 %s 의 응답 %q", r.Path, r.Response, handler, src.Data)
+			}
+		}
+		var ms []string
+		for m := range methods {
+			ms = append(ms, m)
+		}
+		sort.Strings(ms)
+		if strings.Join(ms, ",") != strings.Join(src.Methods, ",") {
+			t.Fatalf("%s 명세의 HTTP 메소드: 얻은 값 %v, 핸들러 %s 의 메소드 %v", path, ms, handler, src.Methods)
+		}
+	}
+	// 명세에만 있고 등록되지 않은 경로가 없어야 한다.
+	for p := range specs {
+		found := false
+		for path := range routes {
+			if p == path || (strings.HasSuffix(path, "/") && strings.HasPrefix(p, path)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("%s 경로는 명세에만 있고 http.go 에 등록되지 않았습니다", p)
+		}
+	}
+}
+
+func TestOpenAPISpec(t *testing.T) {
+	data, err := json.Marshal(openAPISpec())
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := struct {
+		Paths      map[string]map[string]interface{} `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]interface{} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}{}
+	err = json.Unmarshal(data, &spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spec.Paths) != len(APIRoutes) {
+		t.Fatalf("paths: 얻은 값 %d, 원하는 값 %d", len(spec.Paths), len(APIRoutes))
+	}
+	for _, name := range []string{"Item", "Project", "User", "Tasksetting", "Setellite"} {
+		if len(spec.Components.Schemas[name].Properties) == 0 {
+			t.Fatalf("%s 스키마가 없습니다", name)
+		}
+	}
+	if _, found := spec.Components.Schemas["Item"].Properties["tasks"]; !found {
+		t.Fatal("Item 스키마에 tasks 필드가 없습니다")
+	}
+	// 모든 참조는 components.schemas 에 존재해야 한다.
+	for _, ref := range regexp.MustCompile(`"\$ref":"#/components/schemas/(\w+)"`).FindAllStringSubmatch(string(data), -1) {
+		if _, found := spec.Components.Schemas[ref[1]]; !found {
+			t.Fatalf("%s 스키마가 없습니다", ref[1])
+		}
+	}
+	if _, found := spec.Paths["/api/v3/projects/{project}/items/{id}"]["patch"]; !found {
+		t.Fatal("/api/v3 PATCH 명세가 없습니다")
+	}
+}
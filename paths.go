@@ -0,0 +1,246 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// ItemPaths 자료구조는 관리자 설정과 Tasksetting의 경로 템플릿으로 만든 아이템 경로이다.
+// 아이템 타입에 해당하지 않는 경로는 빈 문자열이다. 예) 에셋의 SeqPath
+type ItemPaths struct {
+	Project       string              `json:"project"`
+	ID            string              `json:"id"`
+	ProjectPath   string              `json:"projectpath"`
+	ShotRootPath  string              `json:"shotrootpath"`
+	SeqPath       string              `json:"seqpath"`
+	ShotPath      string              `json:"shotpath"`
+	AssetRootPath string              `json:"assetrootpath"`
+	AssetTypePath string              `json:"assettypepath"`
+	AssetPath     string              `json:"assetpath"`
+	Tasks         map[string]TaskPath `json:"tasks"` // Task 이름별 OS 경로
+}
+
+// PathInfo 자료구조는 파일시스템 경로가 속한 프로젝트, 아이템, Task 정보이다.
+type PathInfo struct {
+	Path     string `json:"path"`     // 입력된 경로
+	Project  string `json:"project"`  // 프로젝트
+	ID       string `json:"id"`       // 아이템 ID. 아이템 경로가 아니라면 빈 문자열이다.
+	Name     string `json:"name"`     // 샷, 에셋 이름
+	Type     string `json:"type"`     // 아이템 타입. org, left, asset
+	Task     string `json:"task"`     // Task 이름. Task 경로가 아니라면 빈 문자열이다.
+	Template string `json:"template"` // 일치한 경로 템플릿. 예) ShotPath, compshot.linuxpath
+	Rest     string `json:"rest"`     // 템플릿 경로 아래의 나머지 경로. 예) pub/images/a.0001.exr
+}
+
+// itemPaths 함수는 관리자 설정의 경로 템플릿으로 프로젝트, 아이템 경로를 만든다.
+// i.Name이 빈 문자열이면 프로젝트 경로만 만든다.
+func itemPaths(s Setting, p Project, i Item) (ItemPaths, error) {
+	result := ItemPaths{Project: p.ID, ID: i.ID, Tasks: make(map[string]TaskPath)}
+	templates := projectFolderTemplates(s)
+	if i.Name != "" {
+		templates = append(templates, itemFolderTemplates(s, i.Type)...)
+	} else {
+		i = Item{Project: p.ID}
+	}
+	for _, t := range templates {
+		path, err := renderPath(t.Name, t.Path, i)
+		if err != nil {
+			return result, err
+		}
+		switch t.Name {
+		case "ProjectPath":
+			result.ProjectPath = path
+		case "ShotRootPath":
+			result.ShotRootPath = path
+		case "SeqPath":
+			result.SeqPath = path
+		case "ShotPath":
+			result.ShotPath = path
+		case "AssetRootPath":
+			result.AssetRootPath = path
+		case "AssetTypePath":
+			result.AssetTypePath = path
+		case "AssetPath":
+			result.AssetPath = path
+		}
+	}
+	return result, nil
+}
+
+// pathPattern 자료구조는 경로 템플릿을 역으로 찾기 위한 정규표현식이다.
+type pathPattern struct {
+	Template string         // 경로 템플릿 이름. 예) ShotPath
+	Task     string         // Task 경로라면 Task 이름
+	TaskType string         // Task 경로라면 Tasksetting 타입. shot, asset
+	re       *regexp.Regexp // 경로 템플릿으로 만든 정규표현식
+	fields   []string       // 정규표현식 그룹 순서대로의 변수 이름
+}
+
+// newPathPattern 함수는 경로 템플릿을 정규표현식으로 바꾼다.
+// {{.Project}} 처럼 필드 하나만 출력하는 템플릿만 지원하며, 변수는 경로 구분자(/)를 포함하지 않는다고 가정한다.
+func newPathPattern(name, tmpl string) (pathPattern, error) {
+	p := pathPattern{Template: name}
+	t, err := template.New(name).Parse(normalizePath(tmpl))
+	if err != nil {
+		return p, err
+	}
+	if t.Tree == nil || t.Tree.Root == nil {
+		return p, fmt.Errorf("%s: 빈 템플릿입니다", name)
+	}
+	var expr strings.Builder
+	expr.WriteString("^")
+	for _, node := range t.Tree.Root.Nodes {
+		switch n := node.(type) {
+		case *parse.TextNode:
+			expr.WriteString(regexp.QuoteMeta(string(n.Text)))
+		case *parse.ActionNode:
+			field, ok := actionField(n)
+			if !ok {
+				return p, fmt.Errorf("%s: %s 는 경로를 역으로 찾을 수 없는 템플릿입니다", name, n.String())
+			}
+			p.fields = append(p.fields, field)
+			expr.WriteString("([^/]+?)")
+		default:
+			return p, fmt.Errorf("%s: %s 는 경로를 역으로 찾을 수 없는 템플릿입니다", name, n.String())
+		}
+	}
+	expr.WriteString("(?:/(.*))?$")
+	p.re, err = regexp.Compile(expr.String())
+	if err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+// actionField 함수는 {{.Name}} 형태의 액션이라면 필드 이름을 반환한다.
+func actionField(n *parse.ActionNode) (string, bool) {
+	if n.Pipe == nil || len(n.Pipe.Decl) != 0 || len(n.Pipe.Cmds) != 1 || len(n.Pipe.Cmds[0].Args) != 1 {
+		return "", false
+	}
+	f, ok := n.Pipe.Cmds[0].Args[0].(*parse.FieldNode)
+	if !ok || len(f.Ident) != 1 {
+		return "", false
+	}
+	return f.Ident[0], true
+}
+
+// normalizePath 함수는 윈도우즈 경로 구분자를 /로 바꾸고 마지막 /를 제거한다.
+func normalizePath(path string) string {
+	path = strings.Replace(path, "\\", "/", -1)
+	if len(path) > 1 {
+		path = strings.TrimRight(path, "/")
+	}
+	return path
+}
+
+// match 함수는 경로가 패턴과 일치하면 변수값과 나머지 경로를 반환한다.
+// 같은 변수가 여러번 사용되었다면 모두 같은 값이어야 한다.
+func (p pathPattern) match(path string) (map[string]string, string, bool) {
+	m := p.re.FindStringSubmatch(path)
+	if m == nil {
+		return nil, "", false
+	}
+	vars := make(map[string]string)
+	for n, field := range p.fields {
+		v := m[n+1]
+		if before, found := vars[field]; found && before != v {
+			return nil, "", false
+		}
+		vars[field] = v
+	}
+	if p.Task != "" {
+		// Task 경로 템플릿의 {{.Task}}, {{.Type}} 값은 Tasksetting과 같아야 한다.
+		if v, found := vars["Task"]; found && v != p.Task {
+			return nil, "", false
+		}
+		if v, found := vars["Type"]; found && v != p.TaskType {
+			return nil, "", false
+		}
+	}
+	return vars, m[len(m)-1], true
+}
+
+// matchPath 함수는 경로와 일치하는 패턴 중 가장 깊은 경로의 패턴을 찾는다.
+// 깊이가 같다면 Task 경로를 우선한다.
+func matchPath(patterns []pathPattern, path string) (pathPattern, map[string]string, string, bool) {
+	path = normalizePath(path)
+	var best pathPattern
+	var bestVars map[string]string
+	bestRest := ""
+	bestLen := -1
+	for _, p := range patterns {
+		vars, rest, ok := p.match(path)
+		if !ok {
+			continue
+		}
+		length := len(path) - len(rest)
+		if length < bestLen || (length == bestLen && (best.Task != "" || p.Task == "")) {
+			continue
+		}
+		best, bestVars, bestRest, bestLen = p, vars, rest, length
+	}
+	return best, bestVars, bestRest, bestLen != -1
+}
+
+// pathPatterns 함수는 관리자 설정과 Tasksetting의 경로 템플릿으로 역으로 찾기 위한 패턴 리스트를 만든다.
+// 역으로 찾을 수 없는 템플릿은 건너뛴다.
+func pathPatterns(s Setting, tasksettings []Tasksetting) []pathPattern {
+	var templates []folderTemplate
+	templates = append(templates, projectFolderTemplates(s)...)
+	templates = append(templates, itemFolderTemplates(s, "org")...)
+	templates = append(templates, itemFolderTemplates(s, "asset")...)
+	var results []pathPattern
+	seen := make(map[string]bool)
+	for _, t := range templates {
+		if t.Path == "" || seen[t.Name] {
+			continue
+		}
+		seen[t.Name] = true
+		p, err := newPathPattern(t.Name, t.Path)
+		if err != nil {
+			continue
+		}
+		results = append(results, p)
+	}
+	for _, t := range tasksettings {
+		paths := map[string]string{
+			"linuxpath":  t.LinuxPath,
+			"macospath":  t.MacOSPath,
+			"windowpath": t.WindowPath,
+			"wfspath":    t.WFSPath,
+		}
+		var keys []string
+		for k := range paths {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if paths[k] == "" {
+				continue
+			}
+			p, err := newPathPattern(t.ID+"."+k, paths[k])
+			if err != nil {
+				continue
+			}
+			p.Task = t.Name
+			p.TaskType = t.Type
+			results = append(results, p)
+		}
+	}
+	return results
+}
+
+// pathVarsName 함수는 경로 변수에서 샷, 에셋 이름을 구한다. Name이 없다면 Seq, Cut으로 샷 이름을 만든다.
+func pathVarsName(vars map[string]string) string {
+	if vars["Name"] != "" {
+		return vars["Name"]
+	}
+	if vars["Seq"] != "" && vars["Cut"] != "" {
+		return vars["Seq"] + "_" + vars["Cut"]
+	}
+	return ""
+}
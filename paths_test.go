@@ -0,0 +1,105 @@
+package main
+
+import "testing"
+
+var testPathSetting = Setting{
+	ProjectPath:   "/show/{{.Project}}",
+	ShotRootPath:  "/show/{{.Project}}/seq/",
+	SeqPath:       "/show/{{.Project}}/seq/{{.Seq}}",
+	ShotPath:      "/show/{{.Project}}/seq/{{.Seq}}/{{.Name}}",
+	AssetRootPath: "/show/{{.Project}}/assets",
+	AssetTypePath: "/show/{{.Project}}/assets/{{.Assettype}}",
+	AssetPath:     "/show/{{.Project}}/assets/{{.Assettype}}/{{.Name}}",
+}
+
+func TestItemPaths(t *testing.T) {
+	p := Project{ID: "TEMP"}
+	shot := Item{Project: "TEMP", ID: "SS_0010_org", Name: "SS_0010", Seq: "SS", Type: "org"}
+	got, err := itemPaths(testPathSetting, p, shot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ProjectPath != "/show/TEMP" || got.ShotRootPath != "/show/TEMP/seq/" || got.SeqPath != "/show/TEMP/seq/SS" || got.ShotPath != "/show/TEMP/seq/SS/SS_0010" || got.AssetPath != "" || got.AssetRootPath != "/show/TEMP/assets" {
+		t.Fatalf("got %+v", got)
+	}
+	asset := Item{Project: "TEMP", ID: "stone_asset", Name: "stone", Type: "asset", Assettype: "prop"}
+	got, err = itemPaths(testPathSetting, p, asset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.AssetTypePath != "/show/TEMP/assets/prop" || got.AssetPath != "/show/TEMP/assets/prop/stone" || got.ShotPath != "" {
+		t.Fatalf("got %+v", got)
+	}
+	got, err = itemPaths(testPathSetting, p, Item{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ProjectPath != "/show/TEMP" || got.SeqPath != "" || got.AssetTypePath != "" {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestNewPathPattern(t *testing.T) {
+	p, err := newPathPattern("compshot.windowpath", `\\10.0.1.2\show\{{.Project}}\seq\{{.Seq}}\{{.Seq}}_{{.Cut}}\comp\`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	vars, rest, ok := p.match(normalizePath(`\\10.0.1.2\show\TEMP\seq\SS\SS_0010\comp\pub\a.exr`))
+	if !ok || vars["Project"] != "TEMP" || vars["Seq"] != "SS" || vars["Cut"] != "0010" || rest != "pub/a.exr" {
+		t.Fatalf("got %v, %q, %v", vars, rest, ok)
+	}
+	// 같은 변수는 같은 값이어야 한다.
+	if _, _, ok := p.match("//10.0.1.2/show/TEMP/seq/SS/AA_0010/comp"); ok {
+		t.Fatal("want mismatch")
+	}
+	// 경로의 일부 폴더 이름만 같은 경우는 일치하지 않는다.
+	if _, _, ok := p.match("//10.0.1.2/show/TEMP/seq/SS/SS_0010/comp2"); ok {
+		t.Fatal("want mismatch")
+	}
+	for _, tmpl := range []string{"/show/{{.Project | printf}}", "/show/{{if .Project}}a{{end}}", "/show/{{.Project"} {
+		if _, err := newPathPattern("bad", tmpl); err == nil {
+			t.Fatalf("%s: want error", tmpl)
+		}
+	}
+}
+
+func TestMatchPath(t *testing.T) {
+	tasksettings := []Tasksetting{
+		{ID: "compshot", Name: "comp", Type: "shot", LinuxPath: "/show/{{.Project}}/seq/{{.Seq}}/{{.Name}}/{{.Task}}"},
+		{ID: "modelasset", Name: "model", Type: "asset", LinuxPath: "/show/{{.Project}}/assets/{{.Assettype}}/{{.Name}}/model", MacOSPath: "/Volumes/show/{{.Project}}/assets/{{.Assettype}}/{{.Name}}/model"},
+		{ID: "roto", Name: "roto", Type: "shot", LinuxPath: "/show/{{.Project}}/seq/{{.Seq}}/{{.Name}}/{{.Task | printf}}"},
+	}
+	patterns := pathPatterns(testPathSetting, tasksettings)
+	cases := []struct {
+		path     string
+		template string
+		task     string
+		name     string
+		rest     string
+	}{
+		{"/show/TEMP", "ProjectPath", "", "", ""},
+		{"/show/TEMP/", "ProjectPath", "", "", ""},
+		{"/show/TEMP/seq/SS", "SeqPath", "", "", ""},
+		{"/show/TEMP/seq/SS/SS_0010", "ShotPath", "", "SS_0010", ""},
+		{"/show/TEMP/seq/SS/SS_0010/plate/SS_0010.0001.exr", "ShotPath", "", "SS_0010", "plate/SS_0010.0001.exr"},
+		{"/show/TEMP/seq/SS/SS_0010/comp/pub/v001", "compshot.linuxpath", "comp", "SS_0010", "pub/v001"},
+		{"/show/TEMP/assets/prop/stone", "AssetPath", "", "stone", ""},
+		{"/show/TEMP/assets/prop/stone/model/stone_v01.abc", "modelasset.linuxpath", "model", "stone", "stone_v01.abc"},
+		{"/Volumes/show/TEMP/assets/prop/stone/model", "modelasset.macospath", "model", "stone", ""},
+	}
+	for _, c := range cases {
+		p, vars, rest, ok := matchPath(patterns, c.path)
+		if !ok {
+			t.Fatalf("%s: not found", c.path)
+		}
+		if p.Template != c.template || p.Task != c.task || pathVarsName(vars) != c.name || rest != c.rest || vars["Project"] != "TEMP" {
+			t.Fatalf("%s: got %s %s %v %q", c.path, p.Template, p.Task, vars, rest)
+		}
+	}
+	if _, _, _, ok := matchPath(patterns, "/home/user/SS_0010"); ok {
+		t.Fatal("want not found")
+	}
+	if pathVarsName(map[string]string{"Seq": "SS", "Cut": "0010"}) != "SS_0010" {
+		t.Fatal("want name from seq, cut")
+	}
+}
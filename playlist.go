@@ -0,0 +1,315 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Playlist 자료구조는 서버에 저장하는 리뷰(데일리) 플레이리스트이다.
+type Playlist struct {
+	ID         string          `json:"id"`         // 플레이리스트 ID
+	Name       string          `json:"name"`       // 플레이리스트 이름
+	Date       string          `json:"date"`       // 리뷰 날짜. 예) 2016-12-06
+	Entries    []PlaylistEntry `json:"entries"`    // 재생 순서대로 정렬된 mov 리스트
+	Reviewers  []string        `json:"reviewers"`  // 리뷰어 ID 리스트
+	Author     string          `json:"author"`     // 플레이리스트를 만든 사용자 ID
+	Createtime string          `json:"createtime"` // 생성시간 RFC3339
+	Updatetime string          `json:"updatetime"` // 수정시간 RFC3339
+}
+
+// PlaylistEntry 자료구조는 플레이리스트에 들어가는 아이템 Task의 mov 하나이다.
+type PlaylistEntry struct {
+	Project  string `json:"project"`  // 프로젝트
+	Item     string `json:"item"`     // 아이템 ID
+	Name     string `json:"name"`     // 아이템 이름
+	Task     string `json:"task"`     // Task 이름
+	Mov      string `json:"mov"`      // mov 경로
+	FrameIn  int    `json:"framein"`  // 시작 프레임. EDL 길이 계산에 사용한다.
+	FrameOut int    `json:"frameout"` // 끝 프레임
+}
+
+// checkPlaylist 함수는 저장할 플레이리스트 값이 올바른지 체크한다.
+func checkPlaylist(p Playlist) error {
+	if strings.TrimSpace(p.Name) == "" {
+		return errors.New("플레이리스트 이름이 필요합니다")
+	}
+	if p.Date != "" && !MatchNormalTime.MatchString(p.Date) {
+		return fmt.Errorf("%s 날짜는 2016-12-06 형태여야 합니다", p.Date)
+	}
+	for n, e := range p.Entries {
+		if e.Project == "" || e.Item == "" || e.Task == "" {
+			return fmt.Errorf("%d번째 항목에 project, item, task 값이 필요합니다", n+1)
+		}
+		if e.Mov == "" {
+			return fmt.Errorf("%d번째 항목 %s %s 에 mov 경로가 없습니다", n+1, e.Item, e.Task)
+		}
+		if e.FrameIn != 0 && e.FrameOut != 0 && e.FrameIn > e.FrameOut {
+			return fmt.Errorf("%d번째 항목의 시작 프레임 %d 이 끝 프레임 %d 보다 큽니다", n+1, e.FrameIn, e.FrameOut)
+		}
+	}
+	return nil
+}
+
+// cleanReviewers 함수는 리뷰어 리스트에서 빈 문자열과 중복을 제거한다.
+func cleanReviewers(reviewers []string) []string {
+	results := []string{}
+	for _, r := range reviewers {
+		r = strings.TrimSpace(r)
+		if r == "" || hasString(results, r) {
+			continue
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// playlistEntries 함수는 아이템 리스트에서 mov가 등록된 Task를 플레이리스트 항목으로 만든다.
+// tasks 순서대로 Task를 살펴보며, task가 빈 문자열이 아니면 해당 Task만 사용한다.
+// date가 빈 문자열이 아니면 mov 등록이력에서 그날 등록된 마지막 mov를 사용한다.
+func playlistEntries(items []Item, tasks []string, task, date string) []PlaylistEntry {
+	entries := []PlaylistEntry{}
+	task = strings.ToLower(task)
+	for _, item := range items {
+		for _, t := range tasks {
+			t = strings.ToLower(t)
+			if task != "" && t != task {
+				continue
+			}
+			it, found := item.Tasks[t]
+			if !found || it.Mov == "" {
+				continue
+			}
+			mov := it.Mov
+			if date != "" {
+				record, found := taskMovOnDate(it, date)
+				if !found {
+					continue
+				}
+				mov = record.Mov
+			}
+			e := PlaylistEntry{
+				Project: item.Project,
+				Item:    item.ID,
+				Name:    item.Name,
+				Task:    t,
+				Mov:     mov,
+			}
+			e.FrameIn, e.FrameOut = itemFrameRange(item)
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// itemFrameRange 함수는 아이템의 저스트 프레임 범위를, 없다면 플레이트 프레임 범위를 반환한다.
+func itemFrameRange(item Item) (int, int) {
+	if item.JustIn != 0 || item.JustOut != 0 {
+		return item.JustIn, item.JustOut
+	}
+	return item.PlateIn, item.PlateOut
+}
+
+// parsePlaylistEntries 함수는 "project item task mov" 형태의 줄 리스트를 플레이리스트 항목으로 바꾼다.
+// mov 경로에는 공백이 들어갈 수 있으므로 네번째 값부터 줄 끝까지를 mov 경로로 사용한다.
+// 기존 항목과 같은 항목은 이름과 프레임 정보를 그대로 유지한다.
+func parsePlaylistEntries(text string, old []PlaylistEntry) ([]PlaylistEntry, error) {
+	entries := []PlaylistEntry{}
+	for n, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("%d번째 줄 %q 은 \"project item task mov\" 형태가 아닙니다", n+1, line)
+		}
+		e := PlaylistEntry{
+			Project: fields[0],
+			Item:    fields[1],
+			Task:    strings.ToLower(fields[2]),
+		}
+		rest := line
+		for _, f := range fields[:3] {
+			rest = strings.TrimLeft(rest, " \t")[len(f):]
+		}
+		e.Mov = strings.TrimSpace(rest)
+		for _, o := range old {
+			if o.Project == e.Project && o.Item == e.Item && o.Task == e.Task && o.Mov == e.Mov {
+				e = o
+				break
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// formPlaylistEntries 함수는 entries 폼 값 리스트를 플레이리스트 항목으로 바꾼다.
+// 각 값은 PlaylistEntry JSON 오브젝트이거나 "project item task mov" 형태의 줄이다.
+func formPlaylistEntries(values []string) ([]PlaylistEntry, error) {
+	entries := []PlaylistEntry{}
+	for _, v := range values {
+		if strings.HasPrefix(strings.TrimSpace(v), "{") {
+			var e PlaylistEntry
+			err := json.Unmarshal([]byte(v), &e)
+			if err != nil {
+				return nil, fmt.Errorf("entries 값을 읽을 수 없습니다: %v", err)
+			}
+			e.Task = strings.ToLower(e.Task)
+			entries = append(entries, e)
+			continue
+		}
+		lines, err := parsePlaylistEntries(v, nil)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, lines...)
+	}
+	return entries, nil
+}
+
+// PlaylistEntries2str 함수는 플레이리스트 항목을 웹에서 편집하기 위한 "project item task mov" 형태의 문자열로 바꾼다.
+func PlaylistEntries2str(entries []PlaylistEntry) string {
+	var lines []string
+	for _, e := range entries {
+		lines = append(lines, strings.Join([]string{e.Project, e.Item, e.Task, e.Mov}, " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// playlistFormats 는 플레이리스트를 내보낼 수 있는 형식과 파일 확장자이다.
+var playlistFormats = map[string]string{
+	"rv":  ".rv",
+	"edl": ".edl",
+	"txt": ".txt",
+}
+
+// playlistFormatNames 함수는 내보낼 수 있는 형식 이름을 정렬해서 반환한다.
+func playlistFormatNames() []string {
+	var names []string
+	for k := range playlistFormats {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// exportPlaylist 함수는 플레이리스트를 format 형식의 파일 내용으로 바꾼다.
+// fps는 EDL 타임코드 계산에만 사용한다.
+func exportPlaylist(p Playlist, format string, fps float64) (string, error) {
+	switch format {
+	case "rv":
+		return playlistRV(p), nil
+	case "edl":
+		return playlistEDL(p, fps)
+	case "txt":
+		return playlistText(p), nil
+	default:
+		return "", fmt.Errorf("%s 형식은 지원하지 않습니다. (%s 만 사용가능합니다)", format, strings.Join(playlistFormatNames(), ", "))
+	}
+}
+
+// playlistFilename 함수는 내보낼 파일의 이름을 만든다.
+func playlistFilename(p Playlist, format string) string {
+	name := strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|', ' ':
+			return '_'
+		}
+		return r
+	}, p.Name)
+	if p.Date != "" {
+		name += "_" + p.Date
+	}
+	return name + playlistFormats[format]
+}
+
+// playlistText 함수는 플레이리스트를 한줄에 mov 경로 하나인 텍스트로 바꾼다.
+func playlistText(p Playlist) string {
+	var b strings.Builder
+	for _, e := range p.Entries {
+		b.WriteString(e.Mov)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// gtoString 함수는 문자열을 RV 세션파일(GTO 텍스트)의 문자열 값으로 바꾼다.
+func gtoString(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, `"`, `\"`, -1)
+	return `"` + s + `"`
+}
+
+// playlistRV 함수는 플레이리스트를 RV 세션파일(.rv) 내용으로 바꾼다.
+// 항목 순서대로 defaultSequence에 연결되어 재생된다.
+func playlistRV(p Playlist) string {
+	var b strings.Builder
+	b.WriteString("GTOa (3)\n\n")
+	b.WriteString("rv : RVSession (2)\n{\n    session\n    {\n        string viewNode = \"defaultSequence\"\n    }\n}\n")
+	var groups []string
+	for n, e := range p.Entries {
+		group := fmt.Sprintf("sourceGroup%06d", n)
+		groups = append(groups, group)
+		fmt.Fprintf(&b, "\n%s : RVSourceGroup (1)\n{\n    ui\n    {\n        string name = %s\n    }\n}\n", group, gtoString(e.Name+" "+e.Task))
+		fmt.Fprintf(&b, "\n%s_source : RVFileSource (1)\n{\n    media\n    {\n        string movie = %s\n    }\n}\n", group, gtoString(e.Mov))
+	}
+	if len(groups) == 0 {
+		return b.String()
+	}
+	var lhs, rhs []string
+	for _, g := range groups {
+		lhs = append(lhs, gtoString(g))
+		rhs = append(rhs, gtoString("defaultSequence"))
+	}
+	fmt.Fprintf(&b, "\nconnections : connection (1)\n{\n    evaluation\n    {\n        string lhs = [ %s ]\n        string rhs = [ %s ]\n    }\n", strings.Join(lhs, " "), strings.Join(rhs, " "))
+	fmt.Fprintf(&b, "\n    top\n    {\n        string nodes = [ %s ]\n    }\n}\n", gtoString("defaultSequence"))
+	return b.String()
+}
+
+// frameToTimecode 함수는 프레임 수를 00:00:00:00 형태의 Non-Drop 타임코드로 바꾼다.
+func frameToTimecode(frame, fps int) string {
+	f := frame % fps
+	s := frame / fps
+	return fmt.Sprintf("%02d:%02d:%02d:%02d", s/3600, (s/60)%60, s%60, f)
+}
+
+// playlistEDL 함수는 플레이리스트를 CMX3600 EDL로 바꾼다.
+// 각 항목의 길이는 프레임 범위로 정하고, 프레임 범위가 없으면 1초로 간주한다.
+// 레코드 타임코드는 01:00:00:00 부터 순서대로 이어 붙인다.
+func playlistEDL(p Playlist, fps float64) (string, error) {
+	rate := int(math.Round(fps))
+	if rate <= 0 {
+		return "", fmt.Errorf("fps %v 값이 올바르지 않습니다", fps)
+	}
+	var b strings.Builder
+	title := p.Name
+	if p.Date != "" {
+		title += " " + p.Date
+	}
+	fmt.Fprintf(&b, "TITLE: %s\nFCM: NON-DROP FRAME\n", title)
+	record := 3600 * rate
+	for n, e := range p.Entries {
+		duration := rate
+		if e.FrameOut != 0 && e.FrameOut >= e.FrameIn {
+			duration = e.FrameOut - e.FrameIn + 1
+		}
+		fmt.Fprintf(&b, "\n%03d  AX       V     C        %s %s %s %s\n",
+			n+1,
+			frameToTimecode(0, rate),
+			frameToTimecode(duration, rate),
+			frameToTimecode(record, rate),
+			frameToTimecode(record+duration, rate),
+		)
+		fmt.Fprintf(&b, "* FROM CLIP NAME: %s\n", filepath.Base(e.Mov))
+		fmt.Fprintf(&b, "* SOURCE FILE: %s\n", e.Mov)
+		fmt.Fprintf(&b, "* COMMENT: %s %s %s\n", e.Project, e.Name, e.Task)
+		record += duration
+	}
+	return b.String(), nil
+}
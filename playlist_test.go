@@ -0,0 +1,147 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+var testPlaylist = Playlist{
+	Name: "TEMP review",
+	Date: "2016-12-06",
+	Entries: []PlaylistEntry{
+		{Project: "TEMP", Item: "SS_0010_org", Name: "SS_0010", Task: "comp", Mov: "/show/TEMP/review/SS_0010_comp_v01.mov", FrameIn: 1001, FrameOut: 1048},
+		{Project: "TEMP", Item: "SS_0020_org", Name: "SS_0020", Task: "fx", Mov: `/show/TEMP/review/SS_0020 "fx".mov`},
+	},
+}
+
+func TestCheckPlaylist(t *testing.T) {
+	if err := checkPlaylist(testPlaylist); err != nil {
+		t.Fatal(err)
+	}
+	bad := []Playlist{
+		{Name: " "},
+		{Name: "a", Date: "20161206"},
+		{Name: "a", Entries: []PlaylistEntry{{Project: "TEMP", Item: "SS_0010_org", Task: "comp"}}},
+		{Name: "a", Entries: []PlaylistEntry{{Project: "TEMP", Task: "comp", Mov: "a.mov"}}},
+		{Name: "a", Entries: []PlaylistEntry{{Project: "TEMP", Item: "SS_0010_org", Task: "comp", Mov: "a.mov", FrameIn: 20, FrameOut: 10}}},
+	}
+	for _, p := range bad {
+		if err := checkPlaylist(p); err == nil {
+			t.Fatalf("%+v: want error", p)
+		}
+	}
+	if got := cleanReviewers([]string{" a", "", "b", "a"}); strings.Join(got, ",") != "a,b" {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestPlaylistEntries(t *testing.T) {
+	items := []Item{
+		{Project: "TEMP", ID: "SS_0010_org", Name: "SS_0010", JustIn: 1001, JustOut: 1048, PlateIn: 1, PlateOut: 100, Tasks: map[string]Task{
+			"comp": {Mov: "/a/comp.mov", Mdate: "2016-12-06T10:00:00+09:00"},
+			"fx":   {Mov: "/a/fx.mov", Mdate: "2016-12-05T10:00:00+09:00"},
+			"roto": {},
+		}},
+		{Project: "TEMP", ID: "SS_0020_org", Name: "SS_0020", PlateIn: 1, PlateOut: 50, Tasks: map[string]Task{
+			"comp": {Mov: "/b/comp.mov", Mdate: "2016-12-06T11:00:00+09:00"},
+		}},
+	}
+	tasks := []string{"fx", "comp", "roto"}
+	got := playlistEntries(items, tasks, "", "")
+	if len(got) != 3 || got[0].Mov != "/a/fx.mov" || got[1].Mov != "/a/comp.mov" || got[2].Mov != "/b/comp.mov" {
+		t.Fatalf("got %+v", got)
+	}
+	if got[1].FrameIn != 1001 || got[1].FrameOut != 1048 || got[2].FrameIn != 1 || got[2].FrameOut != 50 || got[2].Name != "SS_0020" {
+		t.Fatalf("got %+v", got)
+	}
+	got = playlistEntries(items, tasks, "", "2016-12-06")
+	if len(got) != 2 || got[0].Task != "comp" || got[1].Item != "SS_0020_org" {
+		t.Fatalf("got %+v", got)
+	}
+	got = playlistEntries(items, tasks, "FX", "")
+	if len(got) != 1 || got[0].Task != "fx" {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+func TestParsePlaylistEntries(t *testing.T) {
+	text := PlaylistEntries2str(testPlaylist.Entries)
+	got, err := parsePlaylistEntries("# review\n"+text+"\n\n", testPlaylist.Entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 기존 항목은 이름과 프레임 정보를 유지한다.
+	if len(got) != 2 || got[0] != testPlaylist.Entries[0] || got[1] != testPlaylist.Entries[1] {
+		t.Fatalf("got %+v", got)
+	}
+	got, err = parsePlaylistEntries("TEMP  SS_0030_org   Comp  /show/TEMP/a b.mov", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Task != "comp" || got[0].Mov != "/show/TEMP/a b.mov" || got[0].Name != "" {
+		t.Fatalf("got %+v", got)
+	}
+	if _, err := parsePlaylistEntries("TEMP SS_0030_org comp", nil); err == nil {
+		t.Fatal("want error")
+	}
+	got, err = formPlaylistEntries([]string{`{"project":"TEMP","item":"SS_0010_org","task":"COMP","mov":"/a.mov"}`, "TEMP SS_0020_org fx /b.mov"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Task != "comp" || got[0].Mov != "/a.mov" || got[1].Item != "SS_0020_org" {
+		t.Fatalf("got %+v", got)
+	}
+	if _, err := formPlaylistEntries([]string{`{"project":1}`}); err == nil {
+		t.Fatal("want error")
+	}
+}
+
+func TestExportPlaylist(t *testing.T) {
+	text, err := exportPlaylist(testPlaylist, "txt", 24)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != "/show/TEMP/review/SS_0010_comp_v01.mov\n/show/TEMP/review/SS_0020 \"fx\".mov\n" {
+		t.Fatalf("got %q", text)
+	}
+	rv, err := exportPlaylist(testPlaylist, "rv", 24)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"GTOa (3)",
+		`sourceGroup000001_source : RVFileSource (1)`,
+		`string movie = "/show/TEMP/review/SS_0020 \"fx\".mov"`,
+		`string lhs = [ "sourceGroup000000" "sourceGroup000001" ]`,
+	} {
+		if !strings.Contains(rv, want) {
+			t.Fatalf("rv: %q not found in\n%s", want, rv)
+		}
+	}
+	edl, err := exportPlaylist(testPlaylist, "edl", 23.976)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"TITLE: TEMP review 2016-12-06",
+		"001  AX       V     C        00:00:00:00 00:00:02:00 01:00:00:00 01:00:02:00",
+		"002  AX       V     C        00:00:00:00 00:00:01:00 01:00:02:00 01:00:03:00",
+		"* FROM CLIP NAME: SS_0010_comp_v01.mov",
+	} {
+		if !strings.Contains(edl, want) {
+			t.Fatalf("edl: %q not found in\n%s", want, edl)
+		}
+	}
+	if _, err := exportPlaylist(testPlaylist, "edl", 0); err == nil {
+		t.Fatal("want fps error")
+	}
+	if _, err := exportPlaylist(testPlaylist, "xml", 24); err == nil {
+		t.Fatal("want format error")
+	}
+	if got := playlistFilename(Playlist{Name: "TEMP/comp review", Date: "2016-12-06"}, "edl"); got != "TEMP_comp_review_2016-12-06.edl" {
+		t.Fatalf("got %s", got)
+	}
+	if got := frameToTimecode(90061*24+5, 24); got != "25:01:01:05" {
+		t.Fatalf("got %s", got)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// Publish 자료구조는 Task에서 퍼블리시한 결과물 하나의 기록이다.
+type Publish struct {
+	ID          string   `json:"id"`          // 퍼블리시 ID
+	Project     string   `json:"project"`     // 프로젝트
+	Item        string   `json:"item"`        // 아이템 ID
+	Task        string   `json:"task"`        // Task 이름
+	Version     Version  `json:"version"`     // 버전. Task 안에서 고유하다.
+	Files       []string `json:"files"`       // 퍼블리시된 파일 경로. 첫번째 경로가 Task의 Pubfile이 된다.
+	FrameIn     int      `json:"framein"`     // 시작 프레임
+	FrameOut    int      `json:"frameout"`    // 끝 프레임
+	Colorspace  string   `json:"colorspace"`  // OCIO 컬러스페이스. 예) ACES - ACEScg
+	Author      string   `json:"author"`      // 퍼블리시한 사용자 ID
+	Comment     string   `json:"comment"`     // 퍼블리시 설명
+	Uses        []string `json:"uses"`        // 이 퍼블리시를 만들 때 사용한 상위 퍼블리시 ID 리스트
+	Approved    bool     `json:"approved"`    // 승인 여부
+	Approver    string   `json:"approver"`    // 승인한 사용자 ID
+	Approvetime string   `json:"approvetime"` // 승인시간 RFC3339
+	Createtime  string   `json:"createtime"`  // 등록시간 RFC3339
+}
+
+// regexpPublishVersion 은 v01, v01_w02, 3 형태의 버전 문자열이다.
+var regexpPublishVersion = regexp.MustCompile(`^[vV]?(\d{1,4})(?:_[wW](\d{1,4}))?$`)
+
+// parseVersion 함수는 v01, v01_w02 형태의 문자열을 Version으로 바꾼다.
+func parseVersion(s string) (Version, error) {
+	m := regexpPublishVersion.FindStringSubmatch(s)
+	if m == nil {
+		return Version{}, fmt.Errorf("%s 는 v01 또는 v01_w02 형태의 버전이 아닙니다", s)
+	}
+	var v Version
+	v.Main, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		v.Sub, _ = strconv.Atoi(m[2])
+	}
+	return v, nil
+}
+
+// versionString 함수는 Version을 v01, v01_w02 형태의 문자열로 바꾼다.
+func versionString(v Version) string {
+	if v.Sub == 0 {
+		return fmt.Sprintf("v%02d", v.Main)
+	}
+	return fmt.Sprintf("v%02d_w%02d", v.Main, v.Sub)
+}
+
+// versionLess 함수는 a 버전이 b 버전보다 이전 버전인지 체크한다.
+func versionLess(a, b Version) bool {
+	if a.Main != b.Main {
+		return a.Main < b.Main
+	}
+	return a.Sub < b.Sub
+}
+
+// checkPublish 함수는 등록할 퍼블리시 값이 올바른지 체크한다.
+func checkPublish(p Publish) error {
+	if p.Item == "" || p.Task == "" {
+		return errors.New("item, task 값이 필요합니다")
+	}
+	if len(p.Files) == 0 {
+		return errors.New("퍼블리시 파일 경로가 없습니다")
+	}
+	for _, f := range p.Files {
+		if f == "" {
+			return errors.New("빈 파일 경로가 있습니다")
+		}
+	}
+	if p.Version.Main < 0 || p.Version.Sub < 0 {
+		return errors.New("버전은 0보다 작을 수 없습니다")
+	}
+	if p.FrameIn != 0 && p.FrameOut != 0 && p.FrameIn > p.FrameOut {
+		return fmt.Errorf("시작 프레임 %d 이 끝 프레임 %d 보다 큽니다", p.FrameIn, p.FrameOut)
+	}
+	return nil
+}
+
+// sortPublishes 함수는 퍼블리시 리스트를 최신 버전부터 정렬한다.
+func sortPublishes(publishes []Publish) {
+	sort.SliceStable(publishes, func(i, j int) bool {
+		return versionLess(publishes[j].Version, publishes[i].Version)
+	})
+}
+
+// nextPublishVersion 함수는 Task 퍼블리시 리스트 다음에 등록할 메인 버전을 반환한다.
+func nextPublishVersion(publishes []Publish) Version {
+	v := Version{Main: 1}
+	for _, p := range publishes {
+		if p.Version.Main >= v.Main {
+			v.Main = p.Version.Main + 1
+		}
+	}
+	return v
+}
+
+// hasPublishVersion 함수는 퍼블리시 리스트에 같은 버전이 있는지 체크한다.
+func hasPublishVersion(publishes []Publish, v Version) bool {
+	for _, p := range publishes {
+		if p.Version == v {
+			return true
+		}
+	}
+	return false
+}
+
+// latestPublish 함수는 퍼블리시 리스트에서 가장 높은 버전을 반환한다.
+// approved가 true라면 승인된 퍼블리시 중에서 찾는다.
+func latestPublish(publishes []Publish, approved bool) (Publish, bool) {
+	var latest Publish
+	found := false
+	for _, p := range publishes {
+		if approved && !p.Approved {
+			continue
+		}
+		if !found || versionLess(latest.Version, p.Version) {
+			latest = p
+			found = true
+		}
+	}
+	return latest, found
+}
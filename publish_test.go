@@ -0,0 +1,121 @@
+package main
+
+import "testing"
+
+func TestParseVersion(t *testing.T) {
+	cases := []struct {
+		in    string
+		want  Version
+		str   string
+		valid bool
+	}{
+		{"v01", Version{Main: 1}, "v01", true},
+		{"V3", Version{Main: 3}, "v03", true},
+		{"12", Version{Main: 12}, "v12", true},
+		{"v02_w03", Version{Main: 2, Sub: 3}, "v02_w03", true},
+		{"v100", Version{Main: 100}, "v100", true},
+		{"v01_", Version{}, "", false},
+		{"w01", Version{}, "", false},
+		{"", Version{}, "", false},
+	}
+	for _, c := range cases {
+		got, err := parseVersion(c.in)
+		if (err == nil) != c.valid {
+			t.Fatalf("parseVersion(%q): %v", c.in, err)
+		}
+		if !c.valid {
+			continue
+		}
+		if got != c.want || versionString(got) != c.str {
+			t.Fatalf("parseVersion(%q) = %+v(%s), want %+v(%s)", c.in, got, versionString(got), c.want, c.str)
+		}
+	}
+}
+
+func TestCheckPublish(t *testing.T) {
+	valid := Publish{Item: "SS_0010_org", Task: "comp", Files: []string{"/show/TEMP/comp/v01.exr"}, FrameIn: 1001, FrameOut: 1100}
+	if err := checkPublish(valid); err != nil {
+		t.Fatal(err)
+	}
+	invalid := []Publish{
+		{Item: "SS_0010_org", Files: []string{"/a.exr"}},
+		{Item: "SS_0010_org", Task: "comp"},
+		{Item: "SS_0010_org", Task: "comp", Files: []string{""}},
+		{Item: "SS_0010_org", Task: "comp", Files: []string{"/a.exr"}, FrameIn: 1100, FrameOut: 1001},
+		{Item: "SS_0010_org", Task: "comp", Files: []string{"/a.exr"}, Version: Version{Main: -1}},
+	}
+	for n, p := range invalid {
+		if err := checkPublish(p); err == nil {
+			t.Fatalf("invalid[%d]: want error", n)
+		}
+	}
+}
+
+func TestLatestPublish(t *testing.T) {
+	publishes := []Publish{
+		{ID: "a", Version: Version{Main: 1}, Approved: true},
+		{ID: "b", Version: Version{Main: 2, Sub: 1}},
+		{ID: "c", Version: Version{Main: 2}, Approved: true},
+		{ID: "d", Version: Version{Main: 1, Sub: 2}},
+	}
+	if p, found := latestPublish(publishes, false); !found || p.ID != "b" {
+		t.Fatalf("got %+v", p)
+	}
+	if p, found := latestPublish(publishes, true); !found || p.ID != "c" {
+		t.Fatalf("got %+v", p)
+	}
+	if _, found := latestPublish([]Publish{{ID: "a"}}, true); found {
+		t.Fatal("want not found")
+	}
+	if v := nextPublishVersion(publishes); v != (Version{Main: 3}) {
+		t.Fatalf("got %+v", v)
+	}
+	if v := nextPublishVersion(nil); v != (Version{Main: 1}) {
+		t.Fatalf("got %+v", v)
+	}
+	if !hasPublishVersion(publishes, Version{Main: 1, Sub: 2}) || hasPublishVersion(publishes, Version{Main: 1, Sub: 1}) {
+		t.Fatal("hasPublishVersion")
+	}
+	sortPublishes(publishes)
+	var ids string
+	for _, p := range publishes {
+		ids += p.ID
+	}
+	if ids != "bcda" {
+		t.Fatalf("got %s", ids)
+	}
+}
+
+func TestDecodeV3Publish(t *testing.T) {
+	body := map[string]interface{}{
+		"task":       "comp",
+		"version":    "v02_w01",
+		"files":      []interface{}{"/show/TEMP/comp/v02_w01.exr"},
+		"framein":    1001,
+		"frameout":   1100,
+		"colorspace": "ACES - ACEScg",
+		"uses":       []interface{}{"5e8f0a"},
+		"author":     "someone",
+		"approved":   true,
+	}
+	p, err := decodeV3Publish(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Task != "comp" || p.Version != (Version{Main: 2, Sub: 1}) || len(p.Files) != 1 || p.FrameIn != 1001 || p.FrameOut != 1100 || p.Colorspace != "ACES - ACEScg" || len(p.Uses) != 1 {
+		t.Fatalf("got %+v", p)
+	}
+	// 작성자, 승인값은 요청으로 설정할 수 없다.
+	if p.Author != "" || p.Approved {
+		t.Fatalf("got %+v", p)
+	}
+	for _, b := range []map[string]interface{}{
+		{"version": 2},
+		{"version": "latest"},
+		{"files": "/a.exr"},
+	} {
+		if _, err := decodeV3Publish(b); err == nil {
+			t.Fatalf("%v: want error", b)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// /api 에러코드이다. 툴에서는 에러메시지 대신 에러코드를 비교해야 한다.
+const (
+	APIErrBadRequest           = "bad_request"            // 잘못된 요청값
+	APIErrInvalidJSON          = "invalid_json"           // JSON 문법에 맞지 않는 요청
+	APIErrUnauthorized         = "unauthorized"           // 토큰이 없거나 권한이 낮음
+	APIErrForbidden            = "forbidden"              // 허용되지 않은 요청
+	APIErrNotFound             = "not_found"              // 대상을 찾을 수 없음
+	APIErrMethodNotAllowed     = "method_not_allowed"     // 허용되지 않은 HTTP 메소드
+	APIErrConflict             = "conflict"               // 이미 존재하는 대상
+	APIErrInternal             = "internal_error"         // 서버 내부 에러
+	APIErrRequestFailed        = "request_failed"         // 처리중 실패
+	APIErrTransitionNotAllowed = "transition_not_allowed" // 상태변경 규칙에 의해 허용되지 않은 상태변경
+	APIErrPermissionDenied     = "permission_denied"      // 작업에 필요한 AccessLevel보다 낮음
+	APIErrInvalidStatus        = "invalid_status"         // 프로젝트에 등록되지 않은 status
+	APIErrInvalidDate          = "invalid_date"           // 날짜 형식이 잘못됨
+	APIErrValidationFailed     = "validation_failed"      // 일괄처리 체크 실패
+	APIErrPartialFailure       = "partial_failure"        // 일괄처리중 일부 작업 실패
+)
+
+// APIError 자료구조는 /api 응답의 에러이다.
+type APIError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// APIResponse 자료구조는 모든 /api 응답의 공통 형태이다.
+// 성공하면 Error가 null 이고, 실패하면 Error에 에러코드와 메시지가 담긴다.
+type APIResponse struct {
+	Data  interface{} `json:"data"`
+	Error *APIError   `json:"error"`
+}
+
+// apiStatusCode 함수는 HTTP 상태코드에 해당하는 에러코드를 반환한다.
+func apiStatusCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return APIErrBadRequest
+	case http.StatusUnauthorized:
+		return APIErrUnauthorized
+	case http.StatusForbidden:
+		return APIErrForbidden
+	case http.StatusNotFound:
+		return APIErrNotFound
+	case http.StatusMethodNotAllowed:
+		return APIErrMethodNotAllowed
+	case http.StatusConflict:
+		return APIErrConflict
+	case http.StatusInternalServerError:
+		return APIErrInternal
+	default:
+		return APIErrRequestFailed
+	}
+}
+
+// apiResponseWriter 는 /api 핸들러의 응답을 담아두었다가 공통 응답 형태로 바꾸어 전송하기 위해 사용한다.
+type apiResponseWriter struct {
+	header http.Header
+	status int
+	code   string
+	body   bytes.Buffer
+}
+
+func (rw *apiResponseWriter) Header() http.Header {
+	return rw.header
+}
+
+func (rw *apiResponseWriter) Write(b []byte) (int, error) {
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+	return rw.body.Write(b)
+}
+
+func (rw *apiResponseWriter) WriteHeader(status int) {
+	if rw.status == 0 {
+		rw.status = status
+	}
+}
+
+// setAPIErrorCode 함수는 /api 응답의 에러코드를 HTTP 상태코드 대신 지정한 코드로 설정한다.
+func setAPIErrorCode(w http.ResponseWriter, code string) {
+	if rw, ok := w.(*apiResponseWriter); ok {
+		rw.code = code
+	}
+}
+
+// newAPIResponse 함수는 핸들러가 작성한 상태코드와 응답을 공통 응답 형태로 바꾼다.
+// 실패는 http.Error 로 작성된 문자열, {"error":"..."} 형태의 JSON 모두 에러로 처리한다.
+// 성공시 {"data":...,"error":""} 형태의 응답은 data 값만 사용한다.
+func newAPIResponse(status int, body []byte, code string) (int, APIResponse) {
+	if status == 0 {
+		status = http.StatusOK
+	}
+	body = bytes.TrimSpace(body)
+	var data interface{}
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+	if d.Decode(&data) != nil {
+		data = string(body)
+	}
+	obj, isObject := data.(map[string]interface{})
+	var message string
+	if isObject {
+		if v, ok := obj["error"].(string); ok {
+			message = v
+		}
+	}
+	if status < http.StatusBadRequest && message != "" && code == "" {
+		status = http.StatusBadRequest
+		code = APIErrRequestFailed
+	}
+	if status >= http.StatusBadRequest || message != "" {
+		if code == "" {
+			code = apiStatusCode(status)
+		}
+		if message == "" {
+			message = string(body)
+		}
+		// 일괄처리처럼 실패하더라도 결과가 있다면 data에 함께 담는다.
+		return status, APIResponse{Data: obj["data"], Error: &APIError{Code: code, Message: message}}
+	}
+	if isObject {
+		if _, ok := obj["error"]; ok {
+			delete(obj, "error")
+			if v, ok := obj["data"]; ok && len(obj) == 1 {
+				return status, APIResponse{Data: v}
+			}
+		}
+		return status, APIResponse{Data: obj}
+	}
+	return status, APIResponse{Data: data}
+}
+
+// jsonBodyToForm 함수는 application/json 요청의 JSON 오브젝트를 r.PostForm 으로 바꾼다.
+// 기존 핸들러는 수정없이 r.PostForm 으로 JSON 요청값을 사용할 수 있다.
+// 오브젝트가 아닌 JSON(예: /api/bulk 의 리스트)은 r.Body로 그대로 전달한다.
+func jsonBodyToForm(r *http.Request) error {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") || r.Body == nil {
+		return nil
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	r.Body.Close()
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if !bytes.HasPrefix(bytes.TrimSpace(body), []byte("{")) {
+		return nil
+	}
+	obj := make(map[string]interface{})
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+	err = d.Decode(&obj)
+	if err != nil {
+		return err
+	}
+	form := url.Values{}
+	for key, value := range obj {
+		if list, ok := value.([]interface{}); ok {
+			for _, v := range list {
+				form.Add(key, jsonFormValue(v))
+			}
+			continue
+		}
+		form.Set(key, jsonFormValue(value))
+	}
+	r.PostForm = form
+	return nil
+}
+
+// jsonFormValue 함수는 JSON 값을 폼 문자열로 바꾼다.
+func jsonFormValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number, bool:
+		return fmt.Sprintf("%v", v)
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// apiHandler 함수는 /api 핸들러가 JSON 요청을 받고, 공통 응답 형태로 응답하도록 감싼다.
+func apiHandler(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		err := jsonBodyToForm(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(APIResponse{Error: &APIError{Code: APIErrInvalidJSON, Message: err.Error()}})
+			return
+		}
+		err = requestProjectAccess(r)
+		if err != nil {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(APIResponse{Error: &APIError{Code: APIErrForbidden, Message: err.Error()}})
+			return
+		}
+		rw := &apiResponseWriter{header: make(http.Header)}
+		h(rw, r)
+		// 컨택시트처럼 파일을 내려주는 응답은 공통 응답 형태로 바꾸지 않고 그대로 전송한다.
+		if rw.header.Get("Content-Disposition") != "" && rw.status < http.StatusBadRequest {
+			for k, v := range rw.header {
+				w.Header()[k] = v
+			}
+			w.Write(rw.body.Bytes())
+			return
+		}
+		for k, v := range rw.header {
+			if k == "Content-Type" || k == "Content-Length" || k == "X-Content-Type-Options" {
+				continue
+			}
+			w.Header()[k] = v
+		}
+		status, resp := newAPIResponse(rw.status, rw.body.Bytes(), rw.code)
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(resp)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"gopkg.in/mgo.v2"
+)
+
+// handleAPIBulk 함수는 JSON 리스트로 전달된 여러 아이템 수정을 한번에 처리한다.
+// 모든 작업을 먼저 체크하고, 하나라도 에러가 있다면 아무것도 적용하지 않는다.
+func handleAPIBulk(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	type recipe struct {
+		Data  []BulkResult `json:"data"`
+		Error string       `json:"error"`
+	}
+	rcp := recipe{}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, level, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	var ops []BulkOperation
+	err = json.NewDecoder(r.Body).Decode(&ops)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(ops) == 0 {
+		http.Error(w, "처리할 작업이 없습니다", http.StatusBadRequest)
+		return
+	}
+	u, err := requestUser(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	var projects []string
+	for _, op := range ops {
+		projects = append(projects, op.Project)
+	}
+	err = checkProjectAccess(u, projects)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	status := http.StatusOK
+	results, ok := validBulkOperations(session, ops, level)
+	if ok {
+		rcp.Data = applyBulkOperations(session, results, level, restEditor(r, userID))
+		for _, result := range rcp.Data {
+			if result.Error != "" {
+				rcp.Error = "일부 작업이 실패했습니다"
+				setAPIErrorCode(w, APIErrPartialFailure)
+				break
+			}
+		}
+	} else {
+		rcp.Data = results
+		rcp.Error = "체크에 실패한 작업이 있어 적용하지 않았습니다"
+		setAPIErrorCode(w, APIErrValidationFailed)
+		status = http.StatusBadRequest
+	}
+	// json 으로 결과 전송
+	data, _ := json.Marshal(rcp)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(data)
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"gopkg.in/mgo.v2"
+)
+
+// eventHeartbeat 는 연결이 끊기지 않도록 주석을 전송하는 간격이다.
+const eventHeartbeat = 30 * time.Second
+
+// handleAPIEvents 함수는 아이템 이벤트를 Server-Sent Events 로 전송한다.
+// 브라우저의 EventSource는 헤더를 설정할 수 없기 때문에 토큰이 없다면 로그인 세션을 사용한다.
+func handleAPIEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "스트리밍을 지원하지 않습니다", http.StatusInternalServerError)
+		return
+	}
+	project := r.URL.Query().Get("project")
+	u, status, err := eventAccess(r, project)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	ch := itemEvents.subscribe(eventFilter{Project: project, ID: r.URL.Query().Get("id")})
+	defer itemEvents.unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // nginx 프록시가 버퍼링하지 않도록 한다.
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "retry: 3000\n\n")
+	flusher.Flush()
+
+	ticker := time.NewTicker(eventHeartbeat)
+	defer ticker.Stop()
+	serveEvents(w, flusher, r.Context().Done(), ch, ticker.C, u, func() (User, error) {
+		u, _, err := eventAccess(r, project)
+		return u, err
+	})
+}
+
+// eventAccess 함수는 이벤트 스트림을 요청한 토큰 또는 로그인 세션이 유효한지, 구독한 프로젝트에 접근할 수 있는지 체크한다.
+// 스트림이 유지되는 동안 DB 연결을 점유하지 않도록 체크할 때마다 연결한다. 실패하면 응답할 상태코드를 함께 반환한다.
+func eventAccess(r *http.Request, project string) (User, int, error) {
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		return User{}, http.StatusInternalServerError, err
+	}
+	defer session.Close()
+	// 토큰을 사용했다면 폐기, 만료되지 않았는지 체크한다. 토큰이 없다면 로그인 세션을 사용한다.
+	if _, err := GetTokenFromHeader(r); err == nil {
+		_, _, err = TokenHandler(r, session)
+		if err != nil {
+			return User{}, http.StatusUnauthorized, err
+		}
+	}
+	u, err := requestUser(r, session)
+	if err != nil {
+		return User{}, http.StatusUnauthorized, err
+	}
+	if u.IsLeave {
+		return User{}, http.StatusUnauthorized, errUserLeave
+	}
+	if project != "" {
+		err = checkProjectAccess(u, []string{project})
+		if err != nil {
+			return User{}, http.StatusForbidden, err
+		}
+	}
+	return u, http.StatusOK, nil
+}
+
+// serveEvents 함수는 연결이 끊길 때까지 이벤트를 전송한다.
+// heartbeat 마다 recheck로 사용자와 토큰을 다시 체크하고, 실패하면 close 이벤트를 보낸 뒤 스트림을 닫는다.
+func serveEvents(w io.Writer, flusher http.Flusher, done <-chan struct{}, ch <-chan ItemEvent, heartbeat <-chan time.Time, u User, recheck func() (User, error)) {
+	for {
+		select {
+		case <-done:
+			return
+		case <-heartbeat:
+			var err error
+			u, err = recheck()
+			if err != nil {
+				data, _ := json.Marshal(map[string]string{"error": err.Error()})
+				fmt.Fprintf(w, "event: close\ndata: %s\n\n", data)
+				flusher.Flush()
+				return
+			}
+			fmt.Fprint(w, ": ping\n\n")
+			flusher.Flush()
+		case e := <-ch:
+			// project 없이 구독하면 접근할 수 있는 프로젝트의 이벤트만 전송한다.
+			if !u.CanAccessProject(e.Project) {
+				continue
+			}
+			data, err := json.Marshal(e)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: item\ndata: %s\n\n", data)
+			flusher.Flush()
+		}
+	}
+}
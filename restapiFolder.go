@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"gopkg.in/mgo.v2"
+)
+
+// handleAPIFolders 함수는 관리자 설정의 경로 템플릿으로 생성될 폴더 리스트를 반환한다. 폴더는 생성하지 않는다.
+// name이 없으면 프로젝트 폴더를, 있으면 아이템 폴더를 반환한다. DB에 없는 아이템이라면 입력값으로 경로를 만든다.
+func handleAPIFolders(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	q := r.URL.Query()
+	project := q.Get("project")
+	name := q.Get("name")
+	typ := q.Get("type")
+	p, err := getProject(session, project)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	s, err := GetAdminSetting(session)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	var folders []Folder
+	if name == "" {
+		folders, err = projectFolders(s, p)
+	} else {
+		if typ == "" {
+			typ = "org"
+		}
+		var i Item
+		i, err = getItem(session, project, name+"_"+typ)
+		if err != nil {
+			if err != mgo.ErrNotFound {
+				fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+				return
+			}
+			// 아직 등록되지 않은 아이템은 입력값으로 경로를 만든다.
+			i = Item{Project: project, Name: name, Type: typ, ID: name + "_" + typ, Assettype: q.Get("assettype")}
+			if typ != "asset" {
+				i.Seq = strings.Split(name, "_")[0]
+			}
+		}
+		folders, err = itemFolders(s, i)
+	}
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	type recipe struct {
+		Data []Folder `json:"data"`
+	}
+	rcp := recipe{}
+	rcp.Data = checkFolders(append([]Folder{}, folders...))
+	err = json.NewEncoder(w).Encode(rcp)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+}
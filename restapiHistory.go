@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"gopkg.in/mgo.v2"
+)
+
+// handleAPIHistory 함수는 아이템의 변경이력을 반환한다.
+func handleAPIHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	q := r.URL.Query()
+	project := q.Get("project")
+	id := q.Get("id")
+	if id == "" {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", "id를 입력해주세요")
+		return
+	}
+	type recipe struct {
+		Data  []History `json:"data"`
+		Error string    `json:"error"`
+	}
+	rcp := recipe{}
+	_, err = getProject(session, project)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	rcp.Data, err = Histories(session, project, id, q.Get("field"))
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	err = json.NewEncoder(w).Encode(rcp)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+}
+
+// handleAPISnapshots 함수는 아이템의 스냅샷 리스트를 반환한다.
+func handleAPISnapshots(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	q := r.URL.Query()
+	project := q.Get("project")
+	id := q.Get("id")
+	if id == "" {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", "id를 입력해주세요")
+		return
+	}
+	type recipe struct {
+		Data  []Snapshot `json:"data"`
+		Error string     `json:"error"`
+	}
+	rcp := recipe{}
+	_, err = getProject(session, project)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	rcp.Data, err = Snapshots(session, project, id)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	err = json.NewEncoder(w).Encode(rcp)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+}
+
+// handleAPIRevertItem 함수는 아이템의 필드를 입력된 시간의 스냅샷으로 되돌린다.
+func handleAPIRevertItem(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	type Recipe struct {
+		Project string   `json:"project"`
+		ID      string   `json:"id"`
+		Time    string   `json:"time"`
+		Fields  []string `json:"fields"`
+		UserID  string   `json:"userid"`
+		Item    Item     `json:"item"`
+	}
+	rcp := Recipe{}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, level, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if SupervisorAccessLevel > level {
+		http.Error(w, "권한이 낮아서 되돌릴 수 없습니다.", http.StatusUnauthorized)
+		return
+	}
+	rcp.UserID = userID
+	r.ParseForm()
+	for key, values := range r.PostForm {
+		switch key {
+		case "project":
+			v, err := PostFormValueInList(key, values)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			rcp.Project = v
+		case "id":
+			v, err := PostFormValueInList(key, values)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			rcp.ID = v
+		case "time":
+			v, err := PostFormValueInList(key, values)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			rcp.Time = v
+		case "fields", "field":
+			for _, v := range values {
+				rcp.Fields = append(rcp.Fields, Str2List(v)...)
+			}
+		default:
+			http.Error(w, key+"키는 사용할 수 없습니다.(project, id, time, fields 키값만 사용가능합니다.)", http.StatusBadRequest)
+			return
+		}
+	}
+	rcp.Item, err = RevertItem(session, rcp.Project, rcp.ID, rcp.Time, rcp.Fields, level, restEditor(r, rcp.UserID))
+	if err != nil {
+		// 허용되지 않은 상태변경이라면 403 에러를 반환한다.
+		if _, ok := err.(TransitionError); ok {
+			setAPIErrorCode(w, APIErrTransitionNotAllowed)
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	// json 으로 결과 전송
+	data, _ := json.Marshal(rcp)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
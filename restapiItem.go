@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
@@ -27,7 +28,7 @@ func handleAPIRmItemID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer session.Close()
-	_, level, err := TokenHandler(r, session)
+	userID, level, err := TokenHandler(r, session)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
@@ -62,9 +63,9 @@ func handleAPIRmItemID(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
-	err = rmItemID(session, project, id)
+	err = rmItemID(session, project, id, restEditor(r, userID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	type recipe struct {
@@ -118,7 +119,7 @@ func handleAPIItem(w http.ResponseWriter, r *http.Request) {
 	}
 	item, err := getItem(session, project, id)
 	if err != nil {
-		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		v3Error(w, err)
 		return
 	}
 	err = json.NewEncoder(w).Encode(item)
@@ -169,7 +170,7 @@ func handleAPITimeinfo(w http.ResponseWriter, r *http.Request) {
 	}
 	item, err := getItem(session, project, id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	type recipe struct {
@@ -226,7 +227,7 @@ func handleAPIRmItem(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer session.Close()
-	_, _, err = TokenHandler(r, session)
+	userID, _, err := TokenHandler(r, session)
 	if err != nil {
 		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
 		return
@@ -264,9 +265,9 @@ func handleAPIRmItem(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
-	err = rmItem(session, project, name, typ)
+	err = rmItem(session, project, name, typ, restEditor(r, userID))
 	if err != nil {
-		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		v3Error(w, err)
 		return
 	}
 	fmt.Fprintf(w, "{\"error\":\"%s\"}\n", "")
@@ -355,6 +356,9 @@ func handleAPI2Items(w http.ResponseWriter, r *http.Request) {
 		Type3d:     str2bool(q.Get("type3d")),
 		Type2d:     str2bool(q.Get("type2d")),
 	}
+	if q.Get("truestatus") != "" {
+		op.setTrueStatus(splitStatus(q.Get("truestatus")))
+	}
 	result, err := Searchv2(session, op)
 	if err != nil {
 		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
@@ -467,890 +471,34 @@ func handleAPIShots(w http.ResponseWriter, r *http.Request) {
 	}
 	q := r.URL.Query()
 	project := q.Get("project")
-	if project == "" {
-		fmt.Fprintln(w, "{\"error\":\"project 정보가 없습니다.\"}")
-		return
-	}
-	seq := q.Get("seq")
-	if seq == "" {
-		fmt.Fprintln(w, "{\"error\":\"seq 정보가 없습니다.\"}")
-		return
-	}
-	shots, err := Shots(session, project, seq)
-	if err != nil {
-		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
-		return
-	}
-	type recipe struct {
-		Data []string `json:"data"`
-	}
-	rcp := recipe{}
-	rcp.Data = shots
-	err = json.NewEncoder(w).Encode(rcp)
-	if err != nil {
-		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
-		return
-	}
-}
-
-// handleAPISetTaskMov 함수는 Task에 mov를 설정한다.
-func handleAPISetTaskMov(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		Name    string `json:"name"`
-		Task    string `json:"task"`
-		Mov     string `json:"mov"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name", "shot", "asset":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "task":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Task = v
-		case "mov": // 앞뒤샷 포함 여러개의 mov를 등록할 수 있다.
-			rcp.Mov = strings.Join(values, ";")
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		default:
-			http.Error(w, key+"키는 사용할 수 없습니다.(project, shot, asset, task, mov 키값만 사용가능합니다.)", http.StatusBadRequest)
-			return
-		}
-	}
-	rcp.Mov = dipath.Win2lin(rcp.Mov) // 내부적으로 모든 경로는 unix 경로를 사용한다.
-	err = setTaskMov(session, rcp.Project, rcp.Name, rcp.Task, rcp.Mov)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Setmov: %s %s", rcp.Task, rcp.Mov), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Setmov: %s %s\nProject: %s, Name: %s, Author: %s", rcp.Task, rcp.Mov, rcp.Project, rcp.Name, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetTaskDue 함수는 Task에 마감일을 설정한다.
-func handleAPISetTaskDue(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		Name    string `json:"name"`
-		Task    string `json:"task"`
-		Due     int    `json:"due"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "task":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Task = v
-		case "due": // 앞뒤샷 포함 여러개의 mov를 등록할 수 있다.
-			if len(values) != 1 {
-				rcp.Due = 0
-			} else {
-				num, err := strconv.Atoi(values[0])
-				if err != nil {
-					http.Error(w, err.Error(), http.StatusBadRequest)
-				}
-				rcp.Due = num
-			}
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		}
-	}
-	err = setTaskDue(session, rcp.Project, rcp.Name, rcp.Task, rcp.Due)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Due: %s %d", rcp.Task, rcp.Due), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Due: %s %d\nProject: %s, Name: %s, Author: %s", rcp.Task, rcp.Due, rcp.Project, rcp.Name, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPIRenderSize 함수는 아이템에 RenderSize를 설정한다.
-func handleAPISetRenderSize(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		Name    string `json:"name"`
-		ID      string `json:"id"`
-		Size    string `json:"size"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "size":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if !regexpImageSize.MatchString(v) {
-				http.Error(w, "2048x1152 형태로 입력해주세요", http.StatusBadRequest)
-				return
-			}
-			rcp.Size = v
-		}
-	}
-	id, err := SetImageSize(session, rcp.Project, rcp.Name, "rendersize", rcp.Size)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	rcp.ID = id
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Rendersize: %s", rcp.Size), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Rendersize: %s\nProject: %s, Name: %s, Author: %s", rcp.Size, rcp.Project, rcp.Name, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPIUnDistortionSize 함수는 아이템의 DistortionSize를 설정한다.
-func handleAPISetUnDistortionSize(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		Name    string `json:"name"`
-		ID      string `json:"id"`
-		Size    string `json:"size"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "size":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if !regexpImageSize.MatchString(v) {
-				http.Error(w, "2048x1152 형태로 입력해주세요", http.StatusBadRequest)
-				return
-			}
-			rcp.Size = v
-		}
-	}
-	id, err := SetImageSize(session, rcp.Project, rcp.Name, "dsize", rcp.Size)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	rcp.ID = id
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Undistortionsize: %s", rcp.Size), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Undistortionsize: %s\nProject: %s, Name: %s, Author: %s", rcp.Size, rcp.Project, rcp.Name, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetJustIn 함수는 아이템에 JustIn 값을 설정한다.
-func handleAPISetJustIn(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		Name    string `json:"name"`
-		Frame   int    `json:"frame"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "frame":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			n, err := strconv.Atoi(v)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Frame = n
-		}
-	}
-	err = SetFrame(session, rcp.Project, rcp.Name, "justin", rcp.Frame)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Just In: %d", rcp.Frame), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Just In: %d\nProject: %s, Name: %s, Author: %s", rcp.Frame, rcp.Project, rcp.Name, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetPlateIn 함수는 아이템에 PlateIn 값을 설정한다.
-func handleAPISetPlateIn(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		Name    string `json:"name"`
-		Frame   int    `json:"frame"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "frame":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			n, err := strconv.Atoi(v)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Frame = n
-		}
-	}
-	err = SetFrame(session, rcp.Project, rcp.Name, "platein", rcp.Frame)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Plate In: %d", rcp.Frame), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Plate In: %d\nProject: %s, Name: %s, Author: %s", rcp.Frame, rcp.Project, rcp.Name, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetPlateOut 함수는 아이템에 PlateOut 값을 설정한다.
-func handleAPISetPlateOut(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		Name    string `json:"name"`
-		Frame   int    `json:"frame"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "frame":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			n, err := strconv.Atoi(v)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Frame = n
-		}
-	}
-	err = SetFrame(session, rcp.Project, rcp.Name, "plateout", rcp.Frame)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Plate Out: %d", rcp.Frame), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Plate Out: %d\nProject: %s, Name: %s, Author: %s", rcp.Frame, rcp.Project, rcp.Name, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetScanIn 함수는 아이템에 ScanIn 값을 설정한다.
-func handleAPISetScanIn(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		Name    string `json:"name"`
-		Frame   int    `json:"frame"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "frame":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			n, err := strconv.Atoi(v)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Frame = n
-		}
-	}
-	err = SetFrame(session, rcp.Project, rcp.Name, "scanin", rcp.Frame)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Scan In: %d", rcp.Frame), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Scan In: %d\nProject: %s, Name: %s, Author: %s", rcp.Frame, rcp.Project, rcp.Name, rcp.UserID))
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
-}
-
-// handleAPISetScanOut 함수는 아이템에 ScanOut 값을 설정한다.
-func handleAPISetScanOut(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
-		return
-	}
-	type Recipe struct {
-		Project string `json:"project"`
-		Name    string `json:"name"`
-		Frame   int    `json:"frame"`
-		UserID  string `json:"userid"`
-		Error   string `json:"error"`
-	}
-	rcp := Recipe{}
-	session, err := mgo.Dial(*flagDBIP)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	host, _, err := net.SplitHostPort(r.RemoteAddr)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
-		return
-	}
-	r.ParseForm()
-	for key, values := range r.PostForm {
-		switch key {
-		case "project":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Project = v
-		case "name":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Name = v
-		case "userid":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "frame":
-			v, err := PostFormValueInList(key, values)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			n, err := strconv.Atoi(v)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Frame = n
-		}
+	if project == "" {
+		fmt.Fprintln(w, "{\"error\":\"project 정보가 없습니다.\"}")
+		return
 	}
-	err = SetFrame(session, rcp.Project, rcp.Name, "scanout", rcp.Frame)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	seq := q.Get("seq")
+	if seq == "" {
+		fmt.Fprintln(w, "{\"error\":\"seq 정보가 없습니다.\"}")
 		return
 	}
-	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Scan Out: %d", rcp.Frame), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	shots, err := Shots(session, project, seq)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
 		return
 	}
-	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Scan Out: %d\nProject: %s, Name: %s, Author: %s", rcp.Frame, rcp.Project, rcp.Name, rcp.UserID))
+	type recipe struct {
+		Data []string `json:"data"`
+	}
+	rcp := recipe{}
+	rcp.Data = shots
+	err = json.NewEncoder(w).Encode(rcp)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
 		return
 	}
-	// json 으로 결과 전송
-	data, _ := json.Marshal(rcp)
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write(data)
 }
 
-// handleAPISetScanFrame 함수는 아이템에 ScanFrame 값을 설정한다.
-func handleAPISetScanFrame(w http.ResponseWriter, r *http.Request) {
+// handleAPISetTaskMov 함수는 Task에 mov를 설정한다.
+func handleAPISetTaskMov(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
@@ -1358,7 +506,9 @@ func handleAPISetScanFrame(w http.ResponseWriter, r *http.Request) {
 	type Recipe struct {
 		Project string `json:"project"`
 		Name    string `json:"name"`
-		Frame   int    `json:"frame"`
+		Task    string `json:"task"`
+		Mov     string `json:"mov"`
+		Note    string `json:"note"`
 		UserID  string `json:"userid"`
 		Error   string `json:"error"`
 	}
@@ -1389,49 +539,57 @@ func handleAPISetScanFrame(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 			rcp.Project = v
-		case "name":
+		case "name", "shot", "asset":
 			v, err := PostFormValueInList(key, values)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
 			rcp.Name = v
-		case "userid":
+		case "task":
 			v, err := PostFormValueInList(key, values)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
-			}
-		case "frame":
+			rcp.Task = v
+		case "mov": // 앞뒤샷 포함 여러개의 mov를 등록할 수 있다.
+			rcp.Mov = strings.Join(values, ";")
+		case "note":
 			v, err := PostFormValueInList(key, values)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
-			n, err := strconv.Atoi(v)
+			rcp.Note = v
+		case "userid":
+			v, err := PostFormValueInList(key, values)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
-			rcp.Frame = n
+			if rcp.UserID == "unknown" && v != "" {
+				rcp.UserID = v
+			}
+		default:
+			http.Error(w, key+"키는 사용할 수 없습니다.(project, shot, asset, task, mov, note 키값만 사용가능합니다.)", http.StatusBadRequest)
+			return
 		}
 	}
-	err = SetFrame(session, rcp.Project, rcp.Name, "scanframe", rcp.Frame)
+	rcp.Mov = dipath.Win2lin(rcp.Mov) // 내부적으로 모든 경로는 unix 경로를 사용한다.
+	err = setTaskMov(session, rcp.Project, rcp.Name, rcp.Task, rcp.Mov, rcp.Note, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Scan Frame: %d", rcp.Frame), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Setmov: %s %s", rcp.Task, rcp.Mov), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Scan Frame: %d\nProject: %s, Name: %s, Author: %s", rcp.Frame, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Setmov: %s %s\nProject: %s, Name: %s, Author: %s", rcp.Task, rcp.Mov, rcp.Project, rcp.Name, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -1443,8 +601,51 @@ func handleAPISetScanFrame(w http.ResponseWriter, r *http.Request) {
 	w.Write(data)
 }
 
-// handleAPISetHandleIn 함수는 아이템에 HandleIn 값을 설정한다.
-func handleAPISetHandleIn(w http.ResponseWriter, r *http.Request) {
+// handleAPITaskMovs 함수는 Task의 mov 등록이력을 최신 순서로 반환한다.
+func handleAPITaskMovs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	q := r.URL.Query()
+	project := q.Get("project")
+	name := q.Get("name")
+	task := strings.ToLower(q.Get("task"))
+	if project == "" || name == "" || task == "" {
+		fmt.Fprintf(w, "{\"error\":\"%s\"}\n", "project, name, task를 입력해주세요")
+		return
+	}
+	type recipe struct {
+		Data  []MovRecord `json:"data"`
+		Error string      `json:"error"`
+	}
+	rcp := recipe{}
+	rcp.Data, err = TaskMovs(session, project, name, task)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	err = json.NewEncoder(w).Encode(rcp)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+}
+
+// handleAPISetTaskDue 함수는 Task에 마감일을 설정한다.
+func handleAPISetTaskDue(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
@@ -1452,7 +653,8 @@ func handleAPISetHandleIn(w http.ResponseWriter, r *http.Request) {
 	type Recipe struct {
 		Project string `json:"project"`
 		Name    string `json:"name"`
-		Frame   int    `json:"frame"`
+		Task    string `json:"task"`
+		Due     int    `json:"due"`
 		UserID  string `json:"userid"`
 		Error   string `json:"error"`
 	}
@@ -1490,42 +692,47 @@ func handleAPISetHandleIn(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 			rcp.Name = v
-		case "userid":
+		case "task":
 			v, err := PostFormValueInList(key, values)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
-			if rcp.UserID == "unknown" && v != "" {
-				rcp.UserID = v
+			rcp.Task = v
+		case "due": // 앞뒤샷 포함 여러개의 mov를 등록할 수 있다.
+			if len(values) != 1 {
+				rcp.Due = 0
+			} else {
+				num, err := strconv.Atoi(values[0])
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+				}
+				rcp.Due = num
 			}
-		case "frame":
+		case "userid":
 			v, err := PostFormValueInList(key, values)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
-			n, err := strconv.Atoi(v)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
+			if rcp.UserID == "unknown" && v != "" {
+				rcp.UserID = v
 			}
-			rcp.Frame = n
 		}
 	}
-	err = SetFrame(session, rcp.Project, rcp.Name, "handlein", rcp.Frame)
+	err = setTaskDue(session, rcp.Project, rcp.Name, rcp.Task, rcp.Due, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Handle In: %d", rcp.Frame), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Due: %s %d", rcp.Task, rcp.Due), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Handle In: %d\nProject: %s, Name: %s, Author: %s", rcp.Frame, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Due: %s %d\nProject: %s, Name: %s, Author: %s", rcp.Task, rcp.Due, rcp.Project, rcp.Name, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -1537,8 +744,8 @@ func handleAPISetHandleIn(w http.ResponseWriter, r *http.Request) {
 	w.Write(data)
 }
 
-// handleAPISetJustOut 함수는 아이템에 JustOut 값을 설정한다.
-func handleAPISetJustOut(w http.ResponseWriter, r *http.Request) {
+// handleAPIRenderSize 함수는 아이템에 RenderSize를 설정한다.
+func handleAPISetRenderSize(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
@@ -1546,7 +753,8 @@ func handleAPISetJustOut(w http.ResponseWriter, r *http.Request) {
 	type Recipe struct {
 		Project string `json:"project"`
 		Name    string `json:"name"`
-		Frame   int    `json:"frame"`
+		ID      string `json:"id"`
+		Size    string `json:"size"`
 		UserID  string `json:"userid"`
 		Error   string `json:"error"`
 	}
@@ -1593,33 +801,29 @@ func handleAPISetJustOut(w http.ResponseWriter, r *http.Request) {
 			if rcp.UserID == "unknown" && v != "" {
 				rcp.UserID = v
 			}
-		case "frame":
+		case "size":
 			v, err := PostFormValueInList(key, values)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
-			n, err := strconv.Atoi(v)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Frame = n
+			rcp.Size = v
 		}
 	}
-	err = SetFrame(session, rcp.Project, rcp.Name, "justout", rcp.Frame)
+	id, err := SetImageSize(session, rcp.Project, rcp.Name, "rendersize", rcp.Size, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
+	rcp.ID = id
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Just Out: %d", rcp.Frame), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Rendersize: %s", rcp.Size), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Just Out: %d\nProject: %s, Name: %s, Author: %s", rcp.Frame, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Rendersize: %s\nProject: %s, Name: %s, Author: %s", rcp.Size, rcp.Project, rcp.Name, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -1631,8 +835,8 @@ func handleAPISetJustOut(w http.ResponseWriter, r *http.Request) {
 	w.Write(data)
 }
 
-// handleAPISetHandleOut 함수는 아이템에 HandleOut 값을 설정한다.
-func handleAPISetHandleOut(w http.ResponseWriter, r *http.Request) {
+// handleAPIUnDistortionSize 함수는 아이템의 DistortionSize를 설정한다.
+func handleAPISetUnDistortionSize(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
 		return
@@ -1640,7 +844,8 @@ func handleAPISetHandleOut(w http.ResponseWriter, r *http.Request) {
 	type Recipe struct {
 		Project string `json:"project"`
 		Name    string `json:"name"`
-		Frame   int    `json:"frame"`
+		ID      string `json:"id"`
+		Size    string `json:"size"`
 		UserID  string `json:"userid"`
 		Error   string `json:"error"`
 	}
@@ -1687,33 +892,29 @@ func handleAPISetHandleOut(w http.ResponseWriter, r *http.Request) {
 			if rcp.UserID == "unknown" && v != "" {
 				rcp.UserID = v
 			}
-		case "frame":
+		case "size":
 			v, err := PostFormValueInList(key, values)
 			if err != nil {
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
-			n, err := strconv.Atoi(v)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-				return
-			}
-			rcp.Frame = n
+			rcp.Size = v
 		}
 	}
-	err = SetFrame(session, rcp.Project, rcp.Name, "handleout", rcp.Frame)
+	id, err := SetImageSize(session, rcp.Project, rcp.Name, "dsize", rcp.Size, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
+	rcp.ID = id
 	// log
-	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Handle Out: %d", rcp.Frame), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Undistortionsize: %s", rcp.Size), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	// slack log
-	err = slacklog(session, rcp.Project, fmt.Sprintf("Handle Out: %d\nProject: %s, Name: %s, Author: %s", rcp.Frame, rcp.Project, rcp.Name, rcp.UserID))
+	err = slacklog(session, rcp.Project, fmt.Sprintf("Set Undistortionsize: %s\nProject: %s, Name: %s, Author: %s", rcp.Size, rcp.Project, rcp.Name, rcp.UserID))
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -1725,6 +926,104 @@ func handleAPISetHandleOut(w http.ResponseWriter, r *http.Request) {
 	w.Write(data)
 }
 
+// handleAPISetFrame 함수는 아이템에 key에 해당하는 프레임 값을 설정하는 핸들러를 반환한다.
+// /api/setplatein, /api/setjustout 등은 /api/v3 PATCH와 같은 PatchItem을 호출하는 어댑터이다.
+func handleAPISetFrame(key, label string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+			return
+		}
+		type Recipe struct {
+			Project string `json:"project"`
+			Name    string `json:"name"`
+			Frame   int    `json:"frame"`
+			UserID  string `json:"userid"`
+			Error   string `json:"error"`
+		}
+		rcp := Recipe{}
+		session, err := mgo.Dial(*flagDBIP)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer session.Close()
+		rcp.UserID, _, err = TokenHandler(r, session)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		r.ParseForm()
+		for key, values := range r.PostForm {
+			switch key {
+			case "project":
+				v, err := PostFormValueInList(key, values)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				rcp.Project = v
+			case "name":
+				v, err := PostFormValueInList(key, values)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				rcp.Name = v
+			case "userid":
+				v, err := PostFormValueInList(key, values)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				if rcp.UserID == "unknown" && v != "" {
+					rcp.UserID = v
+				}
+			case "frame":
+				v, err := PostFormValueInList(key, values)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				n, err := strconv.Atoi(v)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				rcp.Frame = n
+			}
+		}
+		// 값 검증, 변경이력, 웹훅은 SetFrame이 사용하는 PatchItem에서 처리한다. -1은 값을 설정하지 않는다.
+		err = SetFrame(session, rcp.Project, rcp.Name, key, rcp.Frame, restEditor(r, rcp.UserID))
+		if err != nil {
+			v3Error(w, err)
+			return
+		}
+		// log
+		err = dilog.Add(*flagDBIP, host, fmt.Sprintf("%s: %d", label, rcp.Frame), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// slack log
+		err = slacklog(session, rcp.Project, fmt.Sprintf("%s: %d\nProject: %s, Name: %s, Author: %s", label, rcp.Frame, rcp.Project, rcp.Name, rcp.UserID))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		// json 으로 결과 전송
+		data, _ := json.Marshal(rcp)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}
+}
+
 // handleAPIPlateSize 함수는 아이템의 PlateSize를 설정한다.
 func handleAPISetPlateSize(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -1788,16 +1087,12 @@ func handleAPISetPlateSize(w http.ResponseWriter, r *http.Request) {
 				http.Error(w, err.Error(), http.StatusBadRequest)
 				return
 			}
-			if !regexpImageSize.MatchString(v) {
-				http.Error(w, "2048x1152 형태로 입력해주세요", http.StatusBadRequest)
-				return
-			}
 			rcp.Size = v
 		}
 	}
-	id, err := SetImageSize(session, rcp.Project, rcp.Name, "platesize", rcp.Size)
+	id, err := SetImageSize(session, rcp.Project, rcp.Name, "platesize", rcp.Size, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	rcp.ID = id
@@ -1907,9 +1202,9 @@ func handleAPISetCameraPubPath(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	err = SetCameraPubPath(session, rcp.Project, rcp.Name, rcp.Path)
+	err = SetCameraPubPath(session, rcp.Project, rcp.Name, rcp.Path, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -1996,9 +1291,9 @@ func handleAPISetCameraPubTask(w http.ResponseWriter, r *http.Request) {
 			rcp.Task = v
 		}
 	}
-	err = SetCameraPubTask(session, rcp.Project, rcp.Name, rcp.Task)
+	err = SetCameraPubTask(session, rcp.Project, rcp.Name, rcp.Task, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -2087,9 +1382,9 @@ func handleAPISetCameraProjection(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	err = SetCameraProjection(session, rcp.Project, rcp.Name, rcp.Projection)
+	err = SetCameraProjection(session, rcp.Project, rcp.Name, rcp.Projection, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -2190,9 +1485,9 @@ func handleAPISetObjectID(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	err = SetObjectID(session, rcp.Project, rcp.Name, rcp.In, rcp.Out)
+	err = SetObjectID(session, rcp.Project, rcp.Name, rcp.In, rcp.Out, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -2279,9 +1574,9 @@ func handleAPISetThummov(w http.ResponseWriter, r *http.Request) {
 			rcp.Path = v
 		}
 	}
-	err = SetThummov(session, rcp.Project, rcp.Name, rcp.Path)
+	err = SetThummov(session, rcp.Project, rcp.Name, rcp.Path, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -2367,9 +1662,9 @@ func handleAPISetBeforemov(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	err = SetBeforemov(session, rcp.Project, rcp.Name, rcp.Path)
+	err = SetBeforemov(session, rcp.Project, rcp.Name, rcp.Path, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -2455,9 +1750,9 @@ func handleAPISetAftermov(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	err = SetAftermov(session, rcp.Project, rcp.Name, rcp.Path)
+	err = SetAftermov(session, rcp.Project, rcp.Name, rcp.Path, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -2490,6 +1785,7 @@ func handleAPISetTaskStatus(w http.ResponseWriter, r *http.Request) {
 		Name    string `json:"name"`
 		Task    string `json:"task"`
 		Status  string `json:"status"`
+		Color   string `json:"color"`
 		UserID  string `json:"userid"`
 		Error   string `json:"error"`
 	}
@@ -2500,11 +1796,12 @@ func handleAPISetTaskStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer session.Close()
-	rcp.UserID, _, err = TokenHandler(r, session)
+	userID, level, err := TokenHandler(r, session)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusUnauthorized)
 		return
 	}
+	rcp.UserID = userID
 	host, _, err := net.SplitHostPort(r.RemoteAddr)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusUnauthorized)
@@ -2552,17 +1849,18 @@ func handleAPISetTaskStatus(w http.ResponseWriter, r *http.Request) {
 			rcp.Status = v
 		}
 	}
-	// task가 존재하는지 체크한다.
-	err = HasTask(session, rcp.Project, rcp.Name, rcp.Task)
+	err = SetTaskStatus(session, rcp.Project, rcp.Name, rcp.Task, rcp.Status, level, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		// 허용되지 않은 상태변경이라면 403 에러를 반환한다.
+		v3Error(w, err)
 		return
 	}
-	err = SetTaskStatus(session, rcp.Project, rcp.Name, rcp.Task, rcp.Status)
+	status, err := GetStatus(session, rcp.Project, rcp.Status)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
+	rcp.Color = status.Color
 	// log
 	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Set Task Status: %s %s", rcp.Task, rcp.Status), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
 	if err != nil {
@@ -2638,9 +1936,9 @@ func handleAPIRmTask(w http.ResponseWriter, r *http.Request) {
 			rcp.Task = v
 		}
 	}
-	rcp.Name, err = RmTask(session, rcp.Project, rcp.ID, rcp.Task)
+	rcp.Name, err = RmTask(session, rcp.Project, rcp.ID, rcp.Task, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -2740,9 +2038,9 @@ func handleAPISetAssignTask(w http.ResponseWriter, r *http.Request) {
 			rcp.Status = v
 		}
 	}
-	id, err := SetAssignTask(session, rcp.Project, rcp.Name, rcp.Task, str2bool(rcp.Status))
+	id, err := SetAssignTask(session, rcp.Project, rcp.Name, rcp.Task, str2bool(rcp.Status), restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	rcp.ID = id
@@ -2835,9 +2133,9 @@ func handleAPISetTaskUser(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	err = SetTaskUser(session, rcp.Project, rcp.Name, rcp.Task, rcp.Username)
+	err = SetTaskUser(session, rcp.Project, rcp.Name, rcp.Task, rcp.Username, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -2933,14 +2231,9 @@ func handleAPISetTaskStartdate(w http.ResponseWriter, r *http.Request) {
 			rcp.Date = v
 		}
 	}
-	err = HasTask(session, rcp.Project, rcp.Name, rcp.Task)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-	err = SetTaskStartdate(session, rcp.Project, rcp.Name, rcp.Task, rcp.Date)
+	err = SetTaskStartdate(session, rcp.Project, rcp.Name, rcp.Task, rcp.Date, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -3034,9 +2327,9 @@ func handleAPISetTaskUserNote(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	err = SetTaskUserNote(session, rcp.Project, rcp.Name, rcp.Task, rcp.UserNote)
+	err = SetTaskUserNote(session, rcp.Project, rcp.Name, rcp.Task, rcp.UserNote, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -3125,9 +2418,9 @@ func handleAPISetDeadline2D(w http.ResponseWriter, r *http.Request) {
 			rcp.Date = v
 		}
 	}
-	id, err := SetDeadline2D(session, rcp.Project, rcp.Name, rcp.Date)
+	id, err := SetDeadline2D(session, rcp.Project, rcp.Name, rcp.Date, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	rcp.ID = id
@@ -3218,9 +2511,9 @@ func handleAPISetDeadline3D(w http.ResponseWriter, r *http.Request) {
 			rcp.Date = v
 		}
 	}
-	id, err := SetDeadline3D(session, rcp.Project, rcp.Name, rcp.Date)
+	id, err := SetDeadline3D(session, rcp.Project, rcp.Name, rcp.Date, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	rcp.ID = id
@@ -3319,14 +2612,9 @@ func handleAPISetTaskPredate(w http.ResponseWriter, r *http.Request) {
 			rcp.Date = v
 		}
 	}
-	err = HasTask(session, rcp.Project, rcp.Name, rcp.Task)
+	rcp.ID, err = SetTaskPredate(session, rcp.Project, rcp.Name, rcp.Task, rcp.Date, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-	rcp.ID, err = SetTaskPredate(session, rcp.Project, rcp.Name, rcp.Task, rcp.Date)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -3423,14 +2711,9 @@ func handleAPISetTaskDate(w http.ResponseWriter, r *http.Request) {
 			rcp.Date = v
 		}
 	}
-	err = HasTask(session, rcp.Project, rcp.Name, rcp.Task)
+	err = SetTaskDate(session, rcp.Project, rcp.Name, rcp.Task, rcp.Date, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-	err = SetTaskDate(session, rcp.Project, rcp.Name, rcp.Task, rcp.Date)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -3518,9 +2801,9 @@ func handleAPISetShotType(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	id, err := SetShotType(session, rcp.Project, rcp.Name, rcp.Type)
+	id, err := SetShotType(session, rcp.Project, rcp.Name, rcp.Type, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	rcp.ID = id
@@ -3560,7 +2843,7 @@ func handleAPISetOutputName(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	defer session.Close()
-	_, _, err = TokenHandler(r, session)
+	userID, _, err := TokenHandler(r, session)
 	if err != nil {
 		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
 		return
@@ -3595,7 +2878,7 @@ func handleAPISetOutputName(w http.ResponseWriter, r *http.Request) {
 			outputname = v
 		}
 	}
-	err = SetOutputName(session, project, name, outputname)
+	err = SetOutputName(session, project, name, outputname, restEditor(r, userID))
 	if err != nil {
 		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
 		return
@@ -3667,9 +2950,9 @@ func handleAPISetRetimePlate(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	err = SetRetimePlate(session, rcp.Project, rcp.Name, rcp.Path)
+	err = SetRetimePlate(session, rcp.Project, rcp.Name, rcp.Path, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -3755,9 +3038,9 @@ func handleAPISetOCIOcc(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	err = SetOCIOcc(session, rcp.Project, rcp.Name, rcp.Path)
+	err = SetOCIOcc(session, rcp.Project, rcp.Name, rcp.Path, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -3843,9 +3126,9 @@ func handleAPISetRollmedia(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	err = SetRollmedia(session, rcp.Project, rcp.Name, rcp.Rollmedia)
+	err = SetRollmedia(session, rcp.Project, rcp.Name, rcp.Rollmedia, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -3934,9 +3217,9 @@ func handleAPISetAssetType(w http.ResponseWriter, r *http.Request) {
 			rcp.Type = v
 		}
 	}
-	id, beforeType, _, err := SetAssetType(session, rcp.Project, rcp.Name, rcp.Type)
+	id, beforeType, _, err := SetAssetType(session, rcp.Project, rcp.Name, rcp.Type, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	rcp.ID = id
@@ -4025,13 +3308,9 @@ func handleAPISetRnum(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	if rcp.Rnum != "" && !regexpRnum.MatchString(rcp.Rnum) {
-		http.Error(w, rcp.Rnum+"값은 A0001 형식이 아닙니다.", http.StatusBadRequest)
-		return
-	}
-	id, err := SetRnum(session, rcp.Project, rcp.Name, rcp.Rnum)
+	id, err := SetRnum(session, rcp.Project, rcp.Name, rcp.Rnum, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	rcp.ID = id
@@ -4118,9 +3397,9 @@ func handleAPISetScanTimecodeIn(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	err = SetScanTimecodeIn(session, rcp.Project, rcp.Name, rcp.Timecode)
+	err = SetScanTimecodeIn(session, rcp.Project, rcp.Name, rcp.Timecode, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -4206,9 +3485,9 @@ func handleAPISetScanTimecodeOut(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	err = SetScanTimecodeOut(session, rcp.Project, rcp.Name, rcp.Timecode)
+	err = SetScanTimecodeOut(session, rcp.Project, rcp.Name, rcp.Timecode, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -4294,9 +3573,9 @@ func handleAPISetJustTimecodeIn(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	err = SetJustTimecodeIn(session, rcp.Project, rcp.Name, rcp.Timecode)
+	err = SetJustTimecodeIn(session, rcp.Project, rcp.Name, rcp.Timecode, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -4382,9 +3661,9 @@ func handleAPISetJustTimecodeOut(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	err = SetJustTimecodeOut(session, rcp.Project, rcp.Name, rcp.Timecode)
+	err = SetJustTimecodeOut(session, rcp.Project, rcp.Name, rcp.Timecode, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -4472,9 +3751,9 @@ func handleAPISetFinver(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	err = SetFinver(session, rcp.Project, rcp.Name, rcp.Version)
+	err = SetFinver(session, rcp.Project, rcp.Name, rcp.Version, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -4556,9 +3835,9 @@ func handleAPISetFindate(w http.ResponseWriter, r *http.Request) {
 			rcp.Date = v
 		}
 	}
-	err = SetFindate(session, rcp.Project, rcp.Name, rcp.Date)
+	err = SetFindate(session, rcp.Project, rcp.Name, rcp.Date, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -4633,9 +3912,9 @@ func handleAPISetCrowdAsset(w http.ResponseWriter, r *http.Request) {
 			rcp.Name = v
 		}
 	}
-	id, crowdType, err := SetCrowdAsset(session, rcp.Project, rcp.Name)
+	id, crowdType, err := SetCrowdAsset(session, rcp.Project, rcp.Name, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	rcp.Crowdasset = crowdType
@@ -4729,9 +4008,9 @@ func handleAPIAddTag(w http.ResponseWriter, r *http.Request) {
 			rcp.Tag = strings.Replace(v, " ", "", -1)
 		}
 	}
-	id, err := AddTag(session, rcp.Project, rcp.Name, rcp.Tag)
+	id, err := AddTag(session, rcp.Project, rcp.Name, rcp.Tag, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	rcp.ID = id
@@ -4818,9 +4097,9 @@ func handleAPIRenameTag(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	err = RenameTag(session, rcp.Project, rcp.Before, rcp.After)
+	err = RenameTag(session, rcp.Project, rcp.Before, rcp.After, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 
@@ -4908,9 +4187,9 @@ func handleAPIRmTag(w http.ResponseWriter, r *http.Request) {
 			rcp.Tag = v
 		}
 	}
-	err = RmTag(session, rcp.Project, rcp.Name, rcp.Tag)
+	err = RmTag(session, rcp.Project, rcp.Name, rcp.Tag, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	err = dilog.Add(*flagDBIP, host, fmt.Sprintf("Rm Tag: %s", rcp.Tag), rcp.Project, rcp.Name, "csi3", rcp.UserID, 180)
@@ -5005,9 +4284,9 @@ func handleAPISetNote(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	itemName, note, err := SetNote(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Text, rcp.Overwrite)
+	itemName, note, err := SetNote(session, rcp.Project, rcp.ID, rcp.UserID, rcp.Text, rcp.Overwrite, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 
@@ -5103,9 +4382,9 @@ func handleAPIAddComment(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	rcp.Date = time.Now().Format(time.RFC3339)
-	id, err := AddComment(session, rcp.Project, rcp.Name, rcp.UserID, rcp.Date, rcp.Text, rcp.Media)
+	id, err := AddComment(session, rcp.Project, rcp.Name, rcp.UserID, rcp.Date, rcp.Text, rcp.Media, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	rcp.ID = id
@@ -5197,9 +4476,9 @@ func handleAPIEditComment(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	rcp.Name, err = EditComment(session, rcp.Project, rcp.ID, rcp.Time, rcp.Text, rcp.Media)
+	rcp.Name, err = EditComment(session, rcp.Project, rcp.ID, rcp.Time, rcp.Text, rcp.Media, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -5292,9 +4571,9 @@ func handleAPIRmComment(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	rcp.ID, rcp.Text, err = RmComment(session, rcp.Project, rcp.Name, rcp.UserID, rcp.Date)
+	rcp.ID, rcp.Text, err = RmComment(session, rcp.Project, rcp.Name, rcp.UserID, rcp.Date, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -5390,9 +4669,9 @@ func handleAPIAddSource(w http.ResponseWriter, r *http.Request) {
 			rcp.Path = v
 		}
 	}
-	id, err := AddSource(session, rcp.Project, rcp.Name, rcp.UserID, rcp.Title, rcp.Path)
+	id, err := AddSource(session, rcp.Project, rcp.Name, rcp.UserID, rcp.Title, rcp.Path, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	rcp.ID = id
@@ -5481,9 +4760,9 @@ func handleAPIRmSource(w http.ResponseWriter, r *http.Request) {
 			rcp.Title = v
 		}
 	}
-	id, err := RmSource(session, rcp.Project, rcp.Name, rcp.Title)
+	id, err := RmSource(session, rcp.Project, rcp.Name, rcp.Title, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	rcp.ID = id
@@ -5580,9 +4859,9 @@ func handleAPIAddReference(w http.ResponseWriter, r *http.Request) {
 			rcp.Path = v
 		}
 	}
-	id, err := AddReference(session, rcp.Project, rcp.Name, rcp.UserID, rcp.Title, rcp.Path)
+	id, err := AddReference(session, rcp.Project, rcp.Name, rcp.UserID, rcp.Title, rcp.Path, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	rcp.ID = id
@@ -5671,9 +4950,9 @@ func handleAPIRmReference(w http.ResponseWriter, r *http.Request) {
 			rcp.Title = v
 		}
 	}
-	id, err := RmReference(session, rcp.Project, rcp.Name, rcp.Title)
+	id, err := RmReference(session, rcp.Project, rcp.Name, rcp.Title, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	rcp.ID = id
@@ -5719,6 +4998,7 @@ func handleAPISearch(w http.ResponseWriter, r *http.Request) {
 	var project string
 	var searchword string
 	var sortkey string
+	sheet := url.Values{}
 	args := r.PostForm
 	for key, values := range args {
 		switch key {
@@ -5743,31 +5023,53 @@ func handleAPISearch(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 			sortkey = v
+		case "format", "columns", "layout", "page", "title":
+			// 컨택시트 옵션. format이 있으면 검색결과를 컨택시트 파일로 내보낸다.
+			sheet[key] = values
 		}
 	}
 	type recipe struct {
 		Data []Item `json:"data"`
 	}
 	rcp := recipe{}
+	statuses, err := AllStatuses(session, project)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
 	searchOp := SearchOption{
 		Project:    project,
 		Searchword: searchword,
 		Sortkey:    sortkey,
-		Assign:     true,
-		Ready:      true,
-		Wip:        true,
-		Confirm:    true,
-		Done:       true,
-		Omit:       true,
-		Hold:       true,
-		Out:        true,
-		None:       true,
 	}
+	// 모든 상태를 검색한다.
+	searchOp.setTrueStatus(splitStatus(StatusIDs(statuses)))
 	items, err := Searchv2(session, searchOp)
 	if err != nil {
 		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
 		return
 	}
+	if sheet.Get("format") != "" {
+		o, err := sheetOption(sheet)
+		if err != nil {
+			fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+			return
+		}
+		tasks, err := TasksettingNames(session)
+		if err != nil {
+			fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+			return
+		}
+		data, err := exportSheet(sheetCells(items, statuses, tasks, o.Columns), o)
+		if err != nil {
+			fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+			return
+		}
+		w.Header().Set("Content-Type", sheetFormats[o.Format])
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", o.filename(project)))
+		w.Write(data)
+		return
+	}
 	rcp.Data = items
 	err = json.NewEncoder(w).Encode(rcp)
 	if err != nil {
@@ -5956,9 +5258,9 @@ func handleAPISetTaskLevel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = setTaskLevel(session, rcp.Project, rcp.Name, rcp.Task, rcp.Level)
+	err = setTaskLevel(session, rcp.Project, rcp.Name, rcp.Task, rcp.Level, restEditor(r, rcp.UserID))
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// log
@@ -6043,7 +5345,7 @@ func handleAPITask(w http.ResponseWriter, r *http.Request) {
 	}
 	id, t, err := GetTask(session, rcp.Project, rcp.Name, rcp.RequestTask)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	rcp.Task = t
@@ -6104,7 +5406,7 @@ func handleAPIShottype(w http.ResponseWriter, r *http.Request) {
 	}
 	typ, err := GetShottype(session, rcp.Project, rcp.Name)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	rcp.Shottype = typ
@@ -6157,7 +5459,7 @@ func handleAPIMailInfo(w http.ResponseWriter, r *http.Request) {
 	}
 	p, err := getProject(session, rcp.Project)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	// PM 이메일이 프로젝트 정보에 기입되어있다면 PM에 이메일을 보낼 때 참조한다.
@@ -6172,7 +5474,7 @@ func handleAPIMailInfo(w http.ResponseWriter, r *http.Request) {
 	}
 	i, err := getItem(session, rcp.Project, rcp.ID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		v3Error(w, err)
 		return
 	}
 	rcp.Title = i.Name
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"gopkg.in/mgo.v2"
+)
+
+// handleAPIPaths 함수는 관리자 설정과 Tasksetting의 경로 템플릿으로 만든 프로젝트, 아이템, Task 경로를 반환한다.
+func handleAPIPaths(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	q := r.URL.Query()
+	project := q.Get("project")
+	id := q.Get("id")
+	// id 대신 샷, 에셋 이름을 사용할 수 있다.
+	if id == "" && q.Get("name") != "" {
+		typ, err := Type(session, project, q.Get("name"))
+		if err != nil {
+			fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+			return
+		}
+		id = q.Get("name") + "_" + typ
+	}
+	type recipe struct {
+		Data ItemPaths `json:"data"`
+	}
+	rcp := recipe{}
+	rcp.Data, err = Paths(session, project, id, q.Get("task"))
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	err = json.NewEncoder(w).Encode(rcp)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+}
+
+// handleAPIPathInfo 함수는 파일시스템 경로가 속한 프로젝트, 아이템, Task를 반환한다.
+func handleAPIPathInfo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	type recipe struct {
+		Data PathInfo `json:"data"`
+	}
+	rcp := recipe{}
+	rcp.Data, err = LookupPath(session, r.URL.Query().Get("path"))
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	// 프로젝트는 경로로 찾았기 때문에 찾은 프로젝트로 접근권한을 체크한다.
+	u, err := requestUser(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	err = checkProjectAccess(u, []string{rcp.Data.Project})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	err = json.NewEncoder(w).Encode(rcp)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+}
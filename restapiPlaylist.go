@@ -0,0 +1,395 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"gopkg.in/mgo.v2"
+)
+
+// handleAPIPlaylists 함수는 리뷰 플레이리스트 리스트를 최신 날짜부터 반환한다.
+func handleAPIPlaylists(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	type recipe struct {
+		Data []Playlist `json:"data"`
+	}
+	rcp := recipe{}
+	rcp.Data, err = Playlists(session, r.URL.Query().Get("date"))
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	u, err := requestUser(r, session)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	for i := range rcp.Data {
+		rcp.Data[i].Entries = accessiblePlaylistEntries(u, rcp.Data[i].Entries)
+	}
+	err = json.NewEncoder(w).Encode(rcp)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+}
+
+// handleAPIPlaylist 함수는 리뷰 플레이리스트 하나를 반환한다.
+func handleAPIPlaylist(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	type recipe struct {
+		Data Playlist `json:"data"`
+	}
+	rcp := recipe{}
+	rcp.Data, err = GetPlaylist(session, r.URL.Query().Get("id"))
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	u, err := requestUser(r, session)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	rcp.Data.Entries = accessiblePlaylistEntries(u, rcp.Data.Entries)
+	err = json.NewEncoder(w).Encode(rcp)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+}
+
+// handleAPIAddPlaylist 함수는 리뷰 플레이리스트를 추가한다.
+// entries 없이 project, searchword, task, date 중 하나라도 입력하면 검색결과의 mov로 항목을 만든다.
+func handleAPIAddPlaylist(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, level, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if LeadAccessLevel > level {
+		http.Error(w, "플레이리스트는 Lead 이상만 만들 수 있습니다", http.StatusUnauthorized)
+		return
+	}
+	type recipe struct {
+		Data Playlist `json:"data"`
+	}
+	rcp := recipe{}
+	rcp.Data.Author = userID
+	var search PlaylistSearch
+	hasEntries := false
+	r.ParseForm()
+	for key, values := range r.PostForm {
+		switch key {
+		case "name":
+			v, err := PostFormValueInList(key, values)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			rcp.Data.Name = v
+		case "date":
+			v, err := PostFormValueInList(key, values)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			rcp.Data.Date = v
+			search.Date = v
+		case "reviewers":
+			for _, v := range values {
+				rcp.Data.Reviewers = append(rcp.Data.Reviewers, Str2List(v)...)
+			}
+		case "entries":
+			rcp.Data.Entries, err = formPlaylistEntries(values)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			hasEntries = true
+		case "project", "searchword", "task":
+			v, err := PostFormValueInList(key, values)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			switch key {
+			case "project":
+				search.Project = v
+			case "searchword":
+				search.Searchword = v
+			default:
+				search.Task = v
+			}
+		default:
+			http.Error(w, key+"키는 사용할 수 없습니다.(name, date, reviewers, entries, project, searchword, task 키값만 사용가능합니다.)", http.StatusBadRequest)
+			return
+		}
+	}
+	u, err := requestUser(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if !hasEntries && search != (PlaylistSearch{}) {
+		rcp.Data.Entries, err = SearchPlaylistEntries(session, search)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// 프로젝트 없이 검색했다면 접근할 수 있는 프로젝트의 항목만 사용한다.
+		rcp.Data.Entries = accessiblePlaylistEntries(u, rcp.Data.Entries)
+	}
+	err = checkProjectAccess(u, playlistProjects(rcp.Data.Entries))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	rcp.Data, err = AddPlaylist(session, rcp.Data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	data, _ := json.Marshal(rcp)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPISetPlaylist 함수는 리뷰 플레이리스트를 수정한다. 입력한 키만 수정하며, entries는 입력한 순서로 교체한다.
+func handleAPISetPlaylist(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, level, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if LeadAccessLevel > level {
+		http.Error(w, "플레이리스트는 Lead 이상만 수정할 수 있습니다", http.StatusUnauthorized)
+		return
+	}
+	type recipe struct {
+		Data Playlist `json:"data"`
+	}
+	rcp := recipe{}
+	r.ParseForm()
+	id, err := PostFormValueInList("id", r.PostForm["id"])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rcp.Data, err = GetPlaylist(session, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	setEntries := false
+	for key, values := range r.PostForm {
+		switch key {
+		case "id":
+		case "name":
+			v, err := PostFormValueInList(key, values)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			rcp.Data.Name = v
+		case "date":
+			v, err := PostFormValueInList(key, values)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			rcp.Data.Date = v
+		case "reviewers":
+			rcp.Data.Reviewers = []string{}
+			for _, v := range values {
+				rcp.Data.Reviewers = append(rcp.Data.Reviewers, Str2List(v)...)
+			}
+		case "entries":
+			rcp.Data.Entries, err = formPlaylistEntries(values)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			setEntries = true
+		default:
+			http.Error(w, key+"키는 사용할 수 없습니다.(id, name, date, reviewers, entries 키값만 사용가능합니다.)", http.StatusBadRequest)
+			return
+		}
+	}
+	u, err := requestUser(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	// 교체하는 항목은 모두 접근할 수 있는 프로젝트여야 한다.
+	if setEntries {
+		err = checkProjectAccess(u, playlistProjects(rcp.Data.Entries))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+	rcp.Data, err = SetPlaylist(session, rcp.Data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rcp.Data.Entries = accessiblePlaylistEntries(u, rcp.Data.Entries)
+	data, _ := json.Marshal(rcp)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// handleAPIRmPlaylist 함수는 리뷰 플레이리스트를 삭제한다.
+func handleAPIRmPlaylist(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Post Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, level, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if LeadAccessLevel > level {
+		http.Error(w, "플레이리스트는 Lead 이상만 삭제할 수 있습니다", http.StatusUnauthorized)
+		return
+	}
+	id := r.FormValue("id")
+	err = RmPlaylist(session, id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "{\"data\":{\"id\":%q}}\n", id)
+}
+
+// handleAPIExportPlaylist 함수는 리뷰 플레이리스트를 RV 세션(rv), EDL(edl), 텍스트(txt) 파일로 내려받는다.
+// fps가 없다면 첫번째 항목 프로젝트의 Mov 포멧 fps를 사용하고, 그것도 없다면 24를 사용한다.
+func handleAPIExportPlaylist(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		// 웹에서 내려받을 때는 로그인 세션을 사용한다.
+		if _, err := GetSessionID(r); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+	q := r.URL.Query()
+	p, err := GetPlaylist(session, q.Get("id"))
+	if err == mgo.ErrNotFound {
+		http.Error(w, q.Get("id")+" 플레이리스트가 존재하지 않습니다", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	u, err := requestUser(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	p.Entries = accessiblePlaylistEntries(u, p.Entries)
+	format := q.Get("format")
+	if format == "" {
+		format = "rv"
+	}
+	fps := 24.0
+	if q.Get("fps") != "" {
+		fps, err = strconv.ParseFloat(q.Get("fps"), 64)
+		if err != nil {
+			http.Error(w, "fps 값이 숫자가 아닙니다", http.StatusBadRequest)
+			return
+		}
+	} else if len(p.Entries) != 0 {
+		// EDL은 편집실에서 사용하므로 편집실 Mov 포멧의 fps를 먼저 사용한다.
+		project, err := getProject(session, p.Entries[0].Project)
+		if err == nil && project.EditMov.Fps > 0 {
+			fps = project.EditMov.Fps
+		} else if err == nil && project.OutputMov.Fps > 0 {
+			fps = project.OutputMov.Fps
+		}
+	}
+	text, err := exportPlaylist(p, format, fps)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", playlistFilename(p, format)))
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, text)
+}
@@ -65,6 +65,16 @@ func handleAPIProject(w http.ResponseWriter, r *http.Request) {
 	}
 	q := r.URL.Query()
 	id := q.Get("id")
+	u, err := requestUser(r, session)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	err = checkProjectAccess(u, []string{id})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
 	project, err := getProject(session, id)
 	if err != nil {
 		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
@@ -118,6 +128,55 @@ func handleAPIProjectTags(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// accessibleProjectIDs 함수는 프로젝트 중 사용자가 접근할 수 있고 상태가 status인 프로젝트 ID를 반환한다.
+// status가 빈 문자열이면 작업중인 프로젝트를 반환한다.
+func accessibleProjectIDs(u User, projects []Project, status string) []string {
+	var results []string
+	for _, p := range projects {
+		// 사용자가 접근할 수 없는 프로젝트는 보여주지 않는다.
+		if !u.CanAccessProject(p.ID) {
+			continue
+		}
+		switch status {
+		case "test":
+			if p.Status == TestProjectStatus {
+				results = append(results, p.ID)
+			}
+		case "pre":
+			if p.Status == PreProjectStatus {
+				results = append(results, p.ID)
+			}
+		case "post":
+			if p.Status == PostProjectStatus {
+				results = append(results, p.ID)
+			}
+		case "layover":
+			if p.Status == LayoverProjectStatus {
+				results = append(results, p.ID)
+			}
+		case "backup":
+			if p.Status == BackupProjectStatus {
+				results = append(results, p.ID)
+			}
+		case "archive":
+			if p.Status == ArchiveProjectStatus {
+				results = append(results, p.ID)
+			}
+		case "lawsuit":
+			if p.Status == LawsuitProjectStatus {
+				results = append(results, p.ID)
+			}
+		default:
+			// status값이 빈 문자열이면 작업중인 프로젝트를 results 리스트에 추가한다.
+			// 작업중인 상태는 pre(프리프로덕션), post(포스트프로덕션), backup(백업중)인 상태를 뜻한다.
+			if p.Status == PreProjectStatus || p.Status == PostProjectStatus || p.Status == BackupProjectStatus {
+				results = append(results, p.ID)
+			}
+		}
+	}
+	return results
+}
+
 // handleAPIProjects 함수는 프로젝트 리스트를 반환한다.
 func handleAPIProjects(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -148,44 +207,12 @@ func handleAPIProjects(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
 		return
 	}
-	for _, p := range projectList {
-		switch qStatus {
-		case "test":
-			if p.Status == TestProjectStatus {
-				rcp.Data = append(rcp.Data, p.ID)
-			}
-		case "pre":
-			if p.Status == PreProjectStatus {
-				rcp.Data = append(rcp.Data, p.ID)
-			}
-		case "post":
-			if p.Status == PostProjectStatus {
-				rcp.Data = append(rcp.Data, p.ID)
-			}
-		case "layover":
-			if p.Status == LayoverProjectStatus {
-				rcp.Data = append(rcp.Data, p.ID)
-			}
-		case "backup":
-			if p.Status == BackupProjectStatus {
-				rcp.Data = append(rcp.Data, p.ID)
-			}
-		case "archive":
-			if p.Status == ArchiveProjectStatus {
-				rcp.Data = append(rcp.Data, p.ID)
-			}
-		case "lawsuit":
-			if p.Status == LawsuitProjectStatus {
-				rcp.Data = append(rcp.Data, p.ID)
-			}
-		default:
-			// qStatus값이 빈 문자열이면 작업중인 프로젝트를 results 리스트에 추가한다.
-			// 작업중인 상태는 pre(프리프로덕션), post(포스트프로덕션), backup(백업중)인 상태를 뜻한다.
-			if p.Status == PreProjectStatus || p.Status == PostProjectStatus || p.Status == BackupProjectStatus {
-				rcp.Data = append(rcp.Data, p.ID)
-			}
-		}
+	u, err := requestUser(r, session)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
 	}
+	rcp.Data = accessibleProjectIDs(u, projectList, qStatus)
 	err = json.NewEncoder(w).Encode(rcp)
 	if err != nil {
 		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
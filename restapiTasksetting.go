@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"html/template"
 	"net/http"
+	"strings"
 
 	"gopkg.in/mgo.v2"
 )
@@ -253,3 +254,74 @@ func handleAPICategoryTasksettings(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	w.Write(data)
 }
+
+// handleAPITaskPath 함수는 아이템 Task의 OS별 경로를 반환한다.
+// 경로는 Tasksetting의 LinuxPath, MacOSPath, WindowPath, WFSPath 템플릿에 아이템 정보를 적용하여 만든다.
+func handleAPITaskPath(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Get Only", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	defer session.Close()
+	_, _, err = TokenHandler(r, session)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	q := r.URL.Query()
+	project := q.Get("project")
+	id := q.Get("id")
+	task := strings.ToLower(q.Get("task"))
+	if task == "" {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", "task를 입력해주세요")
+		return
+	}
+	err = HasProject(session, project)
+	if err != nil {
+		v3Error(w, err)
+		return
+	}
+	// id 대신 샷, 에셋 이름을 사용할 수 있다.
+	if id == "" && q.Get("name") != "" {
+		typ, err := Type(session, project, q.Get("name"))
+		if err != nil {
+			v3Error(w, err)
+			return
+		}
+		id = q.Get("name") + "_" + typ
+	}
+	if id == "" {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", "id를 입력해주세요")
+		return
+	}
+	item, err := getItem(session, project, id)
+	if err != nil {
+		v3Error(w, err)
+		return
+	}
+	t, err := getTaskSetting(session, task+tasksettingType(item.Type))
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%s Tasksetting: %v\"}\n", task, err)
+		return
+	}
+	type recipe struct {
+		Data TaskPath `json:"data"`
+	}
+	rcp := recipe{}
+	rcp.Data, err = taskPaths(t, item, task)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+	err = json.NewEncoder(w).Encode(rcp)
+	if err != nil {
+		fmt.Fprintf(w, "{\"error\":\"%v\"}\n", err)
+		return
+	}
+}
@@ -41,6 +41,9 @@ func handleAPIUser(w http.ResponseWriter, r *http.Request) {
 	// 불필요한 정보는 초기화 시킨다.
 	user.Password = ""
 	user.Token = ""
+	user.TOTPSecret = ""
+	user.TOTPPendingSecret = ""
+	user.TOTPRecoveryCodes = nil
 	rcp.Data = user
 	err = json.NewEncoder(w).Encode(rcp)
 	if err != nil {
@@ -82,6 +85,9 @@ func handleAPISearchUser(w http.ResponseWriter, r *http.Request) {
 	for _, user := range users {
 		user.Password = ""
 		user.Token = ""
+		user.TOTPSecret = ""
+		user.TOTPPendingSecret = ""
+		user.TOTPRecoveryCodes = nil
 		rcp.Data = append(rcp.Data, user)
 	}
 	err = json.NewEncoder(w).Encode(rcp)
@@ -0,0 +1,498 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/digital-idea/dilog"
+	"gopkg.in/mgo.v2"
+)
+
+// v3Route 자료구조는 /api/v3 경로를 해석한 값이다.
+type v3Route struct {
+	Project  string // 프로젝트
+	ID       string // 아이템 ID
+	Resource string // "", tasks, comments, tags, uses, usedin, publishes
+	Key      string // Task 이름, 코멘트 등록시간, 태그, 사용하는 아이템 ID 또는 퍼블리시 ID
+}
+
+// parseV3Path 함수는 /api/v3/projects/{project}/items/{id}[/{resource}[/{key}]] 경로를 해석한다.
+func parseV3Path(path string) (v3Route, error) {
+	var route v3Route
+	path = strings.Trim(strings.TrimPrefix(path, "/api/v3"), "/")
+	parts := strings.Split(path, "/")
+	if len(parts) < 4 || len(parts) > 6 || parts[0] != "projects" || parts[2] != "items" {
+		return route, errors.New("/api/v3/projects/{project}/items/{id} 형태의 경로가 아닙니다")
+	}
+	for _, p := range parts {
+		if p == "" {
+			return route, errors.New("경로에 빈 문자열이 있습니다")
+		}
+	}
+	route.Project = parts[1]
+	route.ID = parts[3]
+	if len(parts) > 4 {
+		route.Resource = parts[4]
+		switch route.Resource {
+		case "tasks", "comments", "tags", "uses", "usedin", "publishes":
+		default:
+			return route, fmt.Errorf("%s 리소스는 지원하지 않습니다. (tasks, comments, tags, uses, usedin, publishes 만 사용가능합니다)", route.Resource)
+		}
+	}
+	if len(parts) > 5 {
+		route.Key = parts[5]
+	}
+	return route, nil
+}
+
+// decodeV3Body 함수는 /api/v3 요청의 JSON 오브젝트를 가지고 온다.
+func decodeV3Body(r *http.Request) (map[string]interface{}, error) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	obj := make(map[string]interface{})
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+	err = d.Decode(&obj)
+	if err != nil {
+		return nil, fmt.Errorf("JSON 오브젝트를 읽을 수 없습니다: %v", err)
+	}
+	return obj, nil
+}
+
+// v3StringValue 함수는 JSON 오브젝트에서 key에 해당하는 문자열을 가지고 온다.
+func v3StringValue(obj map[string]interface{}, key string) (string, bool, error) {
+	v, found := obj[key]
+	if !found {
+		return "", false, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", true, fmt.Errorf("%s 값은 문자열이어야 합니다", key)
+	}
+	return s, true, nil
+}
+
+// apiErrorStatus 함수는 에러에 맞는 HTTP 상태코드와 /api 에러코드를 반환한다.
+func apiErrorStatus(err error) (int, string) {
+	switch err.(type) {
+	case NotFoundError:
+		return http.StatusNotFound, APIErrNotFound
+	case TransitionError:
+		return http.StatusForbidden, APIErrTransitionNotAllowed
+	case v3AccessError:
+		return http.StatusForbidden, APIErrPermissionDenied
+	case InvalidStatusError:
+		return http.StatusBadRequest, APIErrInvalidStatus
+	case InvalidDateError:
+		return http.StatusBadRequest, APIErrInvalidDate
+	}
+	if err == mgo.ErrNotFound {
+		return http.StatusNotFound, APIErrNotFound
+	}
+	return http.StatusBadRequest, APIErrBadRequest
+}
+
+// v3Error 함수는 에러에 맞는 상태코드와 에러코드로 /api/v3 에러를 응답한다.
+// 아이템을 수정하는 기존 /api 핸들러도 같은 함수로 에러를 응답한다.
+func v3Error(w http.ResponseWriter, err error) {
+	status, code := apiErrorStatus(err)
+	setAPIErrorCode(w, code)
+	if err == mgo.ErrNotFound {
+		http.Error(w, "아이템이 존재하지 않습니다", status)
+		return
+	}
+	http.Error(w, err.Error(), status)
+}
+
+// handleAPIv3 함수는 리소스 경로를 사용하는 /api/v3 요청을 처리한다.
+//
+// GET, PATCH /api/v3/projects/{project}/items/{id}
+// GET /api/v3/projects/{project}/items/{id}/tasks
+// GET, PATCH /api/v3/projects/{project}/items/{id}/tasks/{task}
+// GET, POST /api/v3/projects/{project}/items/{id}/comments
+// PATCH, DELETE /api/v3/projects/{project}/items/{id}/comments/{date}
+// GET, POST, PUT /api/v3/projects/{project}/items/{id}/tags
+// DELETE /api/v3/projects/{project}/items/{id}/tags/{tag}
+// GET, POST /api/v3/projects/{project}/items/{id}/uses
+// DELETE /api/v3/projects/{project}/items/{id}/uses/{target}
+// GET /api/v3/projects/{project}/items/{id}/usedin
+// GET, POST /api/v3/projects/{project}/items/{id}/publishes
+// GET, PATCH, DELETE /api/v3/projects/{project}/items/{id}/publishes/{publish}
+func handleAPIv3(w http.ResponseWriter, r *http.Request) {
+	route, err := parseV3Path(r.URL.Path)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	session, err := mgo.Dial(*flagDBIP)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer session.Close()
+	userID, level, err := TokenHandler(r, session)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	err = HasProject(session, route.Project)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	item, err := getItem(session, route.Project, route.ID)
+	if err != nil {
+		v3Error(w, err)
+		return
+	}
+	var body map[string]interface{}
+	if r.Method == http.MethodPatch || r.Method == http.MethodPost || r.Method == http.MethodPut {
+		body, err = decodeV3Body(r)
+		if err != nil {
+			setAPIErrorCode(w, APIErrInvalidJSON)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	editor := restEditor(r, userID)
+	var result interface{}
+	switch route.Resource {
+	case "":
+		if route.Key != "" {
+			http.Error(w, "잘못된 경로입니다", http.StatusNotFound)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			result = item
+		case http.MethodPatch:
+			result, err = PatchItem(session, route.Project, route.ID, body, editor)
+		default:
+			http.Error(w, "GET, PATCH Only", http.StatusMethodNotAllowed)
+			return
+		}
+	case "tasks":
+		if route.Key == "" {
+			if r.Method != http.MethodGet {
+				http.Error(w, "GET Only", http.StatusMethodNotAllowed)
+				return
+			}
+			result = item.Tasks
+			break
+		}
+		switch r.Method {
+		case http.MethodGet:
+			t, found := item.Tasks[strings.ToLower(route.Key)]
+			if !found {
+				http.Error(w, fmt.Sprintf("%s Task가 존재하지 않습니다", route.Key), http.StatusNotFound)
+				return
+			}
+			result = t
+		case http.MethodPatch:
+			result, err = PatchTask(session, route.Project, route.ID, route.Key, body, level, editor)
+		default:
+			http.Error(w, "GET, PATCH Only", http.StatusMethodNotAllowed)
+			return
+		}
+	case "comments":
+		result, err = v3Comments(session, r.Method, route, item, body, userID, editor)
+	case "tags":
+		result, err = v3Tags(session, r.Method, route, item, body, editor)
+	case "uses":
+		result, err = v3Uses(session, r.Method, route, item, body, editor)
+	case "usedin":
+		if r.Method != http.MethodGet || route.Key != "" {
+			http.Error(w, "GET Only", http.StatusMethodNotAllowed)
+			return
+		}
+		result, err = UsedIn(session, route.Project, route.ID)
+	case "publishes":
+		result, err = v3Publishes(session, r, route, body, userID, level, editor)
+	}
+	if err == errV3MethodNotAllowed {
+		http.Error(w, err.Error(), http.StatusMethodNotAllowed)
+		return
+	}
+	if err != nil {
+		v3Error(w, err)
+		return
+	}
+	if r.Method != http.MethodGet {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		// log
+		err = dilog.Add(*flagDBIP, host, fmt.Sprintf("%s %s", r.Method, r.URL.Path), route.Project, item.Name, "csi3", userID, 180)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	// json 으로 결과 전송
+	data, err := json.Marshal(result)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// v3AccessError 는 요청한 작업에 필요한 AccessLevel보다 토큰의 AccessLevel이 낮을 때 발생하는 에러이다.
+type v3AccessError struct {
+	Action string
+	Level  AccessLevel
+}
+
+func (e v3AccessError) Error() string {
+	return fmt.Sprintf("%s 은(는) AccessLevel %d 이상만 할 수 있습니다", e.Action, e.Level)
+}
+
+// errV3MethodNotAllowed 는 리소스에서 지원하지 않는 HTTP 메소드로 요청했을 때 발생하는 에러이다.
+var errV3MethodNotAllowed = errors.New("지원하지 않는 HTTP 메소드입니다")
+
+// v3Comments 함수는 아이템의 코멘트 리소스 요청을 처리하고 처리후 코멘트 리스트를 반환한다.
+// 코멘트는 등록시간(date)으로 구분한다.
+func v3Comments(session *mgo.Session, method string, route v3Route, item Item, body map[string]interface{}, userID string, editor Editor) ([]Comment, error) {
+	var err error
+	switch {
+	case method == http.MethodGet && route.Key == "":
+		return item.Comments, nil
+	case method == http.MethodPost && route.Key == "":
+		text, _, err := v3StringValue(body, "text")
+		if err != nil {
+			return nil, err
+		}
+		if text == "" {
+			return nil, errors.New("text 값이 빈 문자열입니다")
+		}
+		media, _, err := v3StringValue(body, "media")
+		if err != nil {
+			return nil, err
+		}
+		_, err = AddComment(session, route.Project, item.Name, userID, time.Now().Format(time.RFC3339), text, media, editor)
+		if err != nil {
+			return nil, err
+		}
+	case method == http.MethodPatch && route.Key != "":
+		var comment Comment
+		found := false
+		for _, c := range item.Comments {
+			if c.Date == route.Key {
+				comment = c
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%s 에 등록된 코멘트가 없습니다", route.Key)
+		}
+		// 요청에 없는 값은 기존값을 유지한다.
+		if v, found, err := v3StringValue(body, "text"); err != nil {
+			return nil, err
+		} else if found {
+			comment.Text = v
+		}
+		if v, found, err := v3StringValue(body, "media"); err != nil {
+			return nil, err
+		} else if found {
+			comment.Media = v
+		}
+		_, err = EditComment(session, route.Project, route.ID, route.Key, comment.Text, comment.Media, editor)
+		if err != nil {
+			return nil, err
+		}
+	case method == http.MethodDelete && route.Key != "":
+		_, _, err = RmComment(session, route.Project, item.Name, userID, route.Key, editor)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errV3MethodNotAllowed
+	}
+	i, err := getItem(session, route.Project, route.ID)
+	if err != nil {
+		return nil, err
+	}
+	return i.Comments, nil
+}
+
+// v3Tags 함수는 아이템의 태그 리소스 요청을 처리하고 처리후 태그 리스트를 반환한다.
+func v3Tags(session *mgo.Session, method string, route v3Route, item Item, body map[string]interface{}, editor Editor) ([]string, error) {
+	var err error
+	switch {
+	case method == http.MethodGet && route.Key == "":
+		return item.Tag, nil
+	case method == http.MethodPost && route.Key == "":
+		tag, _, err := v3StringValue(body, "tag")
+		if err != nil {
+			return nil, err
+		}
+		if tag == "" {
+			return nil, errors.New("tag 값이 빈 문자열입니다")
+		}
+		_, err = AddTag(session, route.Project, item.Name, tag, editor)
+		if err != nil {
+			return nil, err
+		}
+	case method == http.MethodPut && route.Key == "":
+		if _, found := body["tags"]; !found {
+			return nil, errors.New("tags 값이 필요합니다")
+		}
+		_, err = PatchItem(session, route.Project, route.ID, map[string]interface{}{"tag": body["tags"]}, editor)
+		if err != nil {
+			return nil, err
+		}
+	case method == http.MethodDelete && route.Key != "":
+		err = RmTag(session, route.Project, item.Name, route.Key, editor)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errV3MethodNotAllowed
+	}
+	i, err := getItem(session, route.Project, route.ID)
+	if err != nil {
+		return nil, err
+	}
+	return i.Tag, nil
+}
+
+// v3Uses 함수는 아이템이 사용하는 에셋, 샷 리소스 요청을 처리하고 처리후 의존성 리스트를 반환한다.
+// 추가할 때 target 에는 아이템 ID 또는 이름을 사용할 수 있다.
+func v3Uses(session *mgo.Session, method string, route v3Route, item Item, body map[string]interface{}, editor Editor) ([]Dependency, error) {
+	var err error
+	switch {
+	case method == http.MethodGet && route.Key == "":
+		if item.Uses == nil {
+			return []Dependency{}, nil
+		}
+		return item.Uses, nil
+	case method == http.MethodPost && route.Key == "":
+		target, _, err := v3StringValue(body, "target")
+		if err != nil {
+			return nil, err
+		}
+		if target == "" {
+			return nil, errors.New("target 값이 빈 문자열입니다")
+		}
+		kind, _, err := v3StringValue(body, "kind")
+		if err != nil {
+			return nil, err
+		}
+		item, err = AddDependency(session, route.Project, route.ID, target, kind, editor)
+		if err != nil {
+			return nil, err
+		}
+	case method == http.MethodDelete && route.Key != "":
+		item, err = RmDependency(session, route.Project, route.ID, route.Key, editor)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errV3MethodNotAllowed
+	}
+	if item.Uses == nil {
+		return []Dependency{}, nil
+	}
+	return item.Uses, nil
+}
+
+// v3Publishes 함수는 아이템의 퍼블리시 리소스 요청을 처리한다.
+// 퍼블리시 ID 대신 latest를 사용하면 task의 가장 높은 버전을 가지고 온다. approved=true 이면 승인된 퍼블리시 중에서 찾는다.
+func v3Publishes(session *mgo.Session, r *http.Request, route v3Route, body map[string]interface{}, userID string, level AccessLevel, editor Editor) (interface{}, error) {
+	q := r.URL.Query()
+	switch {
+	case r.Method == http.MethodGet && route.Key == "":
+		return Publishes(session, route.Project, route.ID, q.Get("task"))
+	case r.Method == http.MethodGet && route.Key == "latest":
+		if q.Get("task") == "" {
+			return nil, errors.New("task 값이 필요합니다")
+		}
+		return LatestPublish(session, route.Project, route.ID, q.Get("task"), str2bool(q.Get("approved")))
+	case r.Method == http.MethodPost && route.Key == "":
+		p, err := decodeV3Publish(body)
+		if err != nil {
+			return nil, err
+		}
+		p.Item = route.ID
+		p.Author = userID
+		return AddPublish(session, route.Project, p, editor)
+	}
+	if route.Key == "" {
+		return nil, errV3MethodNotAllowed
+	}
+	p, err := GetPublish(session, route.Project, route.Key)
+	if err != nil {
+		return nil, err
+	}
+	if p.Item != route.ID {
+		return nil, mgo.ErrNotFound
+	}
+	switch r.Method {
+	case http.MethodGet:
+		return p, nil
+	case http.MethodPatch:
+		if level < SupervisorAccessLevel {
+			return nil, v3AccessError{Action: "퍼블리시 승인", Level: SupervisorAccessLevel}
+		}
+		v, found := body["approved"]
+		approved, ok := v.(bool)
+		if !found || !ok {
+			return nil, errors.New("approved 값은 true 또는 false 이어야 합니다")
+		}
+		return ApprovePublish(session, route.Project, p.ID, approved, userID)
+	case http.MethodDelete:
+		if level < PmAccessLevel {
+			return nil, v3AccessError{Action: "퍼블리시 삭제", Level: PmAccessLevel}
+		}
+		err = RmPublish(session, route.Project, p.ID, editor)
+		if err != nil {
+			return nil, err
+		}
+		return Publishes(session, route.Project, route.ID, p.Task)
+	}
+	return nil, errV3MethodNotAllowed
+}
+
+// decodeV3Publish 함수는 JSON 오브젝트로 등록할 퍼블리시를 만든다.
+// version은 "v02", "v02_w01" 형태의 문자열이며 없다면 다음 버전을 사용한다.
+func decodeV3Publish(body map[string]interface{}) (Publish, error) {
+	var p Publish
+	version, _, err := v3StringValue(body, "version")
+	if err != nil {
+		return p, err
+	}
+	fields := make(map[string]interface{})
+	for _, k := range []string{"task", "files", "framein", "frameout", "colorspace", "comment", "uses"} {
+		if v, found := body[k]; found {
+			fields[k] = v
+		}
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return p, err
+	}
+	err = json.Unmarshal(data, &p)
+	if err != nil {
+		return p, fmt.Errorf("퍼블리시 값을 읽을 수 없습니다: %v", err)
+	}
+	if version != "" {
+		p.Version, err = parseVersion(version)
+		if err != nil {
+			return p, err
+		}
+	}
+	return p, nil
+}
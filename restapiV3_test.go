@@ -0,0 +1,78 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gopkg.in/mgo.v2"
+)
+
+func TestParseV3Path(t *testing.T) {
+	cases := []struct {
+		path string
+		want v3Route
+		err  bool
+	}{
+		{path: "/api/v3/projects/TEMP/items/SS_0010_org", want: v3Route{Project: "TEMP", ID: "SS_0010_org"}},
+		{path: "/api/v3/projects/TEMP/items/SS_0010_org/", want: v3Route{Project: "TEMP", ID: "SS_0010_org"}},
+		{path: "/api/v3/projects/TEMP/items/SS_0010_org/tasks", want: v3Route{Project: "TEMP", ID: "SS_0010_org", Resource: "tasks"}},
+		{path: "/api/v3/projects/TEMP/items/SS_0010_org/tasks/comp", want: v3Route{Project: "TEMP", ID: "SS_0010_org", Resource: "tasks", Key: "comp"}},
+		{path: "/api/v3/projects/TEMP/items/SS_0010_org/comments/2020-01-01T00:00:00+09:00", want: v3Route{Project: "TEMP", ID: "SS_0010_org", Resource: "comments", Key: "2020-01-01T00:00:00+09:00"}},
+		{path: "/api/v3/projects/TEMP/items/SS_0010_org/tags/fx", want: v3Route{Project: "TEMP", ID: "SS_0010_org", Resource: "tags", Key: "fx"}},
+		{path: "/api/v3/projects/TEMP/items/SS_0010_org/uses/stone01_asset", want: v3Route{Project: "TEMP", ID: "SS_0010_org", Resource: "uses", Key: "stone01_asset"}},
+		{path: "/api/v3/projects/TEMP/items/stone01_asset/usedin", want: v3Route{Project: "TEMP", ID: "stone01_asset", Resource: "usedin"}},
+		{path: "/api/v3/projects/TEMP/items/SS_0010_org/publishes/latest", want: v3Route{Project: "TEMP", ID: "SS_0010_org", Resource: "publishes", Key: "latest"}},
+		{path: "/api/v3/projects/TEMP", err: true},
+		{path: "/api/v3/projects/TEMP/shots/SS_0010_org", err: true},
+		{path: "/api/v3/projects/TEMP/items/SS_0010_org/links", err: true},
+		{path: "/api/v3/projects//items/SS_0010_org", err: true},
+		{path: "/api/v3/projects/TEMP/items/SS_0010_org/tags/fx/bg", err: true},
+	}
+	for _, c := range cases {
+		got, err := parseV3Path(c.path)
+		if (err != nil) != c.err {
+			t.Fatalf("parseV3Path(%s): 얻은 에러 %v", c.path, err)
+		}
+		if err == nil && got != c.want {
+			t.Fatalf("parseV3Path(%s): 얻은 값 %v, 원하는 값 %v", c.path, got, c.want)
+		}
+	}
+}
+
+func TestV3ErrorCode(t *testing.T) {
+	_, statusErr := findStatus([]Status{{ID: "wip"}}, "unknown")
+	_, dateErr := toFullTime("내일")
+	_, patchDateErr := patchTask(Task{Title: "comp"}, map[string]interface{}{"date": "2020-01-01"})
+	cases := []struct {
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{err: mgo.ErrNotFound, wantStatus: http.StatusNotFound, wantCode: APIErrNotFound},
+		{err: NotFoundError("TEMP 프로젝트가 존재하지 않습니다."), wantStatus: http.StatusNotFound, wantCode: APIErrNotFound},
+		{err: TransitionError{From: "wip", To: "done"}, wantStatus: http.StatusForbidden, wantCode: APIErrTransitionNotAllowed},
+		{err: v3AccessError{Action: "Task 수정", Level: PmAccessLevel}, wantStatus: http.StatusForbidden, wantCode: APIErrPermissionDenied},
+		{err: statusErr, wantStatus: http.StatusBadRequest, wantCode: APIErrInvalidStatus},
+		{err: dateErr, wantStatus: http.StatusBadRequest, wantCode: APIErrInvalidDate},
+		{err: patchDateErr, wantStatus: http.StatusBadRequest, wantCode: APIErrInvalidDate},
+		{err: errors.New("잘못된 값"), wantStatus: http.StatusBadRequest, wantCode: APIErrBadRequest},
+	}
+	for _, c := range cases {
+		h := apiHandler(func(w http.ResponseWriter, r *http.Request) {
+			v3Error(w, c.err)
+		})
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest(http.MethodPost, "/api/settaskstatus", nil))
+		var resp APIResponse
+		err := json.Unmarshal(w.Body.Bytes(), &resp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if w.Code != c.wantStatus || resp.Error == nil || resp.Error.Code != c.wantCode {
+			t.Fatalf("v3Error(%v): 얻은 값 %d %v, 원하는 값 %d %s", c.err, w.Code, resp.Error, c.wantStatus, c.wantCode)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewAPIResponse(t *testing.T) {
+	cases := []struct {
+		status     int
+		body       string
+		code       string
+		wantStatus int
+		wantCode   string // 빈 문자열이면 에러가 없어야 한다.
+	}{
+		{status: 0, body: `{"project":"TEMP","name":"SS_0010"}`, wantStatus: http.StatusOK},
+		{status: http.StatusOK, body: `{"data":[1,2],"error":""}`, wantStatus: http.StatusOK},
+		{status: http.StatusOK, body: `{"error":"프로젝트가 존재하지 않습니다"}`, wantStatus: http.StatusBadRequest, wantCode: APIErrRequestFailed},
+		{status: http.StatusUnauthorized, body: "token error\n", wantStatus: http.StatusUnauthorized, wantCode: APIErrUnauthorized},
+		{status: http.StatusMethodNotAllowed, body: "Post Only\n", wantStatus: http.StatusMethodNotAllowed, wantCode: APIErrMethodNotAllowed},
+		{status: http.StatusForbidden, body: "transition\n", code: APIErrTransitionNotAllowed, wantStatus: http.StatusForbidden, wantCode: APIErrTransitionNotAllowed},
+		{status: http.StatusOK, body: `{"data":[{"index":0}],"error":"일부 작업이 실패했습니다"}`, code: APIErrPartialFailure, wantStatus: http.StatusOK, wantCode: APIErrPartialFailure},
+	}
+	for _, c := range cases {
+		status, resp := newAPIResponse(c.status, []byte(c.body), c.code)
+		if status != c.wantStatus {
+			t.Fatalf("newAPIResponse(%d, %s): 얻은 상태코드 %d, 원하는 상태코드 %d", c.status, c.body, status, c.wantStatus)
+		}
+		if c.wantCode == "" {
+			if resp.Error != nil || resp.Data == nil {
+				t.Fatalf("newAPIResponse(%d, %s): 성공 응답이 아닙니다: %v", c.status, c.body, resp)
+			}
+			continue
+		}
+		if resp.Error == nil || resp.Error.Code != c.wantCode {
+			t.Fatalf("newAPIResponse(%d, %s): 얻은 값 %v, 원하는 에러코드 %s", c.status, c.body, resp.Error, c.wantCode)
+		}
+	}
+	// {"data":...,"error":""} 형태는 data 값만 사용한다.
+	_, resp := newAPIResponse(http.StatusOK, []byte(`{"data":["a"],"error":""}`), "")
+	if list, ok := resp.Data.([]interface{}); !ok || len(list) != 1 {
+		t.Fatalf("newAPIResponse: data 값이 올바르지 않습니다: %v", resp.Data)
+	}
+}
+
+func TestAPIHandlerJSONBody(t *testing.T) {
+	h := apiHandler(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.PostForm.Get("project") != "TEMP" || r.PostForm.Get("frame") != "1001" || len(r.PostForm["tags"]) != 2 {
+			http.Error(w, "폼으로 변환되지 않았습니다", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(`{"project":"TEMP"}`))
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/test", strings.NewReader(`{"project":"TEMP","frame":1001,"tags":["a","b"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h(w, req)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"error":null`) {
+		t.Fatalf("apiHandler: 얻은 값 %d %s", w.Code, w.Body.String())
+	}
+	req = httptest.NewRequest(http.MethodPost, "/api/test", strings.NewReader(`{"project":`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	h(w, req)
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), APIErrInvalidJSON) {
+		t.Fatalf("apiHandler: 얻은 값 %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAPIHandlerFile(t *testing.T) {
+	h := apiHandler(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", "attachment; filename=TEMP_contactsheet.pdf")
+		w.Write([]byte("%PDF-1.4"))
+	})
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodPost, "/api/search", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "%PDF-1.4" || w.Header().Get("Content-Type") != "application/pdf" {
+		t.Fatalf("apiHandler: 얻은 값 %d %s %s", w.Code, w.Header().Get("Content-Type"), w.Body.String())
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ReviewNote 자료구조는 리뷰모드에서 플레이리스트 항목 하나에 입력한 노트와 변경할 상태이다.
+type ReviewNote struct {
+	PlaylistEntry
+	Text   string `json:"text"`   // 노트. 아이템 코멘트로 등록된다.
+	Status string `json:"status"` // 변경할 Task 상태 ID. 빈 문자열이면 상태를 바꾸지 않는다.
+}
+
+// ReviewResult 자료구조는 리뷰 노트 하나를 처리한 결과이다.
+type ReviewResult struct {
+	ReviewNote
+	Comment      string `json:"comment"`      // 등록된 코멘트 시간. 등록하지 않았다면 빈 문자열이다.
+	StatusBefore string `json:"statusbefore"` // 변경전 Task 상태 ID
+	Error        string `json:"error"`        // 에러 메시지
+}
+
+// reviewFormKeys 는 리뷰모드 폼에서 항목마다 반복되는 키이다.
+var reviewFormKeys = []string{"project", "item", "name", "task", "mov", "note", "status"}
+
+// reviewNotes 함수는 리뷰모드 폼 값을 리뷰 노트 리스트로 바꾼다.
+// 항목마다 반복되는 키는 같은 순서로 같은 갯수만큼 전달되어야 하며, 노트와 상태가 모두 빈 항목은 제외한다.
+func reviewNotes(form url.Values) ([]ReviewNote, error) {
+	n := len(form["item"])
+	for _, key := range reviewFormKeys {
+		if len(form[key]) != n {
+			return nil, fmt.Errorf("%s 값이 %d개가 아닙니다", key, n)
+		}
+	}
+	notes := []ReviewNote{}
+	for i := 0; i < n; i++ {
+		note := ReviewNote{
+			PlaylistEntry: PlaylistEntry{
+				Project: form["project"][i],
+				Item:    form["item"][i],
+				Name:    form["name"][i],
+				Task:    strings.ToLower(form["task"][i]),
+				Mov:     form["mov"][i],
+			},
+			Text:   strings.TrimSpace(form["note"][i]),
+			Status: form["status"][i],
+		}
+		if note.Text == "" && note.Status == "" {
+			continue
+		}
+		if note.Project == "" || note.Item == "" || note.Task == "" {
+			return nil, fmt.Errorf("%d번째 항목에 project, item, task 값이 필요합니다", i+1)
+		}
+		notes = append(notes, note)
+	}
+	return notes, nil
+}
+
+// reviewCommentText 함수는 리뷰 노트를 아이템 코멘트 내용으로 바꾼다. 어떤 Task의 노트인지 알 수 있도록 Task 이름을 붙인다.
+func reviewCommentText(note ReviewNote) string {
+	return fmt.Sprintf("[%s] %s", note.Task, note.Text)
+}
+
+// reviewCommentDates 함수는 리뷰 노트마다 코멘트 등록시간을 만든다.
+// 코멘트는 등록시간으로 구분하므로 같은 아이템의 노트는 1초씩 늦춰서 등록시간이 겹치지 않게 한다.
+func reviewCommentDates(notes []ReviewNote, now time.Time) []string {
+	dates := make([]string, len(notes))
+	count := make(map[string]int)
+	for i, note := range notes {
+		if note.Text == "" {
+			continue
+		}
+		key := note.Project + "/" + note.Item
+		dates[i] = now.Add(time.Duration(count[key]) * time.Second).Format(time.RFC3339)
+		count[key]++
+	}
+	return dates
+}
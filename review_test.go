@@ -0,0 +1,57 @@
+package main
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestReviewNotes(t *testing.T) {
+	form := url.Values{
+		"project": {"TEMP", "TEMP", "TEMP"},
+		"item":    {"SS_0010_org", "SS_0020_org", "SS_0030_org"},
+		"name":    {"SS_0010", "SS_0020", "SS_0030"},
+		"task":    {"Comp", "fx", "comp"},
+		"mov":     {"/a.mov", "/b.mov", "/c.mov"},
+		"note":    {" 색 보정 필요 ", "", ""},
+		"status":  {"", "", "4"},
+	}
+	notes, err := reviewNotes(form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 노트와 상태가 모두 빈 항목은 제외한다.
+	if len(notes) != 2 || notes[0].Task != "comp" || notes[0].Text != "색 보정 필요" || notes[0].Mov != "/a.mov" || notes[1].Item != "SS_0030_org" || notes[1].Status != "4" {
+		t.Fatalf("got %+v", notes)
+	}
+	form["mov"] = []string{"/a.mov"}
+	if _, err := reviewNotes(form); err == nil {
+		t.Fatal("want count error")
+	}
+	form = url.Values{
+		"project": {""}, "item": {"SS_0010_org"}, "name": {""}, "task": {"comp"}, "mov": {""}, "note": {"a"}, "status": {""},
+	}
+	if _, err := reviewNotes(form); err == nil {
+		t.Fatal("want project error")
+	}
+	if got := reviewCommentText(ReviewNote{PlaylistEntry: PlaylistEntry{Task: "comp"}, Text: "색 보정 필요"}); got != "[comp] 색 보정 필요" {
+		t.Fatalf("got %s", got)
+	}
+}
+
+func TestReviewCommentDates(t *testing.T) {
+	now := time.Date(2016, 12, 6, 10, 0, 0, 0, time.UTC)
+	notes := []ReviewNote{
+		{PlaylistEntry: PlaylistEntry{Project: "TEMP", Item: "SS_0010_org", Task: "comp"}, Text: "a"},
+		{PlaylistEntry: PlaylistEntry{Project: "TEMP", Item: "SS_0010_org", Task: "fx"}, Status: "4"},
+		{PlaylistEntry: PlaylistEntry{Project: "TEMP", Item: "SS_0020_org", Task: "comp"}, Text: "b"},
+		{PlaylistEntry: PlaylistEntry{Project: "TEMP", Item: "SS_0010_org", Task: "fx"}, Text: "c"},
+	}
+	got := reviewCommentDates(notes, now)
+	want := []string{"2016-12-06T10:00:00Z", "", "2016-12-06T10:00:00Z", "2016-12-06T10:00:01Z"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
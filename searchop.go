@@ -2,8 +2,11 @@ package main
 
 import (
 	"encoding/base64"
+	"errors"
 	"log"
 	"net/http"
+	"sort"
+	"strings"
 
 	"gopkg.in/mgo.v2"
 )
@@ -25,6 +28,8 @@ type SearchOption struct {
 	Hold    bool
 	Out     bool
 	None    bool
+	// 프로젝트 상태설정에서 체크된 상태ID 리스트
+	TrueStatus []string
 	// 요소
 	Shot   bool
 	Assets bool
@@ -46,18 +51,6 @@ func (op *SearchOption) setStatusAll() {
 	op.None = true
 }
 
-func (op *SearchOption) setStatusDefault() {
-	op.Assign = true
-	op.Ready = true
-	op.Wip = true
-	op.Confirm = true
-	op.Done = false
-	op.Omit = false
-	op.Hold = false
-	op.Out = false
-	op.None = false
-}
-
 func (op *SearchOption) setStatusNone() {
 	op.Assign = false
 	op.Ready = false
@@ -72,12 +65,69 @@ func (op *SearchOption) setStatusNone() {
 
 // isStatusOff 메소드는 모든 상태가 꺼저 있는지 체크한다.
 func (op *SearchOption) isStatusOff() bool {
+	if len(op.TrueStatus) != 0 {
+		return false
+	}
 	if op.Assign || op.Ready || op.Wip || op.Confirm || op.Done || op.Omit || op.Hold || op.Out || op.None {
 		return false
 	}
 	return true
 }
 
+// checkedStatus 메소드는 검색에 사용할 상태ID 리스트를 반환한다.
+// TrueStatus가 비어있다면 기존 상태 옵션을 상태ID로 변환한다.
+func (op *SearchOption) checkedStatus() []string {
+	if len(op.TrueStatus) != 0 {
+		return op.TrueStatus
+	}
+	ids := []string{}
+	for id, on := range map[string]bool{
+		ASSIGN:  op.Assign,
+		READY:   op.Ready,
+		WIP:     op.Wip,
+		CONFIRM: op.Confirm,
+		DONE:    op.Done,
+		OMIT:    op.Omit,
+		HOLD:    op.Hold,
+		OUT:     op.Out,
+		NONE:    op.None,
+	} {
+		if on {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// setTrueStatus 메소드는 상태ID 리스트를 TrueStatus로 설정하고 기존 상태 옵션도 맞춘다.
+func (op *SearchOption) setTrueStatus(ids []string) {
+	op.TrueStatus = ids
+	op.setStatusNone()
+	for _, id := range ids {
+		switch id {
+		case ASSIGN:
+			op.Assign = true
+		case READY:
+			op.Ready = true
+		case WIP:
+			op.Wip = true
+		case CONFIRM:
+			op.Confirm = true
+		case DONE:
+			op.Done = true
+		case OMIT:
+			op.Omit = true
+		case HOLD:
+			op.Hold = true
+		case OUT:
+			op.Out = true
+		case NONE:
+			op.None = true
+		}
+	}
+}
+
 func handleRequestToSearchOption(r *http.Request) SearchOption {
 	q := r.URL.Query()
 	op := SearchOption{
@@ -96,9 +146,27 @@ func handleRequestToSearchOption(r *http.Request) SearchOption {
 		Out:        str2bool(q.Get("out")),
 		None:       str2bool(q.Get("none")),
 	}
+	// truestatus 값이 있다면 기존 상태 옵션보다 우선한다.
+	if _, found := q["truestatus"]; found {
+		op.setTrueStatus(splitStatus(q.Get("truestatus")))
+	} else {
+		op.setTrueStatus(op.checkedStatus())
+	}
 	return op
 }
 
+// splitStatus 함수는 ","로 구분된 상태ID 문자열을 리스트로 바꾼다.
+func splitStatus(str string) []string {
+	ids := []string{}
+	for _, id := range strings.Split(str, ",") {
+		if id == "" {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
 // LoadCookie 메소드는 request에 이미 설정된 쿠키값을을 SearchOption 자료구조에 추가한다.
 func (op *SearchOption) LoadCookie(session *mgo.Session, r *http.Request) error {
 	for _, cookie := range r.Cookies() {
@@ -146,11 +214,27 @@ func (op *SearchOption) LoadCookie(session *mgo.Session, r *http.Request) error
 			op.Template = cookie.Value
 		}
 	}
-	if op.Project == "" {
-		plist, err := Projectlist(session)
+	for _, cookie := range r.Cookies() {
+		if cookie.Name == "TrueStatus" {
+			op.setTrueStatus(splitStatus(cookie.Value))
+		}
+	}
+	plist, err := Projectlist(session)
+	if err != nil {
+		return err
+	}
+	// 로그인 사용자가 접근할 수 없는 프로젝트는 쿠키에 있더라도 사용하지 않는다.
+	if ssid, err := GetSessionID(r); err == nil {
+		u, err := getUser(session, ssid.ID)
 		if err != nil {
 			return err
 		}
+		plist = u.AccessibleProjects(plist)
+	}
+	if op.Project == "" || !hasString(plist, op.Project) {
+		if len(plist) == 0 {
+			return errors.New("접근할 수 있는 프로젝트가 없습니다")
+		}
 		op.Project = plist[0]
 	}
 	if op.Template == "" {
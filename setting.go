@@ -7,7 +7,30 @@ type Setting struct {
 	RunScriptAfterEditUserProfile string `json:"runscriptafteredituserprofile"` // 사용자 정보 수정후 실행될 쉘스크립트
 	ExcludeProject                string `json:"excludeproject"`                // Search옵션에 제외할 프로젝트명, 마이그레이션 시 사용한다.
 	OCIOConfig                    string `json:"ocioconfig"`                    // OpenColorIO Config Path 설정
+	AutoThumbnail                 bool   `json:"autothumbnail"`                 // 아이템 생성, 썸네일 mov 변경시 썸네일을 자동 생성한다.
+	ThumbnailDecoder              string `json:"thumbnaildecoder"`              // mov, exr 등에서 이미지를 추출할 명령어 템플릿 예) ffmpeg -y -i {{.Input}} -vf select=eq(n\,{{.Frame}}) -frames:v 1 {{.Output}}
+	LDAP                          bool   `json:"ldap"`                          // LDAP/Active Directory 로그인 사용
+	LDAPURL                       string `json:"ldapurl"`                       // LDAP 서버 URL 예) ldaps://dc.example.com:636
+	LDAPInsecureSkipVerify        bool   `json:"ldapinsecureskipverify"`        // ldaps 인증서 검증을 하지 않는다. 사설 인증서를 사용할 때 설정한다.
+	LDAPBindDN                    string `json:"ldapbinddn"`                    // 사용자 검색에 사용할 계정 DN. 비어있으면 익명으로 검색한다.
+	LDAPBindPassword              string `json:"ldapbindpassword"`              // 사용자 검색에 사용할 계정 패스워드
+	LDAPBaseDN                    string `json:"ldapbasedn"`                    // 사용자 검색 Base DN 예) ou=people,dc=example,dc=com
+	LDAPUserFilter                string `json:"ldapuserfilter"`                // 사용자 검색 필터 예) (sAMAccountName={{.ID}})
+	LDAPGroupAccessLevel          string `json:"ldapgroupaccesslevel"`          // 그룹:AccessLevel 매핑. 한줄에 하나씩 입력한다.
+	LDAPGroupOrganization         string `json:"ldapgrouporganization"`         // 그룹:Division,Department,Team 매핑. 한줄에 하나씩 입력한다.
+	OIDC                          bool   `json:"oidc"`                          // OpenID Connect SSO 로그인 사용
+	OIDCIssuer                    string `json:"oidcissuer"`                    // OIDC Issuer URL 예) https://sso.example.com/realms/studio
+	OIDCClientID                  string `json:"oidcclientid"`                  // OIDC Client ID
+	OIDCClientSecret              string `json:"oidcclientsecret"`              // OIDC Client Secret
+	OIDCRedirectURL               string `json:"oidcredirecturl"`               // Provider에 등록한 Redirect URL 예) https://csi.example.com/oidc_callback
+	OIDCScopes                    string `json:"oidcscopes"`                    // 요청할 scope. 비어있으면 openid profile email
+	OIDCIDClaim                   string `json:"oidcidclaim"`                   // CSI 사용자 ID로 사용할 클레임. 비어있으면 preferred_username
+	OIDCGroupsClaim               string `json:"oidcgroupsclaim"`               // 그룹 클레임. 비어있으면 groups
+	OIDCGroupAccessLevel          string `json:"oidcgroupaccesslevel"`          // 그룹:AccessLevel 매핑. 한줄에 하나씩 입력한다.
+	OIDCGroupOrganization         string `json:"oidcgrouporganization"`         // 그룹:Division,Department,Team 매핑. 한줄에 하나씩 입력한다.
+	TOTPAccessLevel               int    `json:"totpaccesslevel"`               // 이 AccessLevel 이상인 사용자는 TOTP 2단계 인증을 해야 한다. 0이면 TOTP를 등록한 사용자만 2단계 인증을 한다.
 	Umask                         string `json:"umask"`                         // Umask 값. 예) 0002
+	AutoMkdir                     bool   `json:"automkdir"`                     // 프로젝트, 아이템 생성시 아래 경로 템플릿으로 폴더를 자동 생성한다.
 	RootPath                      string `json:"rootpath"`                      // Root경로 예) /show
 	ProjectPath                   string `json:"projectpath"`                   // Project경로 예) /show/{{.Project}}
 	ProjectPathPermission         string `json:"projectpathpermission"`         // Project경로의 권한
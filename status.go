@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Status 자료구조는 프로젝트별로 사용하는 작업상태를 정의한다.
+type Status struct {
+	ID        string  `json:"id"`        // DB에 저장되는 상태값. 기존 상태는 "0"~"9"를 사용한다.
+	Name      string  `json:"name"`      // 상태이름. 검색어, REST API에서 사용한다. 예) wip
+	Color     string  `json:"color"`     // 상태색상. 예) #77BB40
+	Order     float64 `json:"order"`     // 정렬순서. 값이 클수록 아이템 상태로 우선 선택된다.
+	Done      bool    `json:"done"`      // 진행률 계산시 완료로 간주할지 여부
+	Excluded  bool    `json:"excluded"`  // 진행률 계산시 전체 갯수에서 제외할지 여부
+	DefaultOn bool    `json:"defaulton"` // 검색창에서 기본으로 체크되는 상태인지 여부
+}
+
+// DefaultStatuses 함수는 기존 상태상수와 동일한 기본 상태리스트를 반환한다.
+// 상태가 설정되지 않은 프로젝트는 이 리스트로 마이그레이션된다.
+func DefaultStatuses() []Status {
+	return []Status{
+		{ID: OMIT, Name: "omit", Color: "#FC8F55", Order: 8, Excluded: true},
+		{ID: CONFIRM, Name: "confirm", Color: "#54D6FD", Order: 7, DefaultOn: true},
+		{ID: WIP, Name: "wip", Color: "#77BB40", Order: 6, DefaultOn: true},
+		{ID: READY, Name: "ready", Color: "#BEEF37", Order: 5, DefaultOn: true},
+		{ID: ASSIGN, Name: "assign", Color: "#FFF76B", Order: 4, DefaultOn: true},
+		{ID: OUT, Name: "out", Color: "#EEA4F1", Order: 3},
+		{ID: DONE, Name: "done", Color: "#F0F1F0", Order: 2, Done: true},
+		{ID: HOLD, Name: "hold", Color: "#989898", Order: 1, Done: true},
+		{ID: NONE, Name: "none", Color: "#CCCDCC", Order: 0, Excluded: true},
+	}
+}
+
+// findStatus 함수는 상태리스트에서 ID 또는 이름이 일치하는 상태를 찾는다.
+func findStatus(statuses []Status, key string) (Status, error) {
+	for _, s := range statuses {
+		if s.ID == key || s.Name == strings.ToLower(key) {
+			return s, nil
+		}
+	}
+	return Status{}, InvalidStatusError(key + " 은(는) 올바른 status가 아닙니다")
+}
+
+// defaultOnStatusIDs 함수는 검색창에서 기본으로 체크되는 상태ID 리스트를 반환한다.
+func defaultOnStatusIDs(statuses []Status) []string {
+	var ids []string
+	for _, s := range statuses {
+		if s.DefaultOn {
+			ids = append(ids, s.ID)
+		}
+	}
+	return ids
+}
+
+// Transition 자료구조는 상태를 변경할 수 있는 규칙이다.
+// 프로젝트에 규칙이 하나도 없다면 모든 상태변경을 허용한다.
+type Transition struct {
+	From        string      `json:"from"`        // 변경전 상태ID. "*"는 모든 상태를 뜻한다.
+	To          string      `json:"to"`          // 변경후 상태ID. "*"는 모든 상태를 뜻한다.
+	AccessLevel AccessLevel `json:"accesslevel"` // 상태변경에 필요한 최소 AccessLevel
+}
+
+// TransitionError 는 허용되지 않은 상태변경시 발생하는 에러이다.
+type TransitionError struct {
+	From        string
+	To          string
+	AccessLevel AccessLevel // 상태변경에 필요한 최소 AccessLevel, 규칙이 없다면 UnknownAccessLevel
+}
+
+func (e TransitionError) Error() string {
+	if e.AccessLevel == UnknownAccessLevel {
+		return fmt.Sprintf("%s 상태에서 %s 상태로 변경할 수 없습니다", e.From, e.To)
+	}
+	return fmt.Sprintf("%s 상태에서 %s 상태로 변경하려면 AccessLevel %d 이상이 필요합니다", e.From, e.To, e.AccessLevel)
+}
+
+// transitionLevel 함수는 from 상태에서 to 상태로 변경할 때 필요한 최소 AccessLevel을 반환한다.
+// 변경을 허용하는 규칙이 없다면 false를 반환한다.
+func transitionLevel(transitions []Transition, from, to string) (AccessLevel, bool) {
+	found := false
+	level := AdminAccessLevel
+	for _, t := range transitions {
+		if t.From != from && t.From != "*" {
+			continue
+		}
+		if t.To != to && t.To != "*" {
+			continue
+		}
+		if !found || t.AccessLevel < level {
+			level = t.AccessLevel
+		}
+		found = true
+	}
+	return level, found
+}
+
+// checkTransition 함수는 사용자 AccessLevel로 from 상태에서 to 상태로 변경할 수 있는지 체크한다.
+func checkTransition(statuses []Status, transitions []Transition, from, to string, level AccessLevel) error {
+	if len(transitions) == 0 || from == to {
+		return nil
+	}
+	need, found := transitionLevel(transitions, from, to)
+	if found && level >= need {
+		return nil
+	}
+	err := TransitionError{
+		From: StatusName(statuses, from),
+		To:   StatusName(statuses, to),
+	}
+	if found {
+		err.AccessLevel = need
+	}
+	return err
+}
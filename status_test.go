@@ -0,0 +1,87 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestUpdateStatus(t *testing.T) {
+	statuses := append(DefaultStatuses(), Status{ID: "10", Name: "review", Order: 6.5})
+	cases := []struct {
+		statuses []Status
+		tasks    map[string]Task
+		want     string
+	}{{
+		statuses: statuses,
+		tasks:    map[string]Task{},
+		want:     NONE,
+	}, {
+		statuses: statuses,
+		tasks:    map[string]Task{"comp": Task{Status: WIP}, "fx": Task{Status: ASSIGN}},
+		want:     WIP,
+	}, {
+		statuses: statuses,
+		tasks:    map[string]Task{"comp": Task{Status: WIP}, "fx": Task{Status: "10"}},
+		want:     "10",
+	}, {
+		statuses: nil, // 상태리스트가 없다면 문자열을 비교한다.
+		tasks:    map[string]Task{"comp": Task{Status: WIP}, "fx": Task{Status: CONFIRM}},
+		want:     CONFIRM,
+	}}
+	for _, c := range cases {
+		item := Item{Tasks: c.tasks}
+		item.updateStatus(c.statuses)
+		if item.Status != c.want {
+			t.Fatalf("updateStatus(%v): 얻은 값 %v, 원하는 값 %v", c.tasks, item.Status, c.want)
+		}
+	}
+}
+
+func TestCalculatePercent(t *testing.T) {
+	statuses := DefaultStatuses()
+	var i Infobarnum
+	i.addStatusNum(DONE, 3)
+	i.addStatusNum(HOLD, 1)
+	i.addStatusNum(WIP, 4)
+	i.addStatusNum(OMIT, 1)
+	i.addStatusNum(NONE, 1)
+	i.Total = 10
+	i.calculatePercent(statuses)
+	if i.Finished != 4 || i.Workload != 8 || i.Percent != 50 {
+		t.Fatalf("calculatePercent: 얻은 값 %d/%d(%v%%), 원하는 값 4/8(50%%)", i.Finished, i.Workload, i.Percent)
+	}
+	if i.Done != 3 || i.Wip != 4 {
+		t.Fatalf("addStatusNum: 기본 상태 필드가 갱신되지 않았습니다")
+	}
+}
+
+func TestCheckTransition(t *testing.T) {
+	statuses := DefaultStatuses()
+	transitions := []Transition{
+		{From: ASSIGN, To: READY, AccessLevel: ArtistAccessLevel},
+		{From: READY, To: WIP, AccessLevel: ArtistAccessLevel},
+		{From: WIP, To: READY, AccessLevel: ArtistAccessLevel},
+		{From: "*", To: CONFIRM, AccessLevel: SupervisorAccessLevel},
+		{From: CONFIRM, To: DONE, AccessLevel: SupervisorAccessLevel},
+	}
+	cases := []struct {
+		transitions []Transition
+		from        string
+		to          string
+		level       AccessLevel
+		want        bool
+	}{
+		{transitions: nil, from: WIP, to: DONE, level: ArtistAccessLevel, want: true}, // 규칙이 없다면 모두 허용한다.
+		{transitions: transitions, from: WIP, to: WIP, level: GuestAccessLevel, want: true},
+		{transitions: transitions, from: READY, to: WIP, level: ArtistAccessLevel, want: true},
+		{transitions: transitions, from: WIP, to: DONE, level: AdminAccessLevel, want: false},
+		{transitions: transitions, from: WIP, to: CONFIRM, level: ArtistAccessLevel, want: false},
+		{transitions: transitions, from: WIP, to: CONFIRM, level: SupervisorAccessLevel, want: true},
+		{transitions: transitions, from: CONFIRM, to: DONE, level: LeadAccessLevel, want: false},
+	}
+	for _, c := range cases {
+		err := checkTransition(statuses, c.transitions, c.from, c.to, c.level)
+		if (err == nil) != c.want {
+			t.Fatalf("checkTransition(%v, %v, %v): 얻은 값 %v, 원하는 값 %v", c.from, c.to, c.level, err, c.want)
+		}
+	}
+}
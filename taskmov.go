@@ -0,0 +1,62 @@
+package main
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// movVersion 함수는 mov 파일명에서 버전을 구한다.
+// 파일명에서 regexpVersion에 해당하는 문자열 중 v로 시작하는 마지막 값을 사용하며, 없다면 0을 반환한다.
+// 여러개의 mov가 ;로 구분되어 있다면 첫번째 mov를 사용한다.
+func movVersion(mov string) int {
+	mov = strings.TrimSpace(strings.Split(mov, ";")[0])
+	base := strings.TrimSuffix(filepath.Base(mov), filepath.Ext(mov))
+	version := 0
+	for _, v := range regexpVersion.FindAllString(base, -1) {
+		if !strings.HasPrefix(v, "v") && !strings.HasPrefix(v, "V") {
+			continue
+		}
+		n, err := strconv.Atoi(v[1:])
+		if err != nil {
+			continue
+		}
+		version = n
+	}
+	return version
+}
+
+// newMovRecord 함수는 mov 등록이력을 만든다.
+func newMovRecord(mov, note, author string, now time.Time) MovRecord {
+	return MovRecord{
+		Mov:     mov,
+		Date:    now.Format(time.RFC3339),
+		Author:  author,
+		Version: movVersion(mov),
+		Note:    note,
+	}
+}
+
+// taskMovs 함수는 Task의 mov 등록이력을 반환한다.
+// 이력을 기록하기 전에 등록된 mov는 Mov, Mdate 값으로 이력 하나를 만든다.
+func taskMovs(t Task) []MovRecord {
+	if len(t.Movs) != 0 {
+		return append([]MovRecord{}, t.Movs...)
+	}
+	if t.Mov == "" {
+		return []MovRecord{}
+	}
+	return []MovRecord{{Mov: t.Mov, Date: t.Mdate, Version: movVersion(t.Mov)}}
+}
+
+// taskMovOnDate 함수는 date(2016-12-05 형식)에 등록된 mov 중 마지막 mov를 반환한다.
+func taskMovOnDate(t Task, date string) (MovRecord, bool) {
+	movs := taskMovs(t)
+	for i := len(movs) - 1; i >= 0; i-- {
+		if ToNormalTime(movs[i].Date) == date {
+			return movs[i], true
+		}
+	}
+	return MovRecord{}, false
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMovVersion(t *testing.T) {
+	cases := map[string]int{
+		"/show/TEMP/review/SS_0010_comp_v003.mov":         3,
+		"/show/TEMP/review/SS_0010_comp_V12.mov":          12,
+		"/show/TEMP/review/SS_0010_v01_comp_v02.mov":      2,
+		"/show/TEMP/review/SS_0010_comp.mov":              0,
+		"/show/v1/SS_0010_comp.mov":                       0,
+		"/a/SS_0010_comp_v04.mov;/a/SS_0020_comp_v09.mov": 4,
+		"": 0,
+	}
+	for mov, want := range cases {
+		if got := movVersion(mov); got != want {
+			t.Fatalf("%s: got %d, want %d", mov, got, want)
+		}
+	}
+	r := newMovRecord("/a/SS_0010_comp_v05.mov", "retime", "kim", time.Date(2016, 12, 5, 10, 0, 0, 0, time.UTC))
+	if r.Version != 5 || r.Date != "2016-12-05T10:00:00Z" || r.Author != "kim" || r.Note != "retime" {
+		t.Fatalf("got %+v", r)
+	}
+}
+
+func TestTaskMovs(t *testing.T) {
+	if got := taskMovs(Task{}); len(got) != 0 {
+		t.Fatalf("got %+v", got)
+	}
+	// 이력이 없는 Task는 Mov, Mdate로 이력을 만든다.
+	legacy := Task{Mov: "/a/comp_v02.mov", Mdate: "2016-12-05T10:00:00+09:00"}
+	got := taskMovs(legacy)
+	if len(got) != 1 || got[0].Mov != legacy.Mov || got[0].Date != legacy.Mdate || got[0].Version != 2 {
+		t.Fatalf("got %+v", got)
+	}
+	task := Task{
+		Mov:   "/a/comp_v03.mov",
+		Mdate: "2016-12-06T11:00:00+09:00",
+		Movs: []MovRecord{
+			{Mov: "/a/comp_v01.mov", Date: "2016-12-05T10:00:00+09:00"},
+			{Mov: "/a/comp_v02.mov", Date: "2016-12-05T18:00:00+09:00"},
+			{Mov: "/a/comp_v03.mov", Date: "2016-12-06T11:00:00+09:00"},
+		},
+	}
+	r, found := taskMovOnDate(task, "2016-12-05")
+	if !found || r.Mov != "/a/comp_v02.mov" {
+		t.Fatalf("got %+v", r)
+	}
+	if _, found := taskMovOnDate(task, "2016-12-07"); found {
+		t.Fatal("want not found")
+	}
+	// 과거 날짜의 데일리는 그날 등록된 mov를 사용한다.
+	items := []Item{{Project: "TEMP", ID: "SS_0010_org", Name: "SS_0010", Tasks: map[string]Task{"comp": task}}}
+	entries := playlistEntries(items, []string{"comp"}, "", "2016-12-05")
+	if len(entries) != 1 || entries[0].Mov != "/a/comp_v02.mov" {
+		t.Fatalf("got %+v", entries)
+	}
+	entries = playlistEntries(items, []string{"comp"}, "", "")
+	if len(entries) != 1 || entries[0].Mov != "/a/comp_v03.mov" {
+		t.Fatalf("got %+v", entries)
+	}
+}
@@ -1,5 +1,13 @@
 package main
 
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"strings"
+	"text/template"
+)
+
 // Tasksetting 자료구조이다
 type Tasksetting struct {
 	ID         string            `json:"id"`         // Task ID. name + type 이다. shot 태스크와 asset 태스크 모두 같다.
@@ -12,4 +20,181 @@ type Tasksetting struct {
 	Attributes map[string]string `json:"attributes"` // Task에 필요한 속성추가. 예) 특정 Task는 멀티 퍼브리쉬 경로가 발생할 수 있다.
 	Order      float64           `json:"order"`      // Task 순서. 드로잉시 정렬되는 순서이다.
 	Category   string            `json:"category"`   // Fx Task중 water, fire, smoke 같은 테스크가 존재할 때 각 Task가 하나의 카테고리로 묶어야 하는 상황이 생긴다. 예) FX 관련 Task를 구할 때
+	Folders    []TaskFolder      `json:"folders"`    // Task가 배정될 때 LinuxPath 아래에 생성되는 폴더 구조
+}
+
+// TaskFolder 자료구조는 Task 경로 아래에 생성되는 폴더 하나이다.
+type TaskFolder struct {
+	Path       string `json:"path"`       // LinuxPath 기준 상대경로. 예) pub/images, {{.Name}}_precomp
+	Permission string `json:"permission"` // 8진수 권한. 빈 문자열이면 0775를 사용한다.
+	UID        string `json:"uid"`        // 소유자 ID
+	GID        string `json:"gid"`        // 그룹 ID
+}
+
+// TaskPath 자료구조는 아이템 Task의 OS별 경로이다.
+type TaskPath struct {
+	Project string `json:"project"`
+	ID      string `json:"id"`
+	Task    string `json:"task"`
+	Linux   string `json:"linux"`
+	MacOS   string `json:"macos"`
+	Windows string `json:"windows"`
+	WFS     string `json:"wfs"`
+}
+
+// taskPathData 자료구조는 Tasksetting 경로 템플릿에서 사용하는 변수이다.
+type taskPathData struct {
+	Project   string // 프로젝트 코드
+	Task      string // 태스크
+	Name      string // 샷이름(SS_0010), 에셋이름
+	Seq       string // 시퀀스 SS
+	Cut       string // 컷 0010
+	Type      string // 타입: shot, asset
+	Assettype string // 에셋타입
+}
+
+// tasksettingType 함수는 아이템 타입을 Tasksetting 타입(shot, asset)으로 바꾼다.
+func tasksettingType(itemType string) string {
+	if itemType == "asset" {
+		return "asset"
+	}
+	return "shot"
+}
+
+// newTaskPathData 함수는 아이템과 Task 이름으로 경로 템플릿 변수를 만든다.
+func newTaskPathData(i Item, task string) taskPathData {
+	return taskPathData{
+		Project:   i.Project,
+		Task:      task,
+		Name:      i.Name,
+		Seq:       i.Seq,
+		Cut:       i.Cut,
+		Type:      tasksettingType(i.Type),
+		Assettype: i.Assettype,
+	}
+}
+
+// renderPath 함수는 경로 템플릿에 data를 적용한다. 빈 템플릿이면 빈 문자열을 반환한다.
+func renderPath(name, tmpl string, data interface{}) (string, error) {
+	if tmpl == "" {
+		return "", nil
+	}
+	t, err := template.New(name).Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("%s: %v", name, err)
+	}
+	var b bytes.Buffer
+	err = t.Execute(&b, data)
+	if err != nil {
+		return "", fmt.Errorf("%s: %v", name, err)
+	}
+	return b.String(), nil
+}
+
+// taskPaths 함수는 아이템 Task의 OS별 경로를 반환한다.
+func taskPaths(t Tasksetting, i Item, task string) (TaskPath, error) {
+	data := newTaskPathData(i, task)
+	p := TaskPath{Project: i.Project, ID: i.ID, Task: task}
+	var err error
+	p.Linux, err = renderPath("linuxpath", t.LinuxPath, data)
+	if err != nil {
+		return p, err
+	}
+	p.MacOS, err = renderPath("macospath", t.MacOSPath, data)
+	if err != nil {
+		return p, err
+	}
+	p.Windows, err = renderPath("windowpath", t.WindowPath, data)
+	if err != nil {
+		return p, err
+	}
+	p.WFS, err = renderPath("wfspath", t.WFSPath, data)
+	if err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+// taskFolders 함수는 Task 경로(LinuxPath) 아래에 생성할 폴더 리스트를 반환한다.
+// LinuxPath가 비어있다면 상대경로를 만들 수 없기 때문에 에러를 반환한다.
+func taskFolders(t Tasksetting, i Item, task string) ([]Folder, error) {
+	if len(t.Folders) == 0 {
+		return nil, nil
+	}
+	data := newTaskPathData(i, task)
+	root, err := renderPath("linuxpath", t.LinuxPath, data)
+	if err != nil {
+		return nil, err
+	}
+	if root == "" {
+		return nil, fmt.Errorf("%s Tasksetting에 Linux Path가 없어 폴더를 생성할 수 없습니다", t.ID)
+	}
+	var results []Folder
+	for _, f := range t.Folders {
+		rel, err := renderPath(f.Path, f.Path, data)
+		if err != nil {
+			return nil, err
+		}
+		rel = path.Clean("/" + rel) // 상대경로가 Task 경로 밖을 가리키지 않도록 한다.
+		results = append(results, Folder{
+			Name:       t.ID,
+			Path:       path.Join(root, rel),
+			Permission: f.Permission,
+			UID:        f.UID,
+			GID:        f.GID,
+		})
+	}
+	return results, nil
+}
+
+// parseTaskFolders 함수는 "경로 권한 UID GID" 형태의 줄 단위 문자열을 TaskFolder 리스트로 바꾼다.
+// 권한, UID, GID는 생략할 수 있다. 예) "pub/images 2775 500 500"
+func parseTaskFolders(text string) ([]TaskFolder, error) {
+	var results []TaskFolder
+	for _, line := range strings.Split(text, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) > 4 || len(fields) == 3 {
+			return nil, fmt.Errorf("%s: \"경로 권한 UID GID\" 형태가 아닙니다", strings.TrimSpace(line))
+		}
+		f := TaskFolder{Path: fields[0]}
+		if len(fields) > 1 {
+			f.Permission = fields[1]
+			_, err := parseFolderMode(f.Permission)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if len(fields) == 4 {
+			f.UID = fields[2]
+			f.GID = fields[3]
+			_, _, err := parseFolderOwner(f.UID, f.GID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		results = append(results, f)
+	}
+	return results, nil
+}
+
+// TaskFolders2str 함수는 TaskFolder 리스트를 parseTaskFolders 에서 읽을 수 있는 줄 단위 문자열로 바꾼다.
+func TaskFolders2str(folders []TaskFolder) string {
+	var lines []string
+	for _, f := range folders {
+		fields := []string{f.Path}
+		if f.UID != "" && f.GID != "" {
+			perm := f.Permission
+			if perm == "" {
+				perm = "0775"
+			}
+			fields = append(fields, perm, f.UID, f.GID)
+		} else if f.Permission != "" {
+			fields = append(fields, f.Permission)
+		}
+		lines = append(lines, strings.Join(fields, " "))
+	}
+	return strings.Join(lines, "\n")
 }
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTaskPaths(t *testing.T) {
+	ts := Tasksetting{
+		ID:         "compshot",
+		LinuxPath:  "/show/{{.Project}}/seq/{{.Seq}}/{{.Name}}/{{.Task}}",
+		MacOSPath:  "/Volumes/show/{{.Project}}/seq/{{.Seq}}/{{.Seq}}_{{.Cut}}/{{.Task}}",
+		WindowPath: "//10.0.1.2/show/{{.Project}}/seq/{{.Seq}}/{{.Name}}/{{.Task}}",
+	}
+	shot := Item{Project: "TEMP", ID: "SS_0010_org", Name: "SS_0010", Seq: "SS", Cut: "0010", Type: "org"}
+	got, err := taskPaths(ts, shot, "comp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := TaskPath{
+		Project: "TEMP",
+		ID:      "SS_0010_org",
+		Task:    "comp",
+		Linux:   "/show/TEMP/seq/SS/SS_0010/comp",
+		MacOS:   "/Volumes/show/TEMP/seq/SS/SS_0010/comp",
+		Windows: "//10.0.1.2/show/TEMP/seq/SS/SS_0010/comp",
+	}
+	if got != want {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	asset := Item{Project: "TEMP", ID: "stone_asset", Name: "stone", Type: "asset", Assettype: "prop"}
+	got, err = taskPaths(Tasksetting{WFSPath: "/show/{{.Project}}/assets/{{.Assettype}}/{{.Name}}/{{.Type}}"}, asset, "model")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.WFS != "/show/TEMP/assets/prop/stone/asset" || got.Linux != "" {
+		t.Fatalf("got %+v", got)
+	}
+	if _, err := taskPaths(Tasksetting{LinuxPath: "/show/{{.Unknown}}"}, shot, "comp"); err == nil {
+		t.Fatal("want unknown field error")
+	}
+}
+
+func TestParseTaskFolders(t *testing.T) {
+	folders, err := parseTaskFolders("dev\n\n  pub 2775\nrender 0775 500 500\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []TaskFolder{
+		{Path: "dev"},
+		{Path: "pub", Permission: "2775"},
+		{Path: "render", Permission: "0775", UID: "500", GID: "500"},
+	}
+	if len(folders) != len(want) {
+		t.Fatalf("got %+v", folders)
+	}
+	for n, w := range want {
+		if folders[n] != w {
+			t.Fatalf("folders[%d] = %+v, want %+v", n, folders[n], w)
+		}
+	}
+	if TaskFolders2str(folders) != "dev\npub 2775\nrender 0775 500 500" {
+		t.Fatalf("got %q", TaskFolders2str(folders))
+	}
+	if TaskFolders2str([]TaskFolder{{Path: "dev", UID: "500", GID: "500"}}) != "dev 0775 500 500" {
+		t.Fatal("permission must be written when owner is set")
+	}
+	for _, text := range []string{"dev 0775 500", "dev 0999", "dev 0775 a b", "dev 0775 500 500 x"} {
+		if _, err := parseTaskFolders(text); err == nil {
+			t.Fatalf("%q: want error", text)
+		}
+	}
+}
+
+func TestTaskFolders(t *testing.T) {
+	root, err := ioutil.TempDir("", "csi3_task")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	ts := Tasksetting{
+		ID:        "compshot",
+		LinuxPath: filepath.Join(root, "{{.Project}}/seq/{{.Seq}}/{{.Name}}/{{.Task}}"),
+		Folders: []TaskFolder{
+			{Path: "dev"},
+			{Path: "pub/images", Permission: "0750"},
+			{Path: "{{.Name}}_precomp"},
+			{Path: "../../escape"},
+		},
+	}
+	shot := Item{Project: "TEMP", Name: "SS_0010", Seq: "SS", Type: "org"}
+	folders, err := taskFolders(ts, shot, "comp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := filepath.Join(root, "TEMP/seq/SS/SS_0010/comp")
+	want := []string{"dev", "pub/images", "SS_0010_precomp", "escape"}
+	if len(folders) != len(want) {
+		t.Fatalf("got %+v", folders)
+	}
+	for n, w := range want {
+		if folders[n].Path != filepath.Join(base, w) {
+			t.Fatalf("folders[%d] = %s, want %s", n, folders[n].Path, filepath.Join(base, w))
+		}
+	}
+	_, err = mkFolders(folders, "0022")
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(filepath.Join(base, "pub/images"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0750 {
+		t.Fatalf("got %v", info.Mode())
+	}
+	// 폴더 구조가 없으면 생성할 폴더도 없다.
+	ts.Folders = nil
+	if folders, err := taskFolders(ts, shot, "comp"); err != nil || len(folders) != 0 {
+		t.Fatalf("got %+v, %v", folders, err)
+	}
+	// Linux Path가 없으면 상대경로를 만들 수 없다.
+	if _, err := taskFolders(Tasksetting{Folders: []TaskFolder{{Path: "dev"}}}, shot, "comp"); err == nil {
+		t.Fatal("want linux path error")
+	}
+}
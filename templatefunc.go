@@ -8,29 +8,48 @@ import (
 	"time"
 )
 
+// itemStatus2color 템플릿 함수는 기본 상태리스트에서 상태값에 해당하는 색상을 반환한다.
 func itemStatus2color(num string) string {
-	switch num {
-	case OMIT:
-		return "#FC8F55"
-	case CONFIRM:
-		return "#54D6FD"
-	case WIP:
-		return "#77BB40"
-	case READY:
-		return "#BEEF37"
-	case ASSIGN:
-		return "#FFF76B"
-	case OUT:
-		return "#EEA4F1"
-	case DONE:
-		return "#F0F1F0"
-	case HOLD:
-		return "#989898"
-	case NONE:
-		return "#CCCDCC"
-	default:
-		return "#CCCDCC"
+	return StatusColor(DefaultStatuses(), num)
+}
+
+// StatusColor 템플릿 함수는 프로젝트 상태리스트에서 상태값에 해당하는 색상을 반환한다.
+func StatusColor(statuses []Status, id string) string {
+	for _, s := range statuses {
+		if s.ID == id {
+			return s.Color
+		}
+	}
+	return "#CCCDCC"
+}
+
+// StatusName 템플릿 함수는 프로젝트 상태리스트에서 상태값에 해당하는 이름을 반환한다.
+func StatusName(statuses []Status, id string) string {
+	for _, s := range statuses {
+		if s.ID == id {
+			return s.Name
+		}
+	}
+	return "none"
+}
+
+// StatusIDs 템플릿 함수는 상태리스트의 ID를 ","로 연결한 문자열을 반환한다.
+func StatusIDs(statuses []Status) string {
+	var ids []string
+	for _, s := range statuses {
+		ids = append(ids, s.ID)
+	}
+	return strings.Join(ids, ",")
+}
+
+// HasStatus 템플릿 함수는 상태ID 리스트에 상태값이 존재하는지 체크한다.
+func HasStatus(ids []string, id string) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
 	}
+	return false
 }
 
 // projectStatus2color 템플릿 함수는 프로젝트 상태를 받아서 Bootstrap4 컬러로 변환합니다.
@@ -55,80 +74,21 @@ func projectStatus2color(status ProjectStatus) string {
 
 // Status2capString 템플릿함수는 status 값을 받아서 대문자를 반환한다.
 func Status2capString(num string) string {
-	switch num {
-	case OMIT:
-		return "OMIT"
-	case CONFIRM:
-		return "CONFIRM"
-	case WIP:
-		return "WIP"
-	case READY:
-		return "READY"
-	case ASSIGN:
-		return "ASSIGN"
-	case OUT:
-		return "OUT"
-	case DONE:
-		return "DONE"
-	case HOLD:
-		return "HOLD"
-	case NONE:
-		return "NONE"
-	default:
-		return "NONE"
-	}
+	return strings.ToUpper(Status2string(num))
 }
 
 // Status2string 템플릿함수는 status 값을 받아서 소문자를 반환한다.
 func Status2string(status string) string {
-	switch status {
-	case OMIT:
-		return "omit"
-	case CONFIRM:
-		return "confirm"
-	case WIP:
-		return "wip"
-	case READY:
-		return "ready"
-	case ASSIGN:
-		return "assign"
-	case OUT:
-		return "out"
-	case DONE:
-		return "done"
-	case HOLD:
-		return "hold"
-	case NONE:
-		return "none"
-	default:
-		return "none"
-	}
+	return StatusName(DefaultStatuses(), status)
 }
 
 // StatusString2string 템플릿함수는 status 문자를 받아서 Status 값을 반환한다.
 func StatusString2string(status string) string {
-	switch status {
-	case "omit":
-		return OMIT
-	case "confirm":
-		return CONFIRM
-	case "wip":
-		return WIP
-	case "ready":
-		return READY
-	case "assign":
-		return ASSIGN
-	case "out":
-		return OUT
-	case "done":
-		return DONE
-	case "hold":
-		return HOLD
-	case "none":
-		return NONE
-	default:
+	s, err := findStatus(DefaultStatuses(), status)
+	if err != nil {
 		return NONE
 	}
+	return s.ID
 }
 
 func name2seq(name string) string {
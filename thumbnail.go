@@ -0,0 +1,281 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/digital-idea/dipath"
+	"github.com/disintegration/imaging"
+)
+
+const (
+	// ThumbnailWidth 는 아이템 썸네일의 가로 크기이다.
+	ThumbnailWidth = 410
+	// ThumbnailHeight 는 아이템 썸네일의 세로 크기이다.
+	ThumbnailHeight = 222
+)
+
+// 썸네일 작업 상태
+const (
+	ThumbnailPending = "pending" // 생성대기
+	ThumbnailSuccess = "success" // 생성완료
+	ThumbnailFailed  = "failed"  // 생성실패
+)
+
+// ThumbnailJob 자료구조는 썸네일 생성작업 하나이다. 작업대기열이면서 작업기록으로 사용한다.
+type ThumbnailJob struct {
+	ID         string `json:"id"`         // 작업 ID
+	Project    string `json:"project"`    // 프로젝트
+	ItemID     string `json:"itemid"`     // 아이템 ID
+	Status     string `json:"status"`     // pending, success, failed
+	Source     string `json:"source"`     // 썸네일을 만든 파일 경로
+	Error      string `json:"error"`      // 실패한 이유
+	Createtime string `json:"createtime"` // 생성시간 RFC3339
+	Updatetime string `json:"updatetime"` // 처리시간 RFC3339
+}
+
+// ThumbnailDecode 자료구조는 썸네일 디코더 명령어 템플릿에 전달되는 값이다.
+type ThumbnailDecode struct {
+	Input  string // 이미지를 추출할 파일 경로
+	Output string // 추출한 이미지를 저장할 jpg 경로
+	Frame  int    // 추출할 프레임. mov는 처음부터 센 프레임 번호이다.
+}
+
+// thumbnailImageExts 는 썸네일 생성시 직접 읽을 수 있는 이미지 확장자이다.
+var thumbnailImageExts = []string{".jpg", ".jpeg", ".png", ".gif", ".bmp", ".tif", ".tiff"}
+
+// thumbnailSeqExts 는 플레이트 시퀀스로 간주하는 확장자이다. 직접 읽을 수 없는 확장자는 디코더를 사용한다.
+var thumbnailSeqExts = append([]string{".exr", ".dpx"}, thumbnailImageExts...)
+
+// regexpFramePattern 은 시퀀스 경로의 프레임 표기를 찾는다. 예) ####, %04d, $F4
+var regexpFramePattern = regexp.MustCompile(`#+|%0?(\d*)d|\$F(\d*)`)
+
+// regexpFrameNumber 는 시퀀스 파일명의 마지막 숫자를 찾는다. 예) SS_0010.1001.exr
+var regexpFrameNumber = regexp.MustCompile(`(\d+)\.[^.]+$`)
+
+// thumbnailFilePath 함수는 아이템 썸네일이 저장되는 경로를 반환한다.
+func thumbnailFilePath(project, id string) string {
+	return fmt.Sprintf("%s/%s/%s.jpg", *flagThumbPath, project, id)
+}
+
+// isThumbnailImage 함수는 썸네일 생성시 직접 읽을 수 있는 이미지인지 확인한다.
+func isThumbnailImage(path string) bool {
+	return hasString(thumbnailImageExts, strings.ToLower(filepath.Ext(path)))
+}
+
+// middleFrame 함수는 프레임 범위의 중간 프레임을 반환한다.
+func middleFrame(in, out int) int {
+	if out < in {
+		return in
+	}
+	return in + (out-in)/2
+}
+
+// seqFramePath 함수는 시퀀스 경로의 프레임 표기를 frame 숫자로 바꾼다. 프레임 표기가 없다면 false를 반환한다.
+func seqFramePath(pattern string, frame int) (string, bool) {
+	loc := regexpFramePattern.FindStringSubmatchIndex(pattern)
+	if loc == nil {
+		return pattern, false
+	}
+	match := pattern[loc[0]:loc[1]]
+	pad := 0
+	switch {
+	case strings.HasPrefix(match, "#"):
+		pad = len(match)
+	case loc[2] != -1 && loc[2] != loc[3]:
+		pad, _ = strconv.Atoi(pattern[loc[2]:loc[3]])
+	case loc[4] != -1 && loc[4] != loc[5]:
+		pad, _ = strconv.Atoi(pattern[loc[4]:loc[5]])
+	}
+	return pattern[:loc[0]] + fmt.Sprintf("%0*d", pad, frame) + pattern[loc[1]:], true
+}
+
+// pickSeqFile 함수는 시퀀스 파일 리스트에서 frame 번호의 파일을, 없다면 가운데 파일을 반환한다.
+func pickSeqFile(files []string, frame int) string {
+	if len(files) == 0 {
+		return ""
+	}
+	sort.Strings(files)
+	for _, f := range files {
+		m := regexpFrameNumber.FindStringSubmatch(filepath.Base(f))
+		if m == nil {
+			continue
+		}
+		n, err := strconv.Atoi(m[1])
+		if err == nil && n == frame {
+			return f
+		}
+	}
+	return files[(len(files)-1)/2]
+}
+
+// plateThumbnailSource 함수는 플레이트 경로에서 썸네일로 사용할 중간 프레임 파일을 찾는다.
+// 플레이트 경로는 프레임 표기가 있는 시퀀스 경로이거나 시퀀스가 들어있는 폴더이다.
+func plateThumbnailSource(platepath string, in, out int) (string, error) {
+	frame := middleFrame(in, out)
+	if path, ok := seqFramePath(platepath, frame); ok {
+		_, err := os.Stat(path)
+		if err != nil {
+			return "", err
+		}
+		return path, nil
+	}
+	entries, err := ioutil.ReadDir(platepath)
+	if err != nil {
+		return "", err
+	}
+	var files []string
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		if !hasString(thumbnailSeqExts, strings.ToLower(filepath.Ext(e.Name()))) {
+			continue
+		}
+		files = append(files, filepath.Join(platepath, e.Name()))
+	}
+	if len(files) == 0 {
+		return "", fmt.Errorf("%s 에 시퀀스 파일이 없습니다", platepath)
+	}
+	return pickSeqFile(files, frame), nil
+}
+
+// itemThumbnailSource 함수는 아이템 썸네일을 만들 파일과 추출할 프레임을 반환한다.
+// 플레이트 경로의 중간 프레임을 우선 사용하고, 없다면 썸네일 mov의 중간 프레임을 사용한다.
+func itemThumbnailSource(i Item) (string, int, error) {
+	if i.Platepath != "" {
+		path, err := plateThumbnailSource(i.Platepath, i.PlateIn, i.PlateOut)
+		if err == nil {
+			return path, middleFrame(i.PlateIn, i.PlateOut), nil
+		}
+		if i.Thummov == "" {
+			return "", 0, err
+		}
+	}
+	if i.Thummov == "" {
+		return "", 0, errors.New("플레이트 경로, 썸네일 mov가 없습니다")
+	}
+	_, err := os.Stat(i.Thummov)
+	if err != nil {
+		return "", 0, err
+	}
+	frame := 0
+	if i.PlateOut > i.PlateIn {
+		frame = (i.PlateOut - i.PlateIn) / 2
+	}
+	return i.Thummov, frame, nil
+}
+
+// decoderArgs 함수는 디코더 명령어 템플릿으로 실행할 명령어 인수를 만든다.
+// 경로에 공백이 있어도 인수 하나가 되도록 공백으로 나눈 후 템플릿을 적용한다.
+func decoderArgs(decoder string, d ThumbnailDecode) ([]string, error) {
+	var args []string
+	for _, field := range strings.Fields(decoder) {
+		tmpl, err := template.New("decoder").Option("missingkey=error").Parse(field)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		err = tmpl.Execute(&buf, d)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, buf.String())
+	}
+	return args, nil
+}
+
+// saveThumbnail 함수는 이미지를 썸네일 크기로 잘라서 dst에 저장한다. 저장할 폴더가 없다면 생성한다.
+func saveThumbnail(src, dst string) error {
+	dir := filepath.Dir(dst)
+	_, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		err = os.MkdirAll(dir, 0775)
+		if err != nil {
+			return err
+		}
+		// 디지털아이디어의 경우 스캔시스템에서 수동으로 이미지를 폴더에 생성하는 경우가 있다.
+		if *flagCompany == "digitalidea" {
+			err = dipath.Ideapath(dir)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	img, err := imaging.Open(src)
+	if err != nil {
+		return err
+	}
+	err = imaging.Save(imaging.Fill(img, ThumbnailWidth, ThumbnailHeight, imaging.Center, imaging.Lanczos), dst)
+	if err != nil {
+		return err
+	}
+	// 디지털아이디어의 경우 스캔시스템에서 수동으로 이미지를 수정하는 경우도 있다.
+	if *flagCompany == "digitalidea" {
+		return dipath.Ideapath(dst)
+	}
+	return nil
+}
+
+// decodeThumbnail 함수는 디코더 명령어로 src의 frame 이미지를 추출해서 썸네일로 저장한다.
+func decodeThumbnail(decoder, src string, frame int, dst string) error {
+	if strings.TrimSpace(decoder) == "" {
+		return fmt.Errorf("%s 파일을 읽으려면 관리자 설정에 Thumbnail Decoder가 필요합니다", filepath.Base(src))
+	}
+	tmp, err := ioutil.TempFile("", "csi3_thumbnail_*.jpg")
+	if err != nil {
+		return err
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+	args, err := decoderArgs(decoder, ThumbnailDecode{Input: src, Output: tmp.Name(), Frame: frame})
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.New("Thumbnail Decoder 명령어가 비어있습니다")
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return saveThumbnail(tmp.Name(), dst)
+}
+
+// makeItemThumbnail 함수는 아이템의 플레이트 시퀀스 또는 썸네일 mov로 썸네일을 생성하고 사용한 파일 경로를 반환한다.
+func makeItemThumbnail(i Item, decoder, dst string) (string, error) {
+	src, frame, err := itemThumbnailSource(i)
+	if err != nil {
+		return "", err
+	}
+	if isThumbnailImage(src) {
+		return src, saveThumbnail(src, dst)
+	}
+	return src, decodeThumbnail(decoder, src, frame, dst)
+}
+
+// finishThumbnailJob 함수는 처리결과를 반영한 ThumbnailJob을 반환한다.
+func finishThumbnailJob(j ThumbnailJob, source string, err error, now time.Time) ThumbnailJob {
+	j.Source = source
+	j.Updatetime = now.Format(time.RFC3339)
+	if err != nil {
+		j.Status = ThumbnailFailed
+		j.Error = err.Error()
+		return j
+	}
+	j.Status = ThumbnailSuccess
+	j.Error = ""
+	return j
+}
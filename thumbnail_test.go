@@ -0,0 +1,190 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/disintegration/imaging"
+)
+
+// writeTestImage 함수는 테스트용 이미지를 확장자에 맞게 저장한다.
+func writeTestImage(t *testing.T, path string, c color.Color) {
+	img := image.NewRGBA(image.Rect(0, 0, 64, 36))
+	for x := 0; x < 64; x++ {
+		for y := 0; y < 36; y++ {
+			img.Set(x, y, c)
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if filepath.Ext(path) == ".png" {
+		err = png.Encode(f, img)
+	} else {
+		err = jpeg.Encode(f, img, nil)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSeqFramePath(t *testing.T) {
+	cases := []struct {
+		pattern string
+		want    string
+		ok      bool
+	}{
+		{"/show/TEMP/plate/SS_0010.####.exr", "/show/TEMP/plate/SS_0010.1001.exr", true},
+		{"/show/TEMP/plate/SS_0010.%06d.dpx", "/show/TEMP/plate/SS_0010.001001.dpx", true},
+		{"/show/TEMP/plate/SS_0010.%d.png", "/show/TEMP/plate/SS_0010.1001.png", true},
+		{"/show/TEMP/plate/SS_0010.$F5.jpg", "/show/TEMP/plate/SS_0010.01001.jpg", true},
+		{"/show/TEMP/plate/", "/show/TEMP/plate/", false},
+	}
+	for _, c := range cases {
+		got, ok := seqFramePath(c.pattern, 1001)
+		if got != c.want || ok != c.ok {
+			t.Fatalf("%s: got %s %v", c.pattern, got, ok)
+		}
+	}
+	if middleFrame(1001, 1100) != 1050 || middleFrame(1001, 0) != 1001 {
+		t.Fatal("middleFrame")
+	}
+	files := []string{"/a/SS.1003.exr", "/a/SS.1001.exr", "/a/SS.1002.exr", "/a/SS.1004.exr"}
+	if got := pickSeqFile(files, 1003); got != "/a/SS.1003.exr" {
+		t.Fatalf("got %s", got)
+	}
+	if got := pickSeqFile(files, 0); got != "/a/SS.1002.exr" {
+		t.Fatalf("got %s", got)
+	}
+}
+
+func TestItemThumbnailSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "csi3_thumbnail_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	plate := filepath.Join(dir, "plate")
+	err = os.Mkdir(plate, 0775)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for f := 1001; f <= 1005; f++ {
+		writeTestImage(t, filepath.Join(plate, fmt.Sprintf("SS_0010.%04d.png", f)), color.White)
+	}
+	ioutil.WriteFile(filepath.Join(plate, "notes.txt"), []byte("x"), 0664)
+	item := Item{Platepath: plate + "/", PlateIn: 1001, PlateOut: 1005}
+	src, frame, err := itemThumbnailSource(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(src) != "SS_0010.1003.png" || frame != 1003 {
+		t.Fatalf("got %s %d", src, frame)
+	}
+	// 프레임 정보가 없다면 가운데 파일을 사용한다.
+	src, _, err = itemThumbnailSource(Item{Platepath: plate})
+	if err != nil || filepath.Base(src) != "SS_0010.1003.png" {
+		t.Fatalf("got %s %v", src, err)
+	}
+	src, _, err = itemThumbnailSource(Item{Platepath: filepath.Join(plate, "SS_0010.####.png"), PlateIn: 1001, PlateOut: 1003})
+	if err != nil || filepath.Base(src) != "SS_0010.1002.png" {
+		t.Fatalf("got %s %v", src, err)
+	}
+	// 플레이트가 없다면 썸네일 mov를 사용한다.
+	mov := filepath.Join(dir, "SS_0010.mov")
+	ioutil.WriteFile(mov, []byte("mov"), 0664)
+	src, frame, err = itemThumbnailSource(Item{Platepath: filepath.Join(dir, "none"), Thummov: mov, PlateIn: 1001, PlateOut: 1101})
+	if err != nil || src != mov || frame != 50 {
+		t.Fatalf("got %s %d %v", src, frame, err)
+	}
+	if _, _, err := itemThumbnailSource(Item{}); err == nil {
+		t.Fatal("want error")
+	}
+}
+
+func TestMakeItemThumbnail(t *testing.T) {
+	dir, err := ioutil.TempDir("", "csi3_thumbnail_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for f := 1; f <= 3; f++ {
+		writeTestImage(t, filepath.Join(dir, fmt.Sprintf("SS_0010.%d.jpg", f)), color.RGBA{255, 0, 0, 255})
+	}
+	dst := filepath.Join(dir, "thumbnail", "TEMP", "SS_0010_org.jpg")
+	src, err := makeItemThumbnail(Item{Platepath: filepath.Join(dir, "SS_0010.%d.jpg"), PlateIn: 1, PlateOut: 3}, "", dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(src) != "SS_0010.2.jpg" {
+		t.Fatalf("got %s", src)
+	}
+	img, err := imaging.Open(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds().Dx() != ThumbnailWidth || img.Bounds().Dy() != ThumbnailHeight {
+		t.Fatalf("got %v", img.Bounds())
+	}
+	// mov는 디코더 명령어로 이미지를 추출한다. 테스트에서는 png 파일을 복사하는 명령어를 사용한다.
+	mov := filepath.Join(dir, "SS_0020 v01.mov")
+	writeTestImage(t, mov+".png", color.Black)
+	err = os.Rename(mov+".png", mov)
+	if err != nil {
+		t.Fatal(err)
+	}
+	item := Item{Thummov: mov}
+	if _, err := makeItemThumbnail(item, "", dst); err == nil {
+		t.Fatal("want decoder error")
+	}
+	_, err = makeItemThumbnail(item, "cp {{.Input}} {{.Output}}", dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err = imaging.Open(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, _, _, _ := img.At(0, 0).RGBA(); r > 0x1000 {
+		t.Fatal("thumbnail is not updated")
+	}
+}
+
+func TestDecoderArgs(t *testing.T) {
+	args, err := decoderArgs("ffmpeg -y -i {{.Input}} -vf select=eq(n\\,{{.Frame}}) -frames:v 1 {{.Output}}", ThumbnailDecode{Input: "/show/a b.mov", Output: "/tmp/a.jpg", Frame: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"ffmpeg", "-y", "-i", "/show/a b.mov", "-vf", `select=eq(n\,12)`, "-frames:v", "1", "/tmp/a.jpg"}
+	if len(args) != len(want) {
+		t.Fatalf("got %q", args)
+	}
+	for i := range want {
+		if args[i] != want[i] {
+			t.Fatalf("got %q", args)
+		}
+	}
+	if _, err := decoderArgs("ffmpeg {{.Inptu}}", ThumbnailDecode{}); err == nil {
+		t.Fatal("want template error")
+	}
+	now := time.Date(2016, 12, 6, 10, 0, 0, 0, time.UTC)
+	j := finishThumbnailJob(ThumbnailJob{Status: ThumbnailPending}, "/a.png", errors.New("decode error"), now)
+	if j.Status != ThumbnailFailed || j.Error != "decode error" || j.Updatetime != "2016-12-06T10:00:00Z" {
+		t.Fatalf("got %+v", j)
+	}
+	j = finishThumbnailJob(j, "/a.png", nil, now)
+	if j.Status != ThumbnailSuccess || j.Error != "" || j.Source != "/a.png" {
+		t.Fatalf("got %+v", j)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"gopkg.in/mgo.v2"
 )
@@ -23,6 +24,9 @@ func TokenHandler(r *http.Request, session *mgo.Session) (string, AccessLevel, e
 	if err != nil {
 		return "unknown", UnknownAccessLevel, err
 	}
+	if strings.HasPrefix(key, apiTokenPrefix) {
+		return apiTokenHandler(r, session, key)
+	}
 	token, err := validToken(session, key)
 	if err != nil {
 		return "unknown", UnknownAccessLevel, err
@@ -32,3 +36,58 @@ func TokenHandler(r *http.Request, session *mgo.Session) (string, AccessLevel, e
 	}
 	return token.ID, token.AccessLevel, nil
 }
+
+// apiTokenHandler 함수는 개인 토큰의 만료일과 권한범위를 체크하고 아이디와 엑세스 레벨을 반환한다.
+// 엑세스 레벨은 토큰을 발급한 사용자의 현재 레벨을 사용한다.
+func apiTokenHandler(r *http.Request, session *mgo.Session, key string) (string, AccessLevel, error) {
+	t, err := getAPITokenByKey(session, key)
+	if err != nil {
+		return "unknown", UnknownAccessLevel, err
+	}
+	now := time.Now()
+	err = t.allow(r, now)
+	if err != nil {
+		return t.UserID, UnknownAccessLevel, err
+	}
+	u, err := getUser(session, t.UserID)
+	if err != nil {
+		return t.UserID, UnknownAccessLevel, err
+	}
+	if u.IsLeave || u.AccessLevel < 2 {
+		return u.ID, u.AccessLevel, errors.New("Insufficient authority levels")
+	}
+	err = touchAPIToken(session, t.ID, now)
+	if err != nil {
+		return u.ID, u.AccessLevel, err
+	}
+	return u.ID, u.AccessLevel, nil
+}
+
+// tokenUserID 함수는 토큰 키를 발급받은 사용자 ID를 반환한다. 기존 토큰과 개인 토큰을 모두 사용한다.
+func tokenUserID(session *mgo.Session, key string) (string, error) {
+	if strings.HasPrefix(key, apiTokenPrefix) {
+		t, err := getAPITokenByKey(session, key)
+		if err != nil {
+			return "", err
+		}
+		return t.UserID, nil
+	}
+	t, err := validToken(session, key)
+	if err != nil {
+		return "", err
+	}
+	return t.ID, nil
+}
+
+// requestAPIToken 함수는 요청이 개인 토큰을 사용한다면 토큰 정보를 반환한다.
+func requestAPIToken(r *http.Request, session *mgo.Session) (APIToken, bool) {
+	key, err := GetTokenFromHeader(r)
+	if err != nil || !strings.HasPrefix(key, apiTokenPrefix) {
+		return APIToken{}, false
+	}
+	t, err := getAPITokenByKey(session, key)
+	if err != nil {
+		return APIToken{}, false
+	}
+	return t, true
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// TOTP(RFC 6238) 설정. Google Authenticator 등 대부분의 인증앱이 사용하는 기본값이다.
+const (
+	totpPeriod           = 30 // 코드가 바뀌는 시간(초)
+	totpDigits           = 6  // 코드 자리수
+	totpSkew             = 1  // 시계 오차를 위해 앞뒤로 허용하는 시간단위 수
+	totpRecoveryCodesNum = 10 // 발급하는 복구코드 갯수
+)
+
+// totpSessionCookie 는 패스워드를 확인하고 TOTP 코드 입력을 기다리는 사용자를 저장하는 쿠키 이름이다.
+const totpSessionCookie = "TOTPSSID"
+
+// totpSessionAge 는 패스워드를 확인한 뒤 TOTP 코드를 입력해야 하는 시간이다.
+const totpSessionAge = 5 * time.Minute
+
+// totpEncoding 은 TOTP 비밀키 인코딩이다. 인증앱은 패딩이 없는 base32 문자열을 사용한다.
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// newTOTPSecret 함수는 새 TOTP 비밀키를 만든다.
+func newTOTPSecret() (string, error) {
+	b := make([]byte, 20)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// totpCode 함수는 비밀키와 시간단위(counter)로 TOTP 코드를 만든다.
+func totpCode(secret string, counter int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimRight(strings.Replace(secret, " ", "", -1), "=")))
+	if err != nil {
+		return "", fmt.Errorf("TOTP 비밀키가 올바르지 않습니다: %v", err)
+	}
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	n := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, n%1000000), nil
+}
+
+// totpCounter 함수는 시간의 TOTP 시간단위를 반환한다.
+func totpCounter(t time.Time) int64 {
+	return t.Unix() / totpPeriod
+}
+
+// totpVerify 함수는 코드가 현재 시간의 앞뒤 totpSkew 범위에서 유효한지 체크하고, 사용한 시간단위를 반환한다.
+// 같은 코드를 다시 사용하지 못하도록 last 이하의 시간단위는 허용하지 않는다.
+func totpVerify(secret, code string, now time.Time, last int64) (int64, bool) {
+	code = strings.Replace(strings.TrimSpace(code), " ", "", -1)
+	if len(code) != totpDigits {
+		return 0, false
+	}
+	current := totpCounter(now)
+	for i := -totpSkew; i <= totpSkew; i++ {
+		c := current + int64(i)
+		if c <= last {
+			continue
+		}
+		want, err := totpCode(secret, c)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(want), []byte(code)) == 1 {
+			return c, true
+		}
+	}
+	return 0, false
+}
+
+// totpURL 함수는 인증앱에 등록할 otpauth:// URL을 만든다.
+func totpURL(issuer, account, secret string) string {
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprintf("%d", totpDigits))
+	v.Set("period", fmt.Sprintf("%d", totpPeriod))
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: v.Encode(),
+	}
+	return u.String()
+}
+
+// totpQRCode 함수는 otpauth:// URL을 웹페이지에 출력할 수 있는 QR코드 PNG data URL로 만든다.
+func totpQRCode(otpauth string) (template.URL, error) {
+	png, err := qrcode.Encode(otpauth, qrcode.Medium, 256)
+	if err != nil {
+		return "", err
+	}
+	return template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(png)), nil
+}
+
+// hashRecoveryCode 함수는 복구코드의 sha256 해시값을 반환한다. 대소문자, 공백, -는 구분하지 않는다.
+func hashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// newRecoveryCodes 함수는 복구코드와 DB에 저장할 해시값을 만든다. 복구코드는 한번만 보여준다.
+func newRecoveryCodes() ([]string, []string, error) {
+	var codes []string
+	var hashes []string
+	for i := 0; i < totpRecoveryCodesNum; i++ {
+		b := make([]byte, 7)
+		_, err := rand.Read(b)
+		if err != nil {
+			return nil, nil, err
+		}
+		s := strings.ToLower(totpEncoding.EncodeToString(b))[:10]
+		code := s[:5] + "-" + s[5:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// useRecoveryCode 함수는 복구코드가 해시 리스트에 있다면 사용한 코드를 제거한 리스트를 반환한다.
+func useRecoveryCode(hashes []string, code string) ([]string, bool) {
+	h := hashRecoveryCode(code)
+	for i, v := range hashes {
+		if subtle.ConstantTimeCompare([]byte(v), []byte(h)) == 1 {
+			return append(append([]string{}, hashes[:i]...), hashes[i+1:]...), true
+		}
+	}
+	return hashes, false
+}
+
+// totpLevelRequired 함수는 AccessLevel이 Admin Setting의 TOTPAccessLevel 이상이라 2단계 인증이 필수인지 체크한다.
+func totpLevelRequired(level AccessLevel, s Setting) bool {
+	return s.TOTPAccessLevel > 0 && level >= AccessLevel(s.TOTPAccessLevel)
+}
+
+// totpRequired 함수는 사용자가 로그인할 때 TOTP 2단계 인증이 필요한지 체크한다.
+// TOTP를 등록한 사용자와 Admin Setting의 TOTPAccessLevel 이상인 사용자는 2단계 인증을 해야 한다.
+func totpRequired(u User, s Setting) bool {
+	return u.TOTPEnabled || totpLevelRequired(u.AccessLevel, s)
+}
+
+// totpSignKey 함수는 TOTP 대기 토큰의 서명키를 반환한다. 대기 토큰을 SSID 쿠키로 사용할 수 없도록 SSID와 다른 키를 사용한다.
+func totpSignKey() []byte {
+	return []byte("totp:" + os.Getenv("CSI_JWT_SIGN_KEY"))
+}
+
+// createTOTPSessionString 함수는 패스워드를 확인한 사용자의 TOTP 대기 토큰을 만든다.
+func createTOTPSessionString(id string, now time.Time) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{
+		Audience:  "totp",
+		Subject:   id,
+		ExpiresAt: now.Add(totpSessionAge).Unix(),
+	})
+	return token.SignedString(totpSignKey())
+}
+
+// parseTOTPSessionString 함수는 TOTP 대기 토큰을 검증하고 사용자 ID를 반환한다.
+func parseTOTPSessionString(s string) (string, error) {
+	claims := jwt.StandardClaims{}
+	token, err := jwt.ParseWithClaims(s, &claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("토큰의 서명 알고리즘이 올바르지 않습니다")
+		}
+		return totpSignKey(), nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if !token.Valid || claims.Audience != "totp" || claims.Subject == "" || claims.ExpiresAt == 0 {
+		return "", errors.New("토큰이 유효하지 않습니다")
+	}
+	return claims.Subject, nil
+}
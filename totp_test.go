@@ -0,0 +1,197 @@
+package main
+
+import (
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+// RFC 6238 부록 B의 SHA1 비밀키 "12345678901234567890"
+const totpTestSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode(t *testing.T) {
+	// RFC 6238 테스트 값의 마지막 6자리
+	cases := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, c := range cases {
+		got, err := totpCode(totpTestSecret, totpCounter(time.Unix(c.unix, 0)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c.want {
+			t.Fatalf("%d: 얻은 값 %s 원하는 값 %s", c.unix, got, c.want)
+		}
+	}
+	if _, err := totpCode("not-base32!", 1); err == nil {
+		t.Fatal("잘못된 비밀키는 에러가 발생해야 합니다")
+	}
+}
+
+func TestTOTPVerify(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := totpCounter(now)
+	code, err := totpCode(totpTestSecret, current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counter, ok := totpVerify(totpTestSecret, code, now, 0)
+	if !ok || counter != current {
+		t.Fatalf("현재 코드: %d %v", counter, ok)
+	}
+	// 공백이 있어도 허용한다.
+	if _, ok := totpVerify(totpTestSecret, code[:3]+" "+code[3:], now, 0); !ok {
+		t.Fatal("공백이 있는 코드도 허용해야 합니다")
+	}
+	// 사용한 시간단위의 코드는 다시 사용할 수 없다.
+	if _, ok := totpVerify(totpTestSecret, code, now, current); ok {
+		t.Fatal("사용한 코드는 허용하면 안됩니다")
+	}
+	// 앞뒤 totpSkew 시간단위는 허용하고 그 밖은 허용하지 않는다.
+	prev, _ := totpCode(totpTestSecret, current-1)
+	if counter, ok := totpVerify(totpTestSecret, prev, now, 0); !ok || counter != current-1 {
+		t.Fatal("이전 시간단위의 코드는 허용해야 합니다")
+	}
+	old, _ := totpCode(totpTestSecret, current-2)
+	if _, ok := totpVerify(totpTestSecret, old, now, 0); ok {
+		t.Fatal("totpSkew 밖의 코드는 허용하면 안됩니다")
+	}
+	for _, c := range []string{"", "12345", "1234567", "abcdef"} {
+		if _, ok := totpVerify(totpTestSecret, c, now, 0); ok {
+			t.Fatalf("%q 코드는 허용하면 안됩니다", c)
+		}
+	}
+}
+
+func TestNewTOTPSecret(t *testing.T) {
+	a, err := newTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := newTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a == b || len(a) != 32 {
+		t.Fatalf("비밀키: %s %s", a, b)
+	}
+	if _, err := totpCode(a, 1); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTOTPURL(t *testing.T) {
+	u, err := url.Parse(totpURL("Lazypic CSI", "alice", totpTestSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if u.Scheme != "otpauth" || u.Host != "totp" || u.Path != "/Lazypic CSI:alice" {
+		t.Fatalf("URL: %s", u)
+	}
+	if q.Get("secret") != totpTestSecret || q.Get("issuer") != "Lazypic CSI" || q.Get("digits") != "6" || q.Get("period") != "30" {
+		t.Fatalf("URL 쿼리: %s", u.RawQuery)
+	}
+	qr, err := totpQRCode(u.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(qr), "data:image/png;base64,") {
+		t.Fatalf("QR코드: %.40s", qr)
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != totpRecoveryCodesNum || len(hashes) != totpRecoveryCodesNum {
+		t.Fatalf("복구코드 갯수: %d %d", len(codes), len(hashes))
+	}
+	if len(codes[0]) != 11 || codes[0][5] != '-' {
+		t.Fatalf("복구코드 형태: %s", codes[0])
+	}
+	// 대소문자, 공백, - 는 구분하지 않는다.
+	after, ok := useRecoveryCode(hashes, " "+strings.ToUpper(strings.Replace(codes[3], "-", "", -1))+" ")
+	if !ok || len(after) != totpRecoveryCodesNum-1 {
+		t.Fatalf("복구코드 사용: %v %d", ok, len(after))
+	}
+	// 원래 리스트는 바뀌지 않는다.
+	if hashes[3] != hashRecoveryCode(codes[3]) {
+		t.Fatal("원래 해시 리스트가 바뀌면 안됩니다")
+	}
+	// 사용한 복구코드는 다시 사용할 수 없다.
+	if _, ok := useRecoveryCode(after, codes[3]); ok {
+		t.Fatal("사용한 복구코드는 허용하면 안됩니다")
+	}
+	if _, ok := useRecoveryCode(hashes, "aaaaa-aaaaa"); ok {
+		t.Fatal("없는 복구코드는 허용하면 안됩니다")
+	}
+}
+
+func TestTOTPRequired(t *testing.T) {
+	s := Setting{TOTPAccessLevel: int(PdAccessLevel)}
+	cases := []struct {
+		user User
+		want bool
+	}{
+		{User{AccessLevel: ArtistAccessLevel}, false},
+		{User{AccessLevel: ArtistAccessLevel, TOTPEnabled: true}, true},
+		{User{AccessLevel: PdAccessLevel}, true},
+		{User{AccessLevel: AdminAccessLevel}, true},
+	}
+	for _, c := range cases {
+		if got := totpRequired(c.user, s); got != c.want {
+			t.Fatalf("%+v: 얻은 값 %v 원하는 값 %v", c.user, got, c.want)
+		}
+	}
+	// TOTPAccessLevel이 0이면 등록한 사용자만 2단계 인증을 한다.
+	if totpRequired(User{AccessLevel: AdminAccessLevel}, Setting{}) {
+		t.Fatal("TOTPAccessLevel 0: 등록하지 않은 사용자는 2단계 인증이 필요없습니다")
+	}
+}
+
+func TestTOTPSessionString(t *testing.T) {
+	token, err := createTOTPSessionString("alice", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := parseTOTPSessionString(token)
+	if err != nil || id != "alice" {
+		t.Fatalf("얻은 값 %s %v", id, err)
+	}
+	expired, err := createTOTPSessionString("alice", time.Now().Add(-totpSessionAge-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parseTOTPSessionString(expired); err == nil {
+		t.Fatal("만료된 토큰은 에러가 발생해야 합니다")
+	}
+	// SSID 토큰은 TOTP 대기 토큰으로 사용할 수 없다.
+	ssid, err := CreateTokenString("alice", AdminAccessLevel, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parseTOTPSessionString(ssid); err == nil {
+		t.Fatal("SSID 토큰은 에러가 발생해야 합니다")
+	}
+	// TOTP 대기 토큰은 SSID 서명키로 검증되지 않는다.
+	if _, err := jwt.Parse(token, func(*jwt.Token) (interface{}, error) {
+		return []byte(os.Getenv("CSI_JWT_SIGN_KEY")), nil
+	}); err == nil {
+		t.Fatal("TOTP 대기 토큰은 SSID로 사용할 수 없어야 합니다")
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"time"
 )
 
@@ -63,6 +64,15 @@ type User struct {
 	Organizations     []Organization `json:"organizations"`     // 조직정보
 	OrganizationsForm string         `json:"organizationsform"` // 가입시 사용된 조직정보 문자
 	AccessProjects    []string       `json:"accessprojects"`    // 사용자에게 허가된 프로젝트 리스트
+	AuthSource        string         `json:"authsource"`        // 외부 인증서비스. ldap, oidc. 빈 문자열이면 CSI 패스워드로 로그인한다.
+	TokenProjects     []string       `json:"-" bson:"-"`        // 개인 토큰으로 요청한 경우 토큰에 허용된 프로젝트. DB에 저장하지 않는다.
+	OIDCIssuer        string         `json:"oidcissuer"`        // 연결된 OIDC 계정의 iss 클레임
+	OIDCSubject       string         `json:"oidcsubject"`       // 연결된 OIDC 계정의 sub 클레임
+	TOTPEnabled       bool           `json:"totpenabled"`       // TOTP 2단계 인증 사용여부
+	TOTPSecret        string         `json:"totpsecret"`        // TOTP 비밀키(base32)
+	TOTPPendingSecret string         `json:"totppendingsecret"` // 등록중인 TOTP 비밀키. 인증앱의 코드를 확인하면 TOTPSecret이 된다.
+	TOTPLastCounter   int64          `json:"totplastcounter"`   // 마지막으로 사용한 TOTP 시간단위. 사용한 코드를 다시 사용할 수 없다.
+	TOTPRecoveryCodes []string       `json:"totprecoverycodes"` // 복구코드의 sha256 해시값. 사용한 코드는 제거된다.
 }
 
 // Token 자료구조. 사용자가 가입될 때 user.token DB에 저장된다. 모든 유저의 Token를 매번 비교하지않고, Token 키의 유효성을 바로 체크하기 위해서 사용한다.
@@ -106,3 +116,19 @@ func (u *User) SetTags() {
 	tags = append(tags, u.Tags...)
 	u.Tags = UniqueSlice(tags)
 }
+
+// errUserLeave 는 퇴사처리된 사용자가 로그인할 때 반환하는 에러이다.
+var errUserLeave = errors.New("퇴사처리된 사용자는 로그인할 수 없습니다")
+
+// checkLocalSignin 메소드는 사용자가 CSI에 등록된 패스워드로 로그인할 수 있는지 체크한다.
+// 퇴사한 사용자와 LDAP, OIDC로 가입한 사용자는 CSI 패스워드로 로그인할 수 없다.
+func (u User) checkLocalSignin() error {
+	if u.IsLeave {
+		return errUserLeave
+	}
+	switch u.AuthSource {
+	case "ldap", "oidc":
+		return errors.New(u.AuthSource + " 사용자는 CSI 패스워드로 로그인할 수 없습니다")
+	}
+	return nil
+}
@@ -0,0 +1,343 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// 웹훅 이벤트 종류이다.
+const (
+	WebhookTaskStatus = "taskstatus" // Task 상태변경
+	WebhookComment    = "comment"    // 코멘트 등록
+	WebhookMov        = "mov"        // mov 등록
+	WebhookDeadline   = "deadline"   // 2D, 3D, Task 마감일 변경
+	WebhookItemAdd    = "itemadd"    // 아이템 추가
+	WebhookItemRemove = "itemremove" // 아이템 삭제
+	WebhookProject    = "project"    // 프로젝트 정보 변경
+)
+
+// WebhookEventTypes 는 웹훅에서 사용할 수 있는 이벤트 종류 리스트이다.
+var WebhookEventTypes = []string{WebhookTaskStatus, WebhookComment, WebhookMov, WebhookDeadline, WebhookItemAdd, WebhookItemRemove, WebhookProject}
+
+// 웹훅 전송상태이다.
+const (
+	DeliveryPending = "pending" // 전송대기, 재시도 대기
+	DeliverySuccess = "success" // 전송완료
+	DeliveryFailed  = "failed"  // 재시도 횟수 초과로 전송실패
+)
+
+// WebhookMaxAttempts 는 웹훅 전송을 시도하는 최대 횟수이다.
+const WebhookMaxAttempts = 8
+
+// Webhook 자료구조는 프로젝트에 등록된 외부 알림 주소이다.
+type Webhook struct {
+	ID         string   `json:"id"`         // 웹훅 ID
+	URL        string   `json:"url"`        // 이벤트를 POST로 전송할 주소
+	Secret     string   `json:"secret"`     // 서명에 사용하는 키. 빈 문자열이면 서명하지 않는다.
+	Events     []string `json:"events"`     // 전송할 이벤트 종류. 비어있으면 모든 이벤트를 전송한다.
+	Tasks      []string `json:"tasks"`      // 전송할 Task. 비어있으면 모든 Task를 전송한다.
+	Statuses   []string `json:"statuses"`   // taskstatus 이벤트에서 전송할 변경후 상태ID. 비어있으면 모든 상태를 전송한다.
+	Author     string   `json:"author"`     // 등록한 사용자 ID
+	Createtime string   `json:"createtime"` // 등록시간. RFC3339
+}
+
+// WebhookEvent 자료구조는 웹훅으로 전송되는 JSON 페이로드이다.
+type WebhookEvent struct {
+	Event   string   `json:"event"`             // 이벤트 종류
+	Project string   `json:"project"`           // 프로젝트
+	ID      string   `json:"id"`                // 아이템 ID. project 이벤트는 빈 문자열이다.
+	Task    string   `json:"task"`              // Task 이름. Task 이벤트가 아니면 빈 문자열이다.
+	Field   string   `json:"field"`             // 변경된 필드. 예) tasks.comp.status
+	Old     string   `json:"old"`               // 변경전 값
+	New     string   `json:"new"`               // 변경후 값
+	Comment *Comment `json:"comment,omitempty"` // comment 이벤트의 코멘트
+	Author  string   `json:"author"`            // 수정한 사용자 ID
+	Source  string   `json:"source"`            // 수정경로: web, rest, cli, excel
+	Time    string   `json:"time"`              // 이벤트 발생시간. RFC3339
+}
+
+// WebhookDelivery 자료구조는 웹훅 전송 하나이다. 전송대기열이면서 전송기록으로 사용한다.
+type WebhookDelivery struct {
+	ID         string `json:"id"`         // 전송 ID. X-CSI-Delivery 헤더로 전달된다.
+	WebhookID  string `json:"webhookid"`  // 웹훅 ID
+	URL        string `json:"url"`        // 전송주소
+	Event      string `json:"event"`      // 이벤트 종류
+	Payload    string `json:"payload"`    // 전송할 JSON
+	Status     string `json:"status"`     // pending, success, failed
+	Attempts   int    `json:"attempts"`   // 전송을 시도한 횟수
+	StatusCode int    `json:"statuscode"` // 마지막 응답 상태코드
+	Error      string `json:"error"`      // 마지막 에러
+	NextTime   string `json:"nexttime"`   // 다음 전송시간. RFC3339
+	Createtime string `json:"createtime"` // 생성시간. RFC3339
+	Updatetime string `json:"updatetime"` // 마지막 전송시간. RFC3339
+}
+
+// checkWebhook 함수는 웹훅의 주소와 이벤트 종류를 체크한다.
+func checkWebhook(w Webhook) error {
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%s 는 http, https 주소가 아닙니다", w.URL)
+	}
+	for _, e := range w.Events {
+		found := false
+		for _, t := range WebhookEventTypes {
+			if e == t {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s 이벤트는 사용할 수 없습니다. (%s 만 사용가능합니다)", e, strings.Join(WebhookEventTypes, ", "))
+		}
+	}
+	return nil
+}
+
+// match 메소드는 이벤트가 웹훅의 조건에 맞는지 체크한다.
+func (w Webhook) match(e WebhookEvent) bool {
+	if len(w.Events) != 0 && !hasString(w.Events, e.Event) {
+		return false
+	}
+	if len(w.Tasks) != 0 && !hasString(w.Tasks, e.Task) {
+		return false
+	}
+	if e.Event == WebhookTaskStatus && len(w.Statuses) != 0 && !hasString(w.Statuses, e.New) {
+		return false
+	}
+	return true
+}
+
+// hasString 함수는 리스트에 문자열이 존재하는지 체크한다.
+func hasString(list []string, s string) bool {
+	for _, i := range list {
+		if i == s {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookEvents 함수는 아이템 변경이력으로 웹훅 이벤트를 만든다.
+// 웹훅 이벤트에 해당하지 않는 변경이력은 무시한다.
+func webhookEvents(project, id string, histories []History, editor Editor, now string) []WebhookEvent {
+	var results []WebhookEvent
+	for _, h := range histories {
+		e := WebhookEvent{
+			Project: project,
+			ID:      id,
+			Field:   h.Field,
+			Old:     h.Old,
+			New:     h.New,
+			Author:  editor.ID,
+			Source:  editor.Source,
+			Time:    now,
+		}
+		switch {
+		case h.Field == "ddline2d" || h.Field == "ddline3d":
+			e.Event = WebhookDeadline
+		case h.Field == "comments":
+			for _, c := range addedComments(h.Old, h.New) {
+				c := c
+				ce := e
+				ce.Event = WebhookComment
+				ce.Old = ""
+				ce.New = c.Text
+				ce.Comment = &c
+				results = append(results, ce)
+			}
+			continue
+		case strings.HasPrefix(h.Field, "tasks."):
+			keys := strings.Split(h.Field, ".")
+			if len(keys) != 3 {
+				continue
+			}
+			e.Task = keys[1]
+			switch keys[2] {
+			case "status":
+				e.Event = WebhookTaskStatus
+			case "mov":
+				if h.New == "" {
+					continue
+				}
+				e.Event = WebhookMov
+			case "predate", "date":
+				e.Event = WebhookDeadline
+			default:
+				continue
+			}
+		default:
+			continue
+		}
+		results = append(results, e)
+	}
+	return results
+}
+
+// addedComments 함수는 변경이력의 코멘트 리스트를 비교하여 새로 등록된 코멘트를 반환한다.
+// 코멘트는 등록시간(date)으로 구분한다.
+func addedComments(oldValue, newValue string) []Comment {
+	var before, after []Comment
+	if oldValue != "" {
+		if json.Unmarshal([]byte(oldValue), &before) != nil {
+			return nil
+		}
+	}
+	if newValue == "" || json.Unmarshal([]byte(newValue), &after) != nil {
+		return nil
+	}
+	dates := make(map[string]bool)
+	for _, c := range before {
+		dates[c.Date] = true
+	}
+	var results []Comment
+	for _, c := range after {
+		if !dates[c.Date] {
+			results = append(results, c)
+		}
+	}
+	return results
+}
+
+// signPayload 함수는 secret으로 페이로드의 HMAC-SHA256 서명을 만든다.
+// 수신측은 X-CSI-Signature 헤더의 sha256= 뒤의 값과 비교하여 요청을 검증할 수 있다.
+func signPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookBackoff 함수는 전송에 실패한 횟수에 따라 다음 재시도까지 기다릴 시간을 반환한다.
+// 30초부터 두배씩 늘어나며 최대 1시간이다.
+func webhookBackoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	d := 30 * time.Second
+	for n := 1; n < attempts; n++ {
+		d *= 2
+		if d >= time.Hour {
+			return time.Hour
+		}
+	}
+	return d
+}
+
+// sendWebhook 함수는 페이로드를 웹훅 주소로 전송하고 응답 상태코드를 반환한다.
+// 2xx 응답이 아니라면 에러를 반환한다.
+func sendWebhook(client *http.Client, d WebhookDelivery, secret string) (int, error) {
+	payload := []byte(d.Payload)
+	req, err := http.NewRequest(http.MethodPost, d.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("User-Agent", "csi3-webhook")
+	req.Header.Set("X-CSI-Event", d.Event)
+	req.Header.Set("X-CSI-Delivery", d.ID)
+	if secret != "" {
+		req.Header.Set("X-CSI-Signature", "sha256="+signPayload(secret, payload))
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, errors.New(resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// nextDelivery 함수는 전송결과를 반영한 WebhookDelivery를 반환한다.
+// 실패하면 재시도 시간을 설정하고, 최대 횟수를 넘기면 실패로 처리한다.
+func nextDelivery(d WebhookDelivery, code int, err error, now time.Time) WebhookDelivery {
+	d.Attempts++
+	d.StatusCode = code
+	d.Updatetime = now.Format(time.RFC3339)
+	if err == nil {
+		d.Status = DeliverySuccess
+		d.Error = ""
+		d.NextTime = ""
+		return d
+	}
+	d.Error = err.Error()
+	if d.Attempts >= WebhookMaxAttempts {
+		d.Status = DeliveryFailed
+		d.NextTime = ""
+		return d
+	}
+	d.Status = DeliveryPending
+	d.NextTime = now.Add(webhookBackoff(d.Attempts)).Format(time.RFC3339)
+	return d
+}
+
+// projectWebhookEvents 함수는 변경전, 변경후 프로젝트 정보를 비교하여 변경된 필드마다 project 이벤트를 만든다.
+func projectWebhookEvents(before, after Project, now string) ([]WebhookEvent, error) {
+	b, err := jsonFields(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := jsonFields(after)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]bool)
+	for k := range b {
+		keys[k] = true
+	}
+	for k := range a {
+		keys[k] = true
+	}
+	var fields []string
+	for k := range keys {
+		if k == "updatetime" {
+			continue
+		}
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+	var results []WebhookEvent
+	for _, f := range fields {
+		oldValue := historyValue(b[f])
+		newValue := historyValue(a[f])
+		if oldValue == newValue {
+			continue
+		}
+		results = append(results, WebhookEvent{
+			Event:   WebhookProject,
+			Project: after.ID,
+			Field:   f,
+			Old:     oldValue,
+			New:     newValue,
+			Time:    now,
+		})
+	}
+	return results, nil
+}
+
+// jsonFields 함수는 자료구조를 JSON 키 기준의 맵으로 바꾼다.
+func jsonFields(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]interface{})
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
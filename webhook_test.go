@@ -0,0 +1,207 @@
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWebhookEvents(t *testing.T) {
+	editor := Editor{ID: "khw", Source: WebSource}
+	now := "2020-04-01T10:00:00+09:00"
+	histories := []History{
+		{Field: "tasks.comp.status", Old: "2", New: "7"},
+		{Field: "tasks.comp.mov", Old: "", New: "/show/TEMP/comp.mov"},
+		{Field: "tasks.fx.mov", Old: "/show/TEMP/fx.mov", New: ""},
+		{Field: "tasks.comp.date", Old: "", New: "2020-04-10T19:00:00+09:00"},
+		{Field: "ddline2d", Old: "", New: "2020-04-20T19:00:00+09:00"},
+		{Field: "tasks.comp.user", Old: "", New: "khw"},
+		{Field: "note.text", Old: "", New: "note"},
+		{
+			Field: "comments",
+			Old:   `[{"date":"2020-03-01T10:00:00+09:00","author":"a","text":"old","media":""}]`,
+			New:   `[{"date":"2020-03-01T10:00:00+09:00","author":"a","text":"old","media":""},{"date":"2020-04-01T10:00:00+09:00","author":"khw","text":"new","media":""}]`,
+		},
+	}
+	events := webhookEvents("TEMP", "SS_0010_org", histories, editor, now)
+	want := []struct {
+		event string
+		task  string
+		new   string
+	}{
+		{WebhookTaskStatus, "comp", "7"},
+		{WebhookMov, "comp", "/show/TEMP/comp.mov"},
+		{WebhookDeadline, "comp", "2020-04-10T19:00:00+09:00"},
+		{WebhookDeadline, "", "2020-04-20T19:00:00+09:00"},
+		{WebhookComment, "", "new"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for n, w := range want {
+		e := events[n]
+		if e.Event != w.event || e.Task != w.task || e.New != w.new {
+			t.Fatalf("events[%d] = %+v, want %+v", n, e, w)
+		}
+		if e.Project != "TEMP" || e.ID != "SS_0010_org" || e.Author != "khw" || e.Time != now {
+			t.Fatalf("events[%d] = %+v", n, e)
+		}
+	}
+	if events[4].Comment == nil || events[4].Comment.Date != "2020-04-01T10:00:00+09:00" {
+		t.Fatalf("comment event = %+v", events[4])
+	}
+}
+
+func TestProjectWebhookEvents(t *testing.T) {
+	before := Project{ID: "TEMP", Name: "temp", Updatetime: "a"}
+	after := Project{ID: "TEMP", Name: "temp2", Updatetime: "b"}
+	events, err := projectWebhookEvents(before, after, "now")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Event != WebhookProject || events[0].Field != "name" || events[0].Old != "temp" || events[0].New != "temp2" {
+		t.Fatalf("got %+v", events)
+	}
+}
+
+func TestWebhookMatch(t *testing.T) {
+	status := WebhookEvent{Event: WebhookTaskStatus, Task: "comp", New: "7"}
+	comment := WebhookEvent{Event: WebhookComment}
+	cases := []struct {
+		hook  Webhook
+		event WebhookEvent
+		want  bool
+	}{
+		{Webhook{}, status, true},
+		{Webhook{}, comment, true},
+		{Webhook{Events: []string{WebhookComment}}, status, false},
+		{Webhook{Events: []string{WebhookComment}}, comment, true},
+		{Webhook{Tasks: []string{"comp"}}, status, true},
+		{Webhook{Tasks: []string{"fx"}}, status, false},
+		{Webhook{Tasks: []string{"comp"}}, comment, false},
+		{Webhook{Statuses: []string{"7"}}, status, true},
+		{Webhook{Statuses: []string{"2"}}, status, false},
+		{Webhook{Statuses: []string{"2"}}, comment, true},
+	}
+	for n, c := range cases {
+		if got := c.hook.match(c.event); got != c.want {
+			t.Fatalf("cases[%d]: got %v, want %v", n, got, c.want)
+		}
+	}
+}
+
+func TestCheckWebhook(t *testing.T) {
+	cases := []struct {
+		hook  Webhook
+		valid bool
+	}{
+		{Webhook{URL: "https://example.com/hook"}, true},
+		{Webhook{URL: "http://127.0.0.1:8080/hook", Events: []string{WebhookMov}}, true},
+		{Webhook{URL: "ftp://example.com/hook"}, false},
+		{Webhook{URL: "example.com/hook"}, false},
+		{Webhook{URL: "https://example.com/hook", Events: []string{"unknown"}}, false},
+	}
+	for n, c := range cases {
+		err := checkWebhook(c.hook)
+		if (err == nil) != c.valid {
+			t.Fatalf("cases[%d]: %v", n, err)
+		}
+	}
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	cases := map[int]time.Duration{
+		0:  30 * time.Second,
+		1:  30 * time.Second,
+		2:  time.Minute,
+		3:  2 * time.Minute,
+		7:  32 * time.Minute,
+		8:  time.Hour,
+		20: time.Hour,
+	}
+	for attempts, want := range cases {
+		if got := webhookBackoff(attempts); got != want {
+			t.Fatalf("webhookBackoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
+
+func TestNextDelivery(t *testing.T) {
+	now := time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC)
+	d := nextDelivery(WebhookDelivery{Status: DeliveryPending}, 500, errTestWebhook, now)
+	if d.Status != DeliveryPending || d.Attempts != 1 || d.NextTime != "2020-04-01T10:00:30Z" {
+		t.Fatalf("got %+v", d)
+	}
+	d = nextDelivery(d, 200, nil, now)
+	if d.Status != DeliverySuccess || d.Error != "" || d.NextTime != "" {
+		t.Fatalf("got %+v", d)
+	}
+	d = nextDelivery(WebhookDelivery{Attempts: WebhookMaxAttempts - 1}, 0, errTestWebhook, now)
+	if d.Status != DeliveryFailed {
+		t.Fatalf("got %+v", d)
+	}
+}
+
+var errTestWebhook = errors.New("500 Internal Server Error")
+
+func TestSendWebhook(t *testing.T) {
+	secret := "secret"
+	var got *http.Request
+	var body []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = ioutil.ReadAll(r.Body)
+		if r.Header.Get("X-CSI-Signature") != "sha256="+signPayload(secret, body) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+	d := WebhookDelivery{
+		ID:      "delivery1",
+		URL:     ts.URL,
+		Event:   WebhookComment,
+		Payload: `{"event":"comment","project":"TEMP"}`,
+	}
+	code, err := sendWebhook(ts.Client(), d, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != http.StatusNoContent {
+		t.Fatalf("got status %d", code)
+	}
+	if got.Method != http.MethodPost || got.Header.Get("X-CSI-Event") != WebhookComment || got.Header.Get("X-CSI-Delivery") != "delivery1" {
+		t.Fatalf("got request %s %v", got.Method, got.Header)
+	}
+	if string(body) != d.Payload {
+		t.Fatalf("got body %s", body)
+	}
+	// 다른 secret으로 서명하면 수신측에서 거부한다.
+	code, err = sendWebhook(ts.Client(), d, "wrong")
+	if err == nil || code != http.StatusUnauthorized {
+		t.Fatalf("got %d, %v", code, err)
+	}
+}
+
+func TestProcessWebhookProjects(t *testing.T) {
+	var processed []string
+	failed := processWebhookProjects([]string{"CIRCLE", "TEMP", "TEST"}, func(project string) error {
+		processed = append(processed, project)
+		if project == "TEMP" {
+			return errors.New("DB 에러")
+		}
+		return nil
+	})
+	// TEMP 프로젝트가 실패하더라도 다음 프로젝트를 처리해야 한다.
+	if !reflect.DeepEqual(processed, []string{"CIRCLE", "TEMP", "TEST"}) {
+		t.Fatalf("처리한 프로젝트: 얻은 값 %v", processed)
+	}
+	if !reflect.DeepEqual(failed, []string{"TEMP"}) {
+		t.Fatalf("실패한 프로젝트: 얻은 값 %v", failed)
+	}
+}